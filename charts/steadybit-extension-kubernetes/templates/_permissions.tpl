@@ -26,6 +26,15 @@ permissions for clusterrole or role
       - get
       - list
       - watch
+  {{/* Required for Events Discovery via the events.k8s.io/v1 API */}}
+  - apiGroups:
+      - events.k8s.io
+    resources:
+      - events
+    verbs:
+      - get
+      - list
+      - watch
   {{/* Required for Single-Replica-Advice */}}
   - apiGroups:
       - autoscaling
@@ -44,6 +53,16 @@ permissions for clusterrole or role
       - get
       - list
       - watch
+  {{- if or (not .Values.discovery.disabled.deployment) (not .Values.discovery.disabled.statefulSet) (not .Values.discovery.disabled.daemonSet) (not .Values.discovery.disabled.cluster) }}
+  {{/* Required for Resource Usage Metrics Actions */}}
+  - apiGroups:
+      - metrics.k8s.io
+    resources:
+      - pods
+    verbs:
+      - get
+      - list
+  {{- end }}
   {{- if not .Values.discovery.disabled.deployment }}
   {{/* Required for Rollout Restart Attack */}}
   - apiGroups:
@@ -141,6 +160,21 @@ permissions for clusterrole or role
       - get
       - list
       - watch
+  {{/* Required for Envoy Gateway gRPC Route Discovery */}}
+  - apiGroups: ["gateway.networking.k8s.io"]
+    resources:
+      - grpcroutes
+    verbs:
+      - get
+      - list
+      - watch
+  {{/* Required for Envoy Gateway HTTP Route Attacks with request conditions (temporary route rules) */}}
+  - apiGroups: ["gateway.networking.k8s.io"]
+    resources:
+      - httproutes
+    verbs:
+      - update
+      - patch
   {{/* Required for Envoy Gateway HTTP Route Attacks (BackendTrafficPolicy) */}}
   - apiGroups: ["gateway.envoyproxy.io"]
     resources:
@@ -154,4 +188,58 @@ permissions for clusterrole or role
       - patch
       - delete
   {{- end }}
+  {{- if not .Values.discovery.disabled.traefik }}
+  {{/* Required for Traefik Route Discovery and Attacks (Middleware attached to the IngressRoute) */}}
+  - apiGroups: ["traefik.io"]
+    resources:
+      - ingressroutes
+    verbs:
+      - get
+      - list
+      - watch
+      - update
+      - patch
+  - apiGroups: ["traefik.io"]
+    resources:
+      - middlewares
+    verbs:
+      - get
+      - list
+      - watch
+      - create
+      - delete
+  {{- end }}
+  {{- if not .Values.discovery.disabled.istio }}
+  {{/* Required for Istio VirtualService Discovery and Attacks (fault routes injected into the VirtualService) */}}
+  - apiGroups: ["networking.istio.io"]
+    resources:
+      - virtualservices
+    verbs:
+      - get
+      - list
+      - watch
+      - update
+      - patch
+  {{- end }}
+  {{- if not .Values.discovery.disabled.gatewayApi }}
+  {{/* Required for Gateway API HTTP Route Discovery */}}
+  - apiGroups: ["gateway.networking.k8s.io"]
+    resources:
+      - gateways
+      - gatewayclasses
+    verbs:
+      - get
+      - list
+      - watch
+  {{/* Required for Gateway API HTTP Route Discovery and Attacks (rules of the HTTPRoute modified) */}}
+  - apiGroups: ["gateway.networking.k8s.io"]
+    resources:
+      - httproutes
+    verbs:
+      - get
+      - list
+      - watch
+      - update
+      - patch
+  {{- end }}
 {{- end -}}
@@ -5,10 +5,13 @@ package client
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"reflect"
 	"slices"
@@ -24,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -64,12 +68,23 @@ const GatewayNetworkingGroup = "gateway.networking.k8s.io"
 // EnvoyGatewayGroup is the Envoy Gateway resource group.
 const EnvoyGatewayGroup = "gateway.envoyproxy.io"
 
+// TraefikGroup is the Traefik resource group.
+const TraefikGroup = "traefik.io"
+
+// IstioNetworkingGroup is the Istio networking resource group.
+const IstioNetworkingGroup = "networking.istio.io"
+
 var (
 	HTTPRouteGVR = schema.GroupVersionResource{
 		Group:    GatewayNetworkingGroup,
 		Version:  "v1",
 		Resource: "httproutes",
 	}
+	GRPCRouteGVR = schema.GroupVersionResource{
+		Group:    GatewayNetworkingGroup,
+		Version:  "v1",
+		Resource: "grpcroutes",
+	}
 	GatewayGVR = schema.GroupVersionResource{
 		Group:    GatewayNetworkingGroup,
 		Version:  "v1",
@@ -85,6 +100,21 @@ var (
 		Version:  "v1alpha1",
 		Resource: "backendtrafficpolicies",
 	}
+	TraefikIngressRouteGVR = schema.GroupVersionResource{
+		Group:    TraefikGroup,
+		Version:  "v1alpha1",
+		Resource: "ingressroutes",
+	}
+	TraefikMiddlewareGVR = schema.GroupVersionResource{
+		Group:    TraefikGroup,
+		Version:  "v1alpha1",
+		Resource: "middlewares",
+	}
+	IstioVirtualServiceGVR = schema.GroupVersionResource{
+		Group:    IstioNetworkingGroup,
+		Version:  "v1beta1",
+		Resource: "virtualservices",
+	}
 )
 
 type Client struct {
@@ -96,12 +126,21 @@ type Client struct {
 		informer cache.SharedIndexInformer
 	}
 
-	envoyGateway struct {
+	gatewayApi struct {
 		httpRouteInformer    cache.SharedIndexInformer
+		grpcRouteInformer    cache.SharedIndexInformer
 		gatewayInformer      cache.SharedIndexInformer
 		gatewayClassInformer cache.SharedIndexInformer
 	}
 
+	traefik struct {
+		ingressRouteInformer cache.SharedIndexInformer
+	}
+
+	istio struct {
+		virtualServiceInformer cache.SharedIndexInformer
+	}
+
 	daemonSet struct {
 		lister   listerAppsv1.DaemonSetLister
 		informer cache.SharedIndexInformer
@@ -169,6 +208,8 @@ type Client struct {
 	handlers struct {
 		sync.Mutex
 		l []chan<- any
+		// specChanges are only notified of updates which change more than the status of an object
+		specChanges []chan<- any
 	}
 	resourceEventHandler cache.ResourceEventHandlerFuncs
 	networkingV1         networkingv1client.NetworkingV1Interface
@@ -346,22 +387,35 @@ func (c *Client) PodsByLabelSelector(labelSelector *metav1.LabelSelector, namesp
 // PodsByOwnerUid returns all running pods that have the given owner UID in their OwnerReferences.
 // This is more accurate than PodsByLabelSelector when selectors are misconfigured.
 func (c *Client) PodsByOwnerUid(ownerUid types.UID, namespace string) []*corev1.Pod {
-	allPods, err := c.pod.lister.Pods(namespace).List(labels.Everything())
+	pods := c.podsByIndex(podOwnerUidIndex, string(ownerUid))
+	if namespace != "" {
+		pods = slices.DeleteFunc(pods, func(pod *corev1.Pod) bool { return pod.Namespace != namespace })
+	}
+	return c.onlyRunningPods(pods)
+}
+
+// PodsByNodeName returns all running pods scheduled on the node.
+func (c *Client) PodsByNodeName(nodeName string) []*corev1.Pod {
+	pods := c.podsByIndex(podNodeNameIndex, nodeName)
+	if extconfig.HasNamespaceFilter() {
+		pods = slices.DeleteFunc(pods, func(pod *corev1.Pod) bool { return pod.Namespace != extconfig.Config.Namespace })
+	}
+	return c.onlyRunningPods(pods)
+}
+
+func (c *Client) podsByIndex(indexName, value string) []*corev1.Pod {
+	items, err := c.pod.informer.GetIndexer().ByIndex(indexName, value)
 	if err != nil {
-		log.Error().Err(err).Msgf("Error while fetching Pods in namespace %s", namespace)
+		log.Error().Err(err).Msgf("Error while fetching pods by %s %s", indexName, value)
 		return nil
 	}
-
-	var result []*corev1.Pod
-	for _, pod := range allPods {
-		for _, ref := range pod.OwnerReferences {
-			if ref.UID == ownerUid {
-				result = append(result, pod)
-				break
-			}
+	pods := make([]*corev1.Pod, 0, len(items))
+	for _, item := range items {
+		if pod, ok := item.(*corev1.Pod); ok {
+			pods = append(pods, pod)
 		}
 	}
-	return c.onlyRunningPods(result)
+	return pods
 }
 
 // PodsOwnedByDeployment returns all running pods owned by the deployment via its ReplicaSets.
@@ -404,7 +458,7 @@ func (c *Client) PodsOwnedByDeployment(deploymentUid types.UID, namespace string
 }
 
 // ExecInPod executes a command in a pod container and returns the output
-func (c *Client) ExecInPod(_ context.Context, namespace, podName, containerName string, command []string) (string, error) {
+func (c *Client) ExecInPod(ctx context.Context, namespace, podName, containerName string, command []string) (string, error) {
 	config := c.GetConfig()
 
 	// Check if we have a valid REST config
@@ -433,7 +487,7 @@ func (c *Client) ExecInPod(_ context.Context, namespace, podName, containerName
 	}
 
 	var stdout, stderr bytes.Buffer
-	err = executor.Stream(remotecommand.StreamOptions{
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
 		Stdout: &stdout,
 		Stderr: &stderr,
 	})
@@ -530,15 +584,45 @@ func listUnstructuredFromInformer(informer cache.SharedIndexInformer) []*unstruc
 }
 
 func (c *Client) HTTPRoutes() []*unstructured.Unstructured {
-	return listUnstructuredFromInformer(c.envoyGateway.httpRouteInformer)
+	return listUnstructuredFromInformer(c.gatewayApi.httpRouteInformer)
+}
+
+func (c *Client) GRPCRoutes() []*unstructured.Unstructured {
+	return listUnstructuredFromInformer(c.gatewayApi.grpcRouteInformer)
 }
 
 func (c *Client) Gateways() []*unstructured.Unstructured {
-	return listUnstructuredFromInformer(c.envoyGateway.gatewayInformer)
+	return listUnstructuredFromInformer(c.gatewayApi.gatewayInformer)
 }
 
 func (c *Client) GatewayClasses() []*unstructured.Unstructured {
-	return listUnstructuredFromInformer(c.envoyGateway.gatewayClassInformer)
+	return listUnstructuredFromInformer(c.gatewayApi.gatewayClassInformer)
+}
+
+func (c *Client) TraefikIngressRoutes() []*unstructured.Unstructured {
+	return listUnstructuredFromInformer(c.traefik.ingressRouteInformer)
+}
+
+func (c *Client) IstioVirtualServices() []*unstructured.Unstructured {
+	return listUnstructuredFromInformer(c.istio.virtualServiceInformer)
+}
+
+func (c *Client) Services() []*corev1.Service {
+	if extconfig.HasNamespaceFilter() {
+		services, err := c.service.lister.Services(extconfig.Config.Namespace).List(labels.Everything())
+		if err != nil {
+			log.Error().Err(err).Msgf("Error while fetching Services")
+			return []*corev1.Service{}
+		}
+		return services
+	} else {
+		services, err := c.service.lister.List(labels.Everything())
+		if err != nil {
+			log.Error().Err(err).Msgf("Error while fetching Services")
+			return []*corev1.Service{}
+		}
+		return services
+	}
 }
 
 func (c *Client) ServicesByPod(pod *corev1.Pod) []*corev1.Service {
@@ -683,6 +767,15 @@ func (c *Client) Nodes() []*corev1.Node {
 	return nodes
 }
 
+func (c *Client) NodeByName(name string) *corev1.Node {
+	if extconfig.HasNamespaceFilter() {
+		return nil
+	}
+	item, err := c.node.lister.Get(name)
+	logGetError(fmt.Sprintf("node %s", name), err)
+	return item
+}
+
 func (c *Client) Events(since time.Time) *[]corev1.Event {
 	// Check if event informer is initialized (maybe nil in tests)
 	if c.event.informer == nil {
@@ -694,7 +787,7 @@ func (c *Client) Events(since time.Time) *[]corev1.Event {
 	result := filterEvents(events, since)
 	//sort events by time
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].LastTimestamp.Time.Before(result[j].LastTimestamp.Time)
+		return EventTimestamp(&result[i]).Before(EventTimestamp(&result[j]))
 	})
 	return &result
 }
@@ -797,6 +890,17 @@ func (c *Client) GetHAProxyIngressClasses() ([]string, bool) {
 	return c.getIngressClassesForControllers("haproxy.org/ingress-controller/haproxy")
 }
 
+func (c *Client) GetTraefikIngressClasses() ([]string, bool) {
+	traefikClassNames, hasDefaultClass := c.getIngressClassesForControllers("traefik.io/ingress-controller")
+
+	// Traefik also serves the "traefik" class when no IngressClass exists for it
+	if !slices.Contains(traefikClassNames, "traefik") {
+		traefikClassNames = append(traefikClassNames, "traefik")
+	}
+
+	return traefikClassNames, hasDefaultClass
+}
+
 func (c *Client) GetNginxIngressClasses() ([]string, bool) {
 	nginxClassNames, hasDefaultClass := c.getIngressClassesForControllers(
 		"k8s.io/ingress-nginx",         // Open source NGINX Ingress Controller
@@ -860,9 +964,18 @@ func logGetError(resource string, err error) {
 
 func filterEvents(events []any, since time.Time) []corev1.Event {
 	var filtered []corev1.Event
-	for _, event := range events {
-		if event.(*corev1.Event).LastTimestamp.Time.After(since) {
-			filtered = append(filtered, *event.(*corev1.Event))
+	for _, obj := range events {
+		var event corev1.Event
+		switch e := obj.(type) {
+		case *corev1.Event:
+			event = *e
+		case *eventsv1.Event:
+			event = toCoreEvent(e)
+		default:
+			continue
+		}
+		if EventTimestamp(&event).After(since) {
+			filtered = append(filtered, event)
 		}
 	}
 	return filtered
@@ -872,13 +985,10 @@ func PrepareClient(stopCh <-chan struct{}) {
 	clientset, rootApiPath, config := createClientset()
 	permissions := checkPermissions(clientset)
 
-	var dynamicClient dynamic.Interface
-	if !extconfig.Config.DiscoveryDisabledArgoRollout || !extconfig.Config.DiscoveryDisabledEnvoyGateway {
-		var err error
-		dynamicClient, err = dynamic.NewForConfig(config)
-		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to create dynamic client")
-		}
+	// The dynamic client is always needed, at least for reading the metrics.k8s.io API.
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create dynamic client")
 	}
 
 	K8S = CreateClient(clientset, stopCh, rootApiPath, permissions, dynamicClient)
@@ -906,24 +1016,27 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 
 	client.resourceEventHandler = cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj any) {
-			client.doNotify(obj)
+			client.doNotify(obj, true)
 		},
 		UpdateFunc: func(oldObj, newObj any) {
-			client.doNotify(newObj)
+			client.doNotify(newObj, !isStatusUpdate(oldObj, newObj))
 		},
 		DeleteFunc: func(obj any) {
-			client.doNotify(obj)
+			client.doNotify(obj, true)
 		},
 	}
 
 	var informerSyncList []cache.InformerSynced
 	var dynamicFactory dynamicinformer.DynamicSharedInformerFactory
 
-	// Envoy Gateway discovery relies on cluster-scoped (GatewayClass) and cross-namespace
-	// (Gateway) resources, so it is only supported when no namespace filter is configured.
-	envoyGatewayEnabled := !extconfig.Config.DiscoveryDisabledEnvoyGateway && !extconfig.HasNamespaceFilter()
+	// Gateway API discovery (provider-neutral and Envoy Gateway) relies on cluster-scoped (GatewayClass) and
+	// cross-namespace (Gateway) resources, so it is only supported when no namespace filter is configured.
+	gatewayApiEnabled := (!extconfig.Config.DiscoveryDisabledEnvoyGateway || !extconfig.Config.DiscoveryDisabledGatewayApi) && !extconfig.HasNamespaceFilter()
+	// Traefik discovery also covers Ingresses with a Traefik IngressClass, which are only watched without a
+	// namespace filter.
+	traefikEnabled := !extconfig.Config.DiscoveryDisabledTraefik && !extconfig.HasNamespaceFilter()
 
-	if !extconfig.Config.DiscoveryDisabledArgoRollout || envoyGatewayEnabled {
+	if !extconfig.Config.DiscoveryDisabledArgoRollout || gatewayApiEnabled || traefikEnabled || !extconfig.Config.DiscoveryDisabledIstio {
 		if extconfig.HasNamespaceFilter() {
 			dynamicFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(
 				dynamicClient,
@@ -952,26 +1065,53 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 		}
 	}
 
-	// Initialize Envoy Gateway informers (HTTPRoute, Gateway, GatewayClass) if enabled.
-	// Like Argo Rollouts, these CRDs may not be installed yet, so we do not block readiness
-	// on their sync.
-	if envoyGatewayEnabled {
+	// Initialize the Gateway API informers (HTTPRoute, GRPCRoute, Gateway, GatewayClass) if enabled. They are
+	// shared by the provider-neutral and the Envoy Gateway discovery. Like Argo Rollouts, these CRDs
+	// may not be installed yet, so we do not block readiness on their sync.
+	if gatewayApiEnabled {
 		httpRouteInformer := dynamicFactory.ForResource(HTTPRouteGVR)
-		client.envoyGateway.httpRouteInformer = httpRouteInformer.Informer()
+		client.gatewayApi.httpRouteInformer = httpRouteInformer.Informer()
 		gatewayInformer := dynamicFactory.ForResource(GatewayGVR)
-		client.envoyGateway.gatewayInformer = gatewayInformer.Informer()
+		client.gatewayApi.gatewayInformer = gatewayInformer.Informer()
 		gatewayClassInformer := dynamicFactory.ForResource(GatewayClassGVR)
-		client.envoyGateway.gatewayClassInformer = gatewayClassInformer.Informer()
-		log.Info().Msg("Envoy Gateway informers initialized (sync not required for readiness)")
+		client.gatewayApi.gatewayClassInformer = gatewayClassInformer.Informer()
+		log.Info().Msg("Gateway API informers initialized (sync not required for readiness)")
 		for _, informer := range []cache.SharedIndexInformer{
-			client.envoyGateway.httpRouteInformer,
-			client.envoyGateway.gatewayInformer,
-			client.envoyGateway.gatewayClassInformer,
+			client.gatewayApi.httpRouteInformer,
+			client.gatewayApi.gatewayInformer,
+			client.gatewayApi.gatewayClassInformer,
 		} {
 			if _, err := informer.AddEventHandler(client.resourceEventHandler); err != nil {
-				log.Fatal().Err(err).Msg("failed to add envoy gateway event handler")
+				log.Fatal().Err(err).Msg("failed to add gateway api event handler")
 			}
 		}
+		// GRPCRoutes are only attacked via Envoy Gateway BackendTrafficPolicies.
+		if !extconfig.Config.DiscoveryDisabledEnvoyGateway {
+			client.gatewayApi.grpcRouteInformer = dynamicFactory.ForResource(GRPCRouteGVR).Informer()
+			if _, err := client.gatewayApi.grpcRouteInformer.AddEventHandler(client.resourceEventHandler); err != nil {
+				log.Fatal().Err(err).Msg("failed to add gateway api event handler")
+			}
+		}
+	}
+
+	// Initialize the Traefik IngressRoute informer if enabled. The CRD may not be installed, so we do not block
+	// readiness on its sync either.
+	if traefikEnabled {
+		client.traefik.ingressRouteInformer = dynamicFactory.ForResource(TraefikIngressRouteGVR).Informer()
+		log.Info().Msg("Traefik informers initialized (sync not required for readiness)")
+		if _, err := client.traefik.ingressRouteInformer.AddEventHandler(client.resourceEventHandler); err != nil {
+			log.Fatal().Err(err).Msg("failed to add traefik event handler")
+		}
+	}
+
+	// Initialize the Istio VirtualService informer if enabled. VirtualServices are namespaced, so the informer
+	// respects the namespace filter. The CRD may not be installed, so we do not block readiness on its sync either.
+	if !extconfig.Config.DiscoveryDisabledIstio {
+		client.istio.virtualServiceInformer = dynamicFactory.ForResource(IstioVirtualServiceGVR).Informer()
+		log.Info().Msg("Istio informers initialized (sync not required for readiness)")
+		if _, err := client.istio.virtualServiceInformer.AddEventHandler(client.resourceEventHandler); err != nil {
+			log.Fatal().Err(err).Msg("failed to add istio event handler")
+		}
 	}
 
 	daemonSets := factory.Apps().V1().DaemonSets()
@@ -1003,6 +1143,9 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	if err := client.pod.informer.SetTransform(transformPod); err != nil {
 		log.Fatal().Err(err).Msg("Failed to add pod transformer")
 	}
+	if err := client.pod.informer.AddIndexers(podIndexers); err != nil {
+		log.Fatal().Err(err).Msg("Failed to add pod indexers")
+	}
 	if _, err := client.pod.informer.AddEventHandler(client.resourceEventHandler); err != nil {
 		log.Fatal().Msg("failed to add pod event handler")
 	}
@@ -1121,8 +1264,12 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 		}
 	}
 
-	events := factory.Core().V1().Events()
-	client.event.informer = events.Informer()
+	if permissions.CanReadEventsV1() && isEventsV1Available(clientset) {
+		log.Info().Msg("Using events.k8s.io/v1 API for events.")
+		client.event.informer = factory.Events().V1().Events().Informer()
+	} else {
+		client.event.informer = factory.Core().V1().Events().Informer()
+	}
 	informerSyncList = append(informerSyncList, client.event.informer.HasSynced)
 	if err := client.event.informer.SetTransform(transformEvents); err != nil {
 		log.Fatal().Err(err).Msg("Failed to add events transformer")
@@ -1143,12 +1290,33 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	return client
 }
 
-func (c *Client) doNotify(event any) {
+func (c *Client) doNotify(event any, specChanged bool) {
 	c.handlers.Lock()
 	defer c.handlers.Unlock()
 	for _, ch := range c.handlers.l {
 		ch <- event
 	}
+	if specChanged {
+		for _, ch := range c.handlers.specChanges {
+			ch <- event
+		}
+	}
+}
+
+// isStatusUpdate reports whether an update left the generation, the labels and the annotations of the object unchanged.
+// Objects without generation, e.g. Services, are never considered status updates.
+func isStatusUpdate(oldObj, newObj any) bool {
+	oldMeta, ok := oldObj.(metav1.Object)
+	if !ok {
+		return false
+	}
+	newMeta, ok := newObj.(metav1.Object)
+	if !ok || newMeta.GetGeneration() == 0 {
+		return false
+	}
+	return oldMeta.GetGeneration() == newMeta.GetGeneration() &&
+		maps.Equal(oldMeta.GetLabels(), newMeta.GetLabels()) &&
+		maps.Equal(oldMeta.GetAnnotations(), newMeta.GetAnnotations())
 }
 
 func (c *Client) Notify(ch chan<- any) {
@@ -1159,12 +1327,32 @@ func (c *Client) Notify(ch chan<- any) {
 	}
 }
 
+// NotifySpecChanges is like Notify, but skips updates which only change the status of an object.
+func (c *Client) NotifySpecChanges(ch chan<- any) {
+	c.handlers.Lock()
+	defer c.handlers.Unlock()
+	if !slices.Contains(c.handlers.specChanges, ch) {
+		c.handlers.specChanges = append(c.handlers.specChanges, ch)
+	}
+}
+
 func (c *Client) StopNotify(ch chan<- any) {
 	c.handlers.Lock()
 	defer c.handlers.Unlock()
-	c.handlers.l = slices.DeleteFunc(c.handlers.l, func(e chan<- any) bool {
+	isCh := func(e chan<- any) bool {
 		return e == ch
-	})
+	}
+	c.handlers.l = slices.DeleteFunc(c.handlers.l, isCh)
+	c.handlers.specChanges = slices.DeleteFunc(c.handlers.specChanges, isCh)
+}
+
+// GetIngress reads the Ingress from the API server instead of the informer cache.
+func (c *Client) GetIngress(ctx context.Context, namespace, name string) (*networkingv1.Ingress, error) {
+	ingress, err := c.clientset.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingress %s/%s: %w", namespace, name, err)
+	}
+	return ingress, nil
 }
 
 func (c *Client) IngressByNamespaceAndName(namespace string, name string, forceUpdate ...bool) (*networkingv1.Ingress, error) {
@@ -1229,7 +1417,7 @@ func (c *Client) UpdateIngressAnnotation(ctx context.Context, namespace string,
 		ingress.Annotations[annotationKey] = newValue
 
 		log.Trace().
-			Stringer("diff", unifiedDiff{a: currentValue, b: newValue}).
+			Stringer("diff", UnifiedDiff{A: currentValue, B: newValue}).
 			Str("namespace", namespace).
 			Str("ingress", ingressName).
 			Msg("Updating ingress annotation")
@@ -1285,12 +1473,12 @@ func (c *Client) checkIngressEvents(namespace, ingressName string, since time.Ti
 				case "Rejected", "InvalidConfiguration", "ConfigurationError", "SyncError", "AddedOrUpdatedWithError":
 					return fmt.Errorf("ingress configuration rejected - Type:%s Reason:%s Age:%s Message:%s",
 						event.Type, event.Reason,
-						time.Since(event.LastTimestamp.Time).Round(time.Second),
+						time.Since(EventTimestamp(&event)).Round(time.Second),
 						event.Message)
 				default:
 					log.Warn().Msgf("Ingress warning event detected - Type:%s Reason:%s Age:%s Message:%s",
 						event.Type, event.Reason,
-						time.Since(event.LastTimestamp.Time).Round(time.Second),
+						time.Since(EventTimestamp(&event)).Round(time.Second),
 						event.Message)
 				}
 			}
@@ -1324,7 +1512,7 @@ func (c *Client) RemoveIngressAnnotationBlock(ctx context.Context, namespace str
 		ingress.Annotations[annotationKey] = newValue
 
 		log.Trace().
-			Stringer("diff", unifiedDiff{a: currentValue, b: newValue}).
+			Stringer("diff", UnifiedDiff{A: currentValue, B: newValue}).
 			Str("namespace", namespace).
 			Str("ingress", ingressName).
 			Msg("Updating ingress annotation")
@@ -1369,6 +1557,45 @@ func removeAnnotationBlock(config, startMarker, endMarker string) string {
 	return config[:startIndex] + config[endOfMarker:]
 }
 
+// AnnotationManager returns the field manager which last wrote the given annotation, e.g. "argocd-controller" or
+// "kustomize-controller". If no manager owns the annotation, because it was removed, the manager of the most recent
+// change of the object is returned.
+func AnnotationManager(obj metav1.Object, annotationKey string) string {
+	var owner, latest *metav1.ManagedFieldsEntry
+	for i := range obj.GetManagedFields() {
+		entry := &obj.GetManagedFields()[i]
+		if latest == nil || managedFieldsEntryAfter(entry, latest) {
+			latest = entry
+		}
+		if ownsAnnotation(entry, annotationKey) && (owner == nil || managedFieldsEntryAfter(entry, owner)) {
+			owner = entry
+		}
+	}
+	if owner != nil {
+		return owner.Manager
+	}
+	if latest != nil {
+		return latest.Manager
+	}
+	return ""
+}
+
+func managedFieldsEntryAfter(a, b *metav1.ManagedFieldsEntry) bool {
+	return a.Time != nil && (b.Time == nil || a.Time.After(b.Time.Time))
+}
+
+func ownsAnnotation(entry *metav1.ManagedFieldsEntry, annotationKey string) bool {
+	if entry.FieldsV1 == nil {
+		return false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+		return false
+	}
+	_, found, _ := unstructured.NestedFieldNoCopy(fields, "f:metadata", "f:annotations", "f:"+annotationKey)
+	return found
+}
+
 func isOpenShift(rootApiPath string) bool {
 	return rootApiPath == "/oapi" || rootApiPath == "oapi"
 }
@@ -1430,10 +1657,16 @@ func IsExcludedFromDiscovery(objectMeta metav1.ObjectMeta) bool {
 	return false
 }
 
-type unifiedDiff struct {
-	a, b string
+// UnifiedDiff renders the unified diff between A and B or an empty string if they are equal. As a fmt.Stringer the diff
+// is only computed when it is logged.
+type UnifiedDiff struct {
+	OldLabel, NewLabel string
+	A, B               string
 }
 
-func (d unifiedDiff) String() string {
-	return udiff.Unified("old", "new", d.a, d.b)
+func (d UnifiedDiff) String() string {
+	if d.A == d.B {
+		return ""
+	}
+	return udiff.Unified(cmp.Or(d.OldLabel, "old"), cmp.Or(d.NewLabel, "new"), d.A, d.B)
 }
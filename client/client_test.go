@@ -9,10 +9,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -538,3 +541,70 @@ func TestUpdateIngressAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnotationManager(t *testing.T) {
+	annotationFields := func(key string) *metav1.FieldsV1 {
+		return &metav1.FieldsV1{Raw: []byte(fmt.Sprintf(`{"f:metadata":{"f:annotations":{"f:%s":{}}}}`, key))}
+	}
+	specFields := &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:rules":{}}}`)}
+	at := func(minute int) *metav1.Time {
+		return new(metav1.NewTime(time.Date(2026, 1, 1, 0, minute, 0, 0, time.UTC)))
+	}
+
+	tests := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		want          string
+	}{
+		{
+			name: "latest manager owning the annotation",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "extension-kubernetes", Time: at(1), FieldsV1: annotationFields("example.com/snippet")},
+				{Manager: "argocd-controller", Time: at(2), FieldsV1: annotationFields("example.com/snippet")},
+				{Manager: "kubectl-edit", Time: at(3), FieldsV1: annotationFields("example.com/other")},
+			},
+			want: "argocd-controller",
+		},
+		{
+			name: "latest manager if the annotation was removed",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kustomize-controller", Time: at(2), FieldsV1: specFields},
+				{Manager: "kube-controller-manager", Time: at(1), FieldsV1: specFields},
+			},
+			want: "kustomize-controller",
+		},
+		{
+			name: "no managed fields",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{ManagedFields: tt.managedFields}}
+			assert.Equal(t, tt.want, AnnotationManager(ingress, "example.com/snippet"))
+		})
+	}
+}
+
+func Test_isStatusUpdate(t *testing.T) {
+	deployment := func(generation int64, labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "shop", Generation: generation, Labels: labels}}
+	}
+	tests := []struct {
+		name   string
+		oldObj any
+		newObj any
+		want   bool
+	}{
+		{name: "same generation", oldObj: deployment(1, nil), newObj: deployment(1, nil), want: true},
+		{name: "changed generation", oldObj: deployment(1, nil), newObj: deployment(2, nil), want: false},
+		{name: "changed labels", oldObj: deployment(1, nil), newObj: deployment(1, map[string]string{"app": "shop"}), want: false},
+		{name: "without generation", oldObj: &corev1.Service{}, newObj: &corev1.Service{}, want: false},
+		{name: "tombstone", oldObj: deployment(1, nil), newObj: "shop", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isStatusUpdate(tt.oldObj, tt.newObj))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DiscoveryCache memoizes owner and service lookups during a single discovery run. The pods of a workload share their
+// owners and usually their labels, so the lookups are done once per workload instead of once per pod. A cache must
+// not outlive the discovery run, otherwise it returns stale results.
+type DiscoveryCache struct {
+	k8s      *Client
+	owners   map[string]OwnerRefListWithResource
+	services map[string][]*corev1.Service
+}
+
+func NewDiscoveryCache(k8s *Client) *DiscoveryCache {
+	return &DiscoveryCache{
+		k8s:      k8s,
+		owners:   map[string]OwnerRefListWithResource{},
+		services: map[string][]*corev1.Service{},
+	}
+}
+
+// OwnerReferences is the cached variant of OwnerReferences.
+func (c *DiscoveryCache) OwnerReferences(meta *metav1.ObjectMeta) OwnerRefListWithResource {
+	if len(meta.OwnerReferences) == 0 {
+		return OwnerRefListWithResource{}
+	}
+	var key strings.Builder
+	key.WriteString(meta.Namespace)
+	for _, ref := range meta.OwnerReferences {
+		key.WriteString("/" + ref.Kind + "/" + ref.Name)
+	}
+	if owners, ok := c.owners[key.String()]; ok {
+		return owners
+	}
+	owners := OwnerReferences(c.k8s, meta)
+	c.owners[key.String()] = owners
+	return owners
+}
+
+// ServicesMatchingToPodLabels is the cached variant of Client.ServicesMatchingToPodLabels.
+func (c *DiscoveryCache) ServicesMatchingToPodLabels(namespace string, podLabels map[string]string) []*corev1.Service {
+	key := namespace + "/" + labels.Set(podLabels).String()
+	if services, ok := c.services[key]; ok {
+		return services
+	}
+	services := c.k8s.ServicesMatchingToPodLabels(namespace, podLabels)
+	c.services[key] = services
+	return services
+}
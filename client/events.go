@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// isEventsV1Available checks whether the cluster serves events via the events.k8s.io/v1 API.
+func isEventsV1Available(clientset kubernetes.Interface) bool {
+	resources, err := clientset.Discovery().ServerResourcesForGroupVersion(eventsv1.SchemeGroupVersion.String())
+	if err != nil {
+		log.Debug().Err(err).Msg("events.k8s.io/v1 not available, falling back to core/v1 events")
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "events" {
+			return true
+		}
+	}
+	return false
+}
+
+// toCoreEvent converts events of the events.k8s.io/v1 API into the core/v1 representation, so the rest of the
+// extension only has to deal with a single event type. Both APIs are backed by the same storage, the conversion
+// mirrors the one done by the api server.
+func toCoreEvent(event *eventsv1.Event) corev1.Event {
+	result := corev1.Event{
+		ObjectMeta:          event.ObjectMeta,
+		InvolvedObject:      event.Regarding,
+		Related:             event.Related,
+		Reason:              event.Reason,
+		Message:             event.Note,
+		Type:                event.Type,
+		Action:              event.Action,
+		EventTime:           event.EventTime,
+		FirstTimestamp:      event.DeprecatedFirstTimestamp,
+		LastTimestamp:       event.DeprecatedLastTimestamp,
+		Count:               event.DeprecatedCount,
+		ReportingController: event.ReportingController,
+		ReportingInstance:   event.ReportingInstance,
+		Source: corev1.EventSource{
+			Component: event.DeprecatedSource.Component,
+			Host:      event.DeprecatedSource.Host,
+		},
+	}
+	if result.Source.Component == "" {
+		result.Source.Component = event.ReportingController
+	}
+	if event.Series != nil {
+		result.Series = &corev1.EventSeries{
+			Count:            event.Series.Count,
+			LastObservedTime: event.Series.LastObservedTime,
+		}
+	}
+	return result
+}
+
+// EventTimestamp returns the time the event was last observed. Newer components leave the deprecated
+// lastTimestamp empty and report eventTime and series.lastObservedTime instead.
+func EventTimestamp(event *corev1.Event) time.Time {
+	if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
+		return event.Series.LastObservedTime.Time
+	}
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	if !event.FirstTimestamp.IsZero() {
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// EventCount returns how often the event has been observed, taking the series into account.
+func EventCount(event *corev1.Event) int32 {
+	if event.Series != nil && event.Series.Count > 0 {
+		return event.Series.Count
+	}
+	if event.Count > 0 {
+		return event.Count
+	}
+	return 1
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEventTimestamp(t *testing.T) {
+	created := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	first := created.Add(1 * time.Minute)
+	last := created.Add(2 * time.Minute)
+	eventTime := created.Add(3 * time.Minute)
+	observed := created.Add(4 * time.Minute)
+
+	tests := []struct {
+		name  string
+		event corev1.Event
+		want  time.Time
+	}{
+		{
+			name: "series last observed time",
+			event: corev1.Event{
+				EventTime: metav1.MicroTime{Time: eventTime},
+				Series:    &corev1.EventSeries{Count: 5, LastObservedTime: metav1.MicroTime{Time: observed}},
+			},
+			want: observed,
+		},
+		{
+			name:  "last timestamp",
+			event: corev1.Event{FirstTimestamp: metav1.Time{Time: first}, LastTimestamp: metav1.Time{Time: last}},
+			want:  last,
+		},
+		{
+			name:  "event time",
+			event: corev1.Event{EventTime: metav1.MicroTime{Time: eventTime}},
+			want:  eventTime,
+		},
+		{
+			name:  "first timestamp",
+			event: corev1.Event{FirstTimestamp: metav1.Time{Time: first}},
+			want:  first,
+		},
+		{
+			name:  "creation timestamp",
+			event: corev1.Event{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: created}}},
+			want:  created,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EventTimestamp(&tt.event))
+		})
+	}
+}
+
+func TestEventCount(t *testing.T) {
+	assert.Equal(t, int32(1), EventCount(&corev1.Event{}))
+	assert.Equal(t, int32(3), EventCount(&corev1.Event{Count: 3}))
+	assert.Equal(t, int32(7), EventCount(&corev1.Event{Count: 3, Series: &corev1.EventSeries{Count: 7}}))
+}
+
+func TestEventsUsesEventsV1Api(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	now := time.Now()
+	clientset := fake.NewClientset(
+		&eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: "recent", Namespace: "shop"},
+			Regarding:  corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "checkout-1"},
+			Reason:     "BackOff",
+			Note:       "Back-off restarting failed container",
+			Type:       corev1.EventTypeWarning,
+			EventTime:  metav1.MicroTime{Time: now.Add(-10 * time.Minute)},
+			Series:     &eventsv1.EventSeries{Count: 12, LastObservedTime: metav1.MicroTime{Time: now}},
+		},
+		&eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "shop"},
+			Regarding:  corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "checkout-2"},
+			Note:       "Started container",
+			Type:       corev1.EventTypeNormal,
+			EventTime:  metav1.MicroTime{Time: now.Add(-10 * time.Minute)},
+		},
+	)
+	clientset.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "events.k8s.io/v1", APIResources: []metav1.APIResource{{Name: "events", Kind: "Event", Namespaced: true}}},
+	}
+
+	client := CreateClient(clientset, stopCh, "", MockAllPermitted(), testutil.NewFakeDynamicClient())
+	events := *client.Events(now.Add(-1 * time.Minute))
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "Back-off restarting failed container", events[0].Message)
+	assert.Equal(t, "checkout-1", events[0].InvolvedObject.Name)
+	assert.Equal(t, int32(12), EventCount(&events[0]))
+	assert.Equal(t, now.Truncate(time.Microsecond), EventTimestamp(&events[0]).Truncate(time.Microsecond))
+}
+
+func TestCheckIngressEventsUsesEventsV1Timestamp(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	now := time.Now()
+	clientset := fake.NewClientset(&eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "rejected", Namespace: "shop"},
+		Regarding:  corev1.ObjectReference{Kind: "Ingress", Namespace: "shop", Name: "checkout"},
+		Reason:     "AddedOrUpdatedWithError",
+		Note:       "invalid configuration snippet",
+		Type:       corev1.EventTypeWarning,
+		EventTime:  metav1.MicroTime{Time: now.Add(-5 * time.Second)},
+	})
+	clientset.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "events.k8s.io/v1", APIResources: []metav1.APIResource{{Name: "events", Kind: "Event", Namespaced: true}}},
+	}
+
+	client := CreateClient(clientset, stopCh, "", MockAllPermitted(), testutil.NewFakeDynamicClient())
+	err := client.checkIngressEvents("shop", "checkout", now.Add(-1*time.Minute))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Reason:AddedOrUpdatedWithError Age:5s Message:invalid configuration snippet")
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+)
+
+// GetHTTPRoute reads the HTTPRoute from the API server instead of the informer cache.
+func (c *Client) GetHTTPRoute(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	route, err := c.dynamicClient.Resource(HTTPRouteGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HTTPRoute %s/%s: %w", namespace, name, err)
+	}
+	return route, nil
+}
+
+// UpdateHTTPRoute applies update to the latest version of the HTTPRoute and writes it back, retrying on conflicts.
+// The HTTPRoute is left untouched if update reports no change.
+func (c *Client) UpdateHTTPRoute(ctx context.Context, namespace, name string, update func(route *unstructured.Unstructured) (bool, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		route, err := c.GetHTTPRoute(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+		changed, err := update(route)
+		if err != nil || !changed {
+			return err
+		}
+		_, err = c.dynamicClient.Resource(HTTPRouteGVR).Namespace(namespace).Update(ctx, route, metav1.UpdateOptions{})
+		return err
+	})
+}
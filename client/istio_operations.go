@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+)
+
+// GetIstioVirtualService reads the VirtualService from the API server instead of the informer cache.
+func (c *Client) GetIstioVirtualService(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	virtualService, err := c.dynamicClient.Resource(IstioVirtualServiceGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualService %s/%s: %w", namespace, name, err)
+	}
+	return virtualService, nil
+}
+
+// UpdateIstioVirtualService applies update to the latest version of the VirtualService and writes it back, retrying
+// on conflicts. The VirtualService is left untouched if update reports no change.
+func (c *Client) UpdateIstioVirtualService(ctx context.Context, namespace, name string, update func(virtualService *unstructured.Unstructured) (bool, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		virtualService, err := c.GetIstioVirtualService(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+		changed, err := update(virtualService)
+		if err != nil || !changed {
+			return err
+		}
+		_, err = c.dynamicClient.Resource(IstioVirtualServiceGVR).Namespace(namespace).Update(ctx, virtualService, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MetricsGroup is the resource group served by metrics-server.
+const MetricsGroup = "metrics.k8s.io"
+
+var (
+	PodMetricsGVR = schema.GroupVersionResource{
+		Group:    MetricsGroup,
+		Version:  "v1beta1",
+		Resource: "pods",
+	}
+	NodeMetricsGVR = schema.GroupVersionResource{
+		Group:    MetricsGroup,
+		Version:  "v1beta1",
+		Resource: "nodes",
+	}
+)
+
+// ErrMetricsUnavailable is returned if the metrics.k8s.io API is not served, typically because metrics-server is
+// not installed or not ready.
+var ErrMetricsUnavailable = errors.New("metrics.k8s.io API is not available, is metrics-server installed?")
+
+const metricsRequestTimeout = 10 * time.Second
+
+// ResourceUsage is the cpu and memory usage of a pod (summed up over its containers) or a node as reported by the
+// metrics.k8s.io API.
+type ResourceUsage struct {
+	Namespace string
+	Name      string
+	Timestamp time.Time
+	// CPU usage in millicores
+	CPU int64
+	// Memory usage in bytes
+	Memory int64
+}
+
+// PodMetrics returns the resource usage of all pods in the given namespace (or all namespaces, if empty).
+func (c *Client) PodMetrics(ctx context.Context, namespace string) ([]ResourceUsage, error) {
+	list, err := c.listMetrics(ctx, PodMetricsGVR, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ResourceUsage, 0, len(list.Items))
+	for _, item := range list.Items {
+		containers, _, err := unstructured.NestedSlice(item.Object, "containers")
+		if err != nil {
+			return nil, fmt.Errorf("invalid pod metrics %s/%s: %w", item.GetNamespace(), item.GetName(), err)
+		}
+		usage := ResourceUsage{
+			Namespace: item.GetNamespace(),
+			Name:      item.GetName(),
+			Timestamp: metricsTimestamp(&item),
+		}
+		for _, container := range containers {
+			if values, ok := container.(map[string]any); ok {
+				cpu, memory := parseUsage(values)
+				usage.CPU += cpu
+				usage.Memory += memory
+			}
+		}
+		result = append(result, usage)
+	}
+	return result, nil
+}
+
+// NodeMetrics returns the resource usage of all nodes.
+func (c *Client) NodeMetrics(ctx context.Context) ([]ResourceUsage, error) {
+	list, err := c.listMetrics(ctx, NodeMetricsGVR, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ResourceUsage, 0, len(list.Items))
+	for _, item := range list.Items {
+		cpu, memory := parseUsage(item.Object)
+		result = append(result, ResourceUsage{
+			Name:      item.GetName(),
+			Timestamp: metricsTimestamp(&item),
+			CPU:       cpu,
+			Memory:    memory,
+		})
+	}
+	return result, nil
+}
+
+func (c *Client) listMetrics(ctx context.Context, gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+	if c.dynamicClient == nil {
+		return nil, ErrMetricsUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, metricsRequestTimeout)
+	defer cancel()
+
+	list, err := c.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) || k8sErrors.IsServiceUnavailable(err) || meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("%w: %s", ErrMetricsUnavailable, err.Error())
+		}
+		return nil, fmt.Errorf("failed to list %s: %w", gvr.String(), err)
+	}
+	return list, nil
+}
+
+func metricsTimestamp(item *unstructured.Unstructured) time.Time {
+	value, _, _ := unstructured.NestedString(item.Object, "timestamp")
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp
+	}
+	return time.Now()
+}
+
+func parseUsage(obj map[string]any) (cpu int64, memory int64) {
+	usage, _, _ := unstructured.NestedStringMap(obj, "usage")
+	if q, err := resource.ParseQuantity(usage["cpu"]); err == nil {
+		cpu = q.MilliValue()
+	}
+	if q, err := resource.ParseQuantity(usage["memory"]); err == nil {
+		memory = q.Value()
+	}
+	return cpu, memory
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// SetNodeUnschedulable cordons (or uncordons) the node. It returns false if the node already was in the requested
+// state, so callers can remember whether they have to revert the change.
+func (c *Client) SetNodeUnschedulable(ctx context.Context, nodeName string, unschedulable bool) (bool, error) {
+	node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		return false, nil
+	}
+	patch := fmt.Appendf(nil, `{"spec":{"unschedulable":%t}}`, unschedulable)
+	if _, err := c.clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return false, fmt.Errorf("failed to patch node %s: %w", nodeName, err)
+	}
+	return true, nil
+}
+
+// GetNode reads the node from the API server instead of the informer cache, which only holds a subset of the node.
+func (c *Client) GetNode(ctx context.Context, nodeName string) (*corev1.Node, error) {
+	node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+	return node, nil
+}
+
+// PatchNodeLabels sets the labels of the node, labels with a nil value are removed.
+func (c *Client) PatchNodeLabels(ctx context.Context, nodeName string, labels map[string]*string) error {
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"labels": labels}})
+	if err != nil {
+		return err
+	}
+	if _, err := c.clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch labels of node %s: %w", nodeName, err)
+	}
+	return nil
+}
+
+// AddNodeTaint adds the taint to the node. It returns false if the node already has a taint with the same key and
+// effect.
+func (c *Client) AddNodeTaint(ctx context.Context, nodeName string, taint corev1.Taint) (bool, error) {
+	added := false
+	_, err := c.UpdateNodeTaints(ctx, nodeName, func(taints []corev1.Taint) []corev1.Taint {
+		added = !slices.ContainsFunc(taints, matchesTaint(taint))
+		if !added {
+			return taints
+		}
+		if taint.Effect == corev1.TaintEffectNoExecute && taint.TimeAdded == nil {
+			taint.TimeAdded = new(metav1.Now())
+		}
+		return append(taints, taint)
+	})
+	if err != nil {
+		return false, err
+	}
+	return added, nil
+}
+
+// RemoveNodeTaint removes the taints with the same key and effect from the node. It returns false if there was no such
+// taint.
+func (c *Client) RemoveNodeTaint(ctx context.Context, nodeName string, taint corev1.Taint) (bool, error) {
+	removed := false
+	_, err := c.UpdateNodeTaints(ctx, nodeName, func(taints []corev1.Taint) []corev1.Taint {
+		remaining := slices.DeleteFunc(taints, matchesTaint(taint))
+		removed = len(remaining) != len(taints)
+		return remaining
+	})
+	if err != nil {
+		return false, err
+	}
+	return removed, nil
+}
+
+// UpdateNodeTaints replaces the taints of the node with the result of update, which gets a copy of the current taints.
+// The update is retried on conflicts, so update may be called more than once. It returns the taints before the update.
+func (c *Client) UpdateNodeTaints(ctx context.Context, nodeName string, update func([]corev1.Taint) []corev1.Taint) ([]corev1.Taint, error) {
+	var previous []corev1.Taint
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		previous = node.Spec.Taints
+		taints := update(slices.Clone(node.Spec.Taints))
+		if slices.EqualFunc(taints, previous, func(a, b corev1.Taint) bool { return a.MatchTaint(&b) && a.Value == b.Value }) {
+			return nil
+		}
+		node.Spec.Taints = taints
+		_, err = c.clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update taints of node %s: %w", nodeName, err)
+	}
+	return previous, nil
+}
+
+// matchesTaint matches taints with the same key and effect, like kubectl taint does.
+func matchesTaint(taint corev1.Taint) func(corev1.Taint) bool {
+	return func(other corev1.Taint) bool {
+		return taint.MatchTaint(&other)
+	}
+}
+
+// PodsOnNode lists the pods scheduled on the node from the API server. In contrast to the informer cache the pods are
+// complete, e.g. contain annotations and volumes, which are needed to decide whether a pod can be evicted.
+func (c *Client) PodsOnNode(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	list, err := c.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of node %s: %w", nodeName, err)
+	}
+	// the field selector is not supported by every client (e.g. fakes), so filter again
+	return slices.DeleteFunc(list.Items, func(pod corev1.Pod) bool {
+		return pod.Spec.NodeName != nodeName
+	}), nil
+}
+
+// EvictPod evicts the pod using the Eviction API, which respects PodDisruptionBudgets. An eviction blocked by a
+// PodDisruptionBudget fails with a TooManyRequests error.
+func (c *Client) EvictPod(ctx context.Context, namespace, name string, gracePeriodSeconds *int64) error {
+	return c.clientset.CoreV1().Pods(namespace).EvictV1(ctx, &policyv1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Namespace: namespace, Name: name},
+		DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds},
+	})
+}
+
+// DeletePod deletes the pod without going through the Eviction API, so PodDisruptionBudgets are not respected.
+func (c *Client) DeletePod(ctx context.Context, namespace, name string, gracePeriodSeconds *int64) error {
+	return c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds})
+}
+
+// CreatePod creates the pod and returns it as stored by the API server, e.g. with the name generated for it.
+func (c *Client) CreatePod(ctx context.Context, pod *corev1.Pod) (*corev1.Pod, error) {
+	return c.clientset.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+}
+
+// GetPod reads the pod from the API server instead of the informer cache, which only holds a subset of the pod.
+func (c *Client) GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	return c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+}
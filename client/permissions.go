@@ -56,16 +56,21 @@ var requiredPermissions = []requiredPermission{
 	{group: "", resource: "namespaces", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
 	{group: "", resource: "nodes", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: false},
 	{group: "", resource: "events", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: false},
+	{group: "events.k8s.io", resource: "events", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
 	{group: "apps", resource: "deployments", verbs: []string{"patch"}, allowGracefulFailure: true},
 	{group: "apps", resource: "deployments", subresource: "scale", verbs: []string{"get", "update", "patch"}, allowGracefulFailure: true},
 	{group: "apps", resource: "replicasets", subresource: "scale", verbs: []string{"get", "update", "patch"}, allowGracefulFailure: true},
 	{group: "apps", resource: "statefulsets", subresource: "scale", verbs: []string{"get", "update", "patch"}, allowGracefulFailure: true},
 	{group: "", resource: "pods", verbs: []string{"delete"}, allowGracefulFailure: true},
+	{group: "", resource: "pods", verbs: []string{"create"}, allowGracefulFailure: true},
 	{group: "", resource: "pods", subresource: "eviction", verbs: []string{"create"}, allowGracefulFailure: true},
 	{group: "", resource: "nodes", verbs: []string{"patch"}, allowGracefulFailure: true},
+	{group: "scheduling.k8s.io", resource: "priorityclasses", verbs: []string{"create", "delete"}, allowGracefulFailure: true},
 	{group: "", resource: "pods", subresource: "exec", verbs: []string{"create"}, allowGracefulFailure: true},
 	{group: "networking.k8s.io", resource: "ingresses", verbs: []string{"get", "list", "watch", "update", "patch"}, allowGracefulFailure: true},
 	{group: "networking.k8s.io", resource: "ingressclasses", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: MetricsGroup, resource: "pods", verbs: []string{"get", "list"}, allowGracefulFailure: true},
+	{group: MetricsGroup, resource: "nodes", verbs: []string{"get", "list"}, allowGracefulFailure: true},
 }
 
 var argoRolloutPermissions = []requiredPermission{
@@ -74,12 +79,28 @@ var argoRolloutPermissions = []requiredPermission{
 }
 
 var envoyGatewayPermissions = []requiredPermission{
-	{group: GatewayNetworkingGroup, resource: "httproutes", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: GatewayNetworkingGroup, resource: "httproutes", verbs: []string{"get", "list", "watch", "update", "patch"}, allowGracefulFailure: true},
+	{group: GatewayNetworkingGroup, resource: "grpcroutes", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
 	{group: GatewayNetworkingGroup, resource: "gateways", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
 	{group: GatewayNetworkingGroup, resource: "gatewayclasses", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
 	{group: EnvoyGatewayGroup, resource: "backendtrafficpolicies", verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}, allowGracefulFailure: true},
 }
 
+var gatewayApiPermissions = []requiredPermission{
+	{group: GatewayNetworkingGroup, resource: "httproutes", verbs: []string{"get", "list", "watch", "update", "patch"}, allowGracefulFailure: true},
+	{group: GatewayNetworkingGroup, resource: "gateways", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: GatewayNetworkingGroup, resource: "gatewayclasses", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+}
+
+var traefikPermissions = []requiredPermission{
+	{group: TraefikGroup, resource: "ingressroutes", verbs: []string{"get", "list", "watch", "update", "patch"}, allowGracefulFailure: true},
+	{group: TraefikGroup, resource: "middlewares", verbs: []string{"get", "list", "watch", "create", "delete"}, allowGracefulFailure: true},
+}
+
+var istioPermissions = []requiredPermission{
+	{group: IstioNetworkingGroup, resource: "virtualservices", verbs: []string{"get", "list", "watch", "update", "patch"}, allowGracefulFailure: true},
+}
+
 func getRequiredPermissions() []requiredPermission {
 	permissions := requiredPermissions
 	if !extconfig.Config.DiscoveryDisabledArgoRollout {
@@ -88,6 +109,15 @@ func getRequiredPermissions() []requiredPermission {
 	if !extconfig.Config.DiscoveryDisabledEnvoyGateway {
 		permissions = append(permissions, envoyGatewayPermissions...)
 	}
+	if !extconfig.Config.DiscoveryDisabledGatewayApi {
+		permissions = append(permissions, gatewayApiPermissions...)
+	}
+	if !extconfig.Config.DiscoveryDisabledTraefik {
+		permissions = append(permissions, traefikPermissions...)
+	}
+	if !extconfig.Config.DiscoveryDisabledIstio {
+		permissions = append(permissions, istioPermissions...)
+	}
 	return permissions
 }
 
@@ -186,6 +216,19 @@ func (p *PermissionCheckResult) CanReadNamespaces() bool {
 		"namespaces/watch"})
 }
 
+func (p *PermissionCheckResult) CanReadEventsV1() bool {
+	return p.hasPermissions([]string{
+		"events.k8s.io/events/get",
+		"events.k8s.io/events/list",
+		"events.k8s.io/events/watch"})
+}
+
+func (p *PermissionCheckResult) CanReadPodMetrics() bool {
+	return p.hasPermissions([]string{
+		"metrics.k8s.io/pods/get",
+		"metrics.k8s.io/pods/list"})
+}
+
 func (p *PermissionCheckResult) IsRolloutRestartPermitted() bool {
 	return p.hasPermissions([]string{
 		"apps/deployments/patch",
@@ -229,6 +272,28 @@ func (p *PermissionCheckResult) IsDrainNodePermitted() bool {
 	})
 }
 
+func (p *PermissionCheckResult) IsNodeLabelPermitted() bool {
+	return p.hasPermissions([]string{
+		"nodes/patch",
+	})
+}
+
+func (p *PermissionCheckResult) IsCapacitySqueezePermitted() bool {
+	return p.hasPermissions([]string{
+		"pods/create",
+		"pods/delete",
+	})
+}
+
+func (p *PermissionCheckResult) IsPreemptionPermitted() bool {
+	return p.hasPermissions([]string{
+		"pods/create",
+		"pods/delete",
+		"scheduling.k8s.io/priorityclasses/create",
+		"scheduling.k8s.io/priorityclasses/delete",
+	})
+}
+
 func (p *PermissionCheckResult) IsListIngressPermitted() bool {
 	return p.hasPermissions([]string{
 		"networking.k8s.io/ingresses/get",
@@ -294,6 +359,14 @@ func (p *PermissionCheckResult) IsListEnvoyGatewayHttpRoutesPermitted() bool {
 	})
 }
 
+func (p *PermissionCheckResult) IsListEnvoyGatewayGrpcRoutesPermitted() bool {
+	return p.hasPermissions([]string{
+		"gateway.networking.k8s.io/grpcroutes/get",
+		"gateway.networking.k8s.io/grpcroutes/list",
+		"gateway.networking.k8s.io/grpcroutes/watch",
+	})
+}
+
 func (p *PermissionCheckResult) IsModifyBackendTrafficPolicyPermitted() bool {
 	return p.hasPermissions([]string{
 		"gateway.envoyproxy.io/backendtrafficpolicies/get",
@@ -303,6 +376,55 @@ func (p *PermissionCheckResult) IsModifyBackendTrafficPolicyPermitted() bool {
 	})
 }
 
+func (p *PermissionCheckResult) IsListGatewayApiHttpRoutesPermitted() bool {
+	return p.hasPermissions([]string{
+		"gateway.networking.k8s.io/httproutes/get",
+		"gateway.networking.k8s.io/httproutes/list",
+		"gateway.networking.k8s.io/httproutes/watch",
+		"gateway.networking.k8s.io/gateways/list",
+		"gateway.networking.k8s.io/gatewayclasses/list",
+	})
+}
+
+func (p *PermissionCheckResult) IsModifyHttpRoutesPermitted() bool {
+	return p.hasPermissions([]string{
+		"gateway.networking.k8s.io/httproutes/get",
+		"gateway.networking.k8s.io/httproutes/update",
+	})
+}
+
+func (p *PermissionCheckResult) IsListTraefikIngressRoutesPermitted() bool {
+	return p.hasPermissions([]string{
+		"traefik.io/ingressroutes/get",
+		"traefik.io/ingressroutes/list",
+		"traefik.io/ingressroutes/watch",
+	})
+}
+
+func (p *PermissionCheckResult) IsModifyTraefikMiddlewarePermitted() bool {
+	return p.hasPermissions([]string{
+		"traefik.io/ingressroutes/update",
+		"traefik.io/middlewares/get",
+		"traefik.io/middlewares/create",
+		"traefik.io/middlewares/delete",
+	})
+}
+
+func (p *PermissionCheckResult) IsListIstioVirtualServicesPermitted() bool {
+	return p.hasPermissions([]string{
+		"networking.istio.io/virtualservices/get",
+		"networking.istio.io/virtualservices/list",
+		"networking.istio.io/virtualservices/watch",
+	})
+}
+
+func (p *PermissionCheckResult) IsModifyIstioVirtualServicesPermitted() bool {
+	return p.hasPermissions([]string{
+		"networking.istio.io/virtualservices/get",
+		"networking.istio.io/virtualservices/update",
+	})
+}
+
 func MockAllPermitted() *PermissionCheckResult {
 	result := make(map[string]PermissionCheckOutcome)
 	for _, p := range getRequiredPermissions() {
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	podNodeNameIndex = "nodeName"
+	podOwnerUidIndex = "ownerUid"
+)
+
+// podIndexers avoid scanning all pods when looking up the pods of a node or of an owner.
+var podIndexers = cache.Indexers{
+	podNodeNameIndex: func(obj any) ([]string, error) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok || pod.Spec.NodeName == "" {
+			return nil, nil
+		}
+		return []string{pod.Spec.NodeName}, nil
+	},
+	podOwnerUidIndex: func(obj any) ([]string, error) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return nil, nil
+		}
+		uids := make([]string, 0, len(pod.OwnerReferences))
+		for _, ref := range pod.OwnerReferences {
+			uids = append(uids, string(ref.UID))
+		}
+		return uids, nil
+	},
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"testing"
+
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodsByNodeNameAndOwnerUid(t *testing.T) {
+	// Given
+	pod := func(name, namespace, nodeName string, owner types.UID, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       namespace,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "rs-" + string(owner), UID: owner}},
+			},
+			Spec:   corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	clientset := fake.NewClientset(
+		pod("a", "default", "node-1", "uid-1", corev1.PodRunning),
+		pod("b", "default", "node-1", "uid-2", corev1.PodRunning),
+		pod("c", "other", "node-2", "uid-1", corev1.PodRunning),
+		pod("d", "default", "node-1", "uid-1", corev1.PodSucceeded),
+		pod("e", "default", "", "uid-1", corev1.PodPending),
+	)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client := CreateClient(clientset, stopCh, "", MockAllPermitted(), testutil.NewFakeDynamicClient())
+
+	names := func(pods []*corev1.Pod) []string {
+		result := make([]string, 0, len(pods))
+		for _, p := range pods {
+			result = append(result, p.Name)
+		}
+		return result
+	}
+
+	// Then
+	assert.ElementsMatch(t, []string{"a", "b"}, names(client.PodsByNodeName("node-1")))
+	assert.ElementsMatch(t, []string{"c"}, names(client.PodsByNodeName("node-2")))
+	assert.Empty(t, client.PodsByNodeName("node-3"))
+	assert.ElementsMatch(t, []string{"a"}, names(client.PodsByOwnerUid("uid-1", "default")))
+	assert.ElementsMatch(t, []string{"a", "c"}, names(client.PodsByOwnerUid("uid-1", "")))
+}
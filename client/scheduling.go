@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreatePriorityClass creates the cluster-scoped PriorityClass.
+func (c *Client) CreatePriorityClass(ctx context.Context, priorityClass *schedulingv1.PriorityClass) error {
+	if _, err := c.clientset.SchedulingV1().PriorityClasses().Create(ctx, priorityClass, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create priority class %s: %w", priorityClass.Name, err)
+	}
+	return nil
+}
+
+// DeletePriorityClass deletes the PriorityClass, a PriorityClass that is already gone is no error.
+func (c *Client) DeletePriorityClass(ctx context.Context, name string) error {
+	err := c.clientset.SchedulingV1().PriorityClasses().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete priority class %s: %w", name, err)
+	}
+	return nil
+}
+
+// PodsWithLabels lists the pods matching the label selector from the API server. Unlike the informer cache the list
+// contains pods of all phases, e.g. pending pods.
+func (c *Client) PodsWithLabels(ctx context.Context, namespace string, labelSelector string) ([]corev1.Pod, error) {
+	list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods with labels %s: %w", labelSelector, err)
+	}
+	return list.Items, nil
+}
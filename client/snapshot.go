@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	DeploymentGVR  = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	StatefulSetGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}
+	DaemonSetGVR   = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}
+	IngressGVR     = schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+	NodeGVR        = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "nodes"}
+)
+
+// snapshotIgnoredAnnotations change with every apply or are copies of the object itself, they would only add noise.
+var snapshotIgnoredAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// ResourceSnapshot returns the YAML representation of the desired state of a resource, i.e. without status and
+// server-managed metadata, so it can be compared over time. The resource is read from the API server, as the
+// informer caches strip parts of the objects. Cluster-scoped resources are read with an empty namespace.
+func (c *Client) ResourceSnapshot(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (string, error) {
+	if c.dynamicClient == nil {
+		return "", fmt.Errorf("no dynamic client available")
+	}
+	obj, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s %s: %w", gvr.Resource, name, err)
+	}
+	return snapshotYaml(obj)
+}
+
+func snapshotYaml(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "status")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "generation")
+	for _, annotation := range snapshotIgnoredAnnotations {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", annotation)
+	}
+	if annotations, found, _ := unstructured.NestedMap(obj.Object, "metadata", "annotations"); found && len(annotations) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+	}
+
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s: %w", obj.GetName(), err)
+	}
+	return string(out), nil
+}
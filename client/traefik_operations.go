@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+)
+
+// TraefikIngressRouteFromCache returns the IngressRoute from the informer cache, or nil if it is not cached.
+func (c *Client) TraefikIngressRouteFromCache(namespace, name string) *unstructured.Unstructured {
+	if c.traefik.ingressRouteInformer == nil {
+		return nil
+	}
+	item, exists, err := c.traefik.ingressRouteInformer.GetIndexer().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil
+	}
+	route, _ := item.(*unstructured.Unstructured)
+	return route
+}
+
+// GetTraefikIngressRoute reads the IngressRoute from the API server instead of the informer cache.
+func (c *Client) GetTraefikIngressRoute(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	route, err := c.dynamicClient.Resource(TraefikIngressRouteGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IngressRoute %s/%s: %w", namespace, name, err)
+	}
+	return route, nil
+}
+
+// UpdateTraefikIngressRoute applies update to the latest version of the IngressRoute and writes it back, retrying on
+// conflicts. The IngressRoute is left untouched if update reports no change.
+func (c *Client) UpdateTraefikIngressRoute(ctx context.Context, namespace, name string, update func(route *unstructured.Unstructured) bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		route, err := c.GetTraefikIngressRoute(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+		if !update(route) {
+			return nil
+		}
+		_, err = c.dynamicClient.Resource(TraefikIngressRouteGVR).Namespace(namespace).Update(ctx, route, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// UpdateIngressAnnotationValue applies update to the current value of the ingress annotation and writes it back,
+// retrying on conflicts. An empty result removes the annotation.
+func (c *Client) UpdateIngressAnnotationValue(ctx context.Context, namespace, name, annotationKey string, update func(value string) string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ingress, err := c.clientset.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get ingress %s/%s: %w", namespace, name, err)
+		}
+		currentValue := ingress.Annotations[annotationKey]
+		newValue := update(currentValue)
+		if newValue == currentValue {
+			return nil
+		}
+		if newValue == "" {
+			delete(ingress.Annotations, annotationKey)
+		} else {
+			if ingress.Annotations == nil {
+				ingress.Annotations = map[string]string{}
+			}
+			ingress.Annotations[annotationKey] = newValue
+		}
+		_, err = c.clientset.NetworkingV1().Ingresses(namespace).Update(ctx, ingress, metav1.UpdateOptions{})
+		return err
+	})
+}
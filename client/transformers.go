@@ -1,10 +1,13 @@
 package client
 
 import (
+	"strings"
+
 	"github.com/steadybit/extension-kubernetes/v2/extconfig"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 )
@@ -123,6 +126,10 @@ func transformEvents(i any) (any, error) {
 		event.ObjectMeta.ManagedFields = nil
 		return event, nil
 	}
+	if event, ok := i.(*eventsv1.Event); ok {
+		event.ObjectMeta.ManagedFields = nil
+		return event, nil
+	}
 	return i, nil
 }
 
@@ -130,10 +137,17 @@ func transformNodes(i any) (any, error) {
 	if node, ok := i.(*corev1.Node); ok {
 		node.ObjectMeta.Annotations = nil
 		node.ObjectMeta.ManagedFields = nil
-		node.Spec = corev1.NodeSpec{}
+		node.Spec = corev1.NodeSpec{
+			Unschedulable: node.Spec.Unschedulable,
+			Taints:        node.Spec.Taints,
+			ProviderID:    node.Spec.ProviderID,
+		}
 		node.Status = corev1.NodeStatus{
-			Conditions: node.Status.Conditions,
-			Addresses:  node.Status.Addresses,
+			Conditions:  node.Status.Conditions,
+			Addresses:   node.Status.Addresses,
+			Capacity:    node.Status.Capacity,
+			Allocatable: node.Status.Allocatable,
+			NodeInfo:    node.Status.NodeInfo,
 		}
 		return node, nil
 	}
@@ -199,35 +213,38 @@ func transformIngressClass(i any) (any, error) {
 	return i, nil
 }
 
+// SteadybitBlockMarker starts the configuration blocks the ingress attacks add to snippet annotations.
+const SteadybitBlockMarker = "# BEGIN STEADYBIT - "
+
 func transformIngress(i any) (any, error) {
 	if d, ok := i.(*networkingv1.Ingress); ok {
 		// Preserve ingressClassName and the class annotation if present
 		ingressClassName := d.Spec.IngressClassName
 
-		// Preserve only the specific annotation we need
-		classAnnotation := ""
-		if d.ObjectMeta.Annotations != nil {
-			classAnnotation = d.ObjectMeta.Annotations["kubernetes.io/ingress.class"]
-		}
-
-		// Clear annotations but keep what we need in a minimal map
-		if classAnnotation != "" {
-			d.ObjectMeta.Annotations = map[string]string{
-				"kubernetes.io/ingress.class": classAnnotation,
+		// Preserve only the class annotation and the snippet annotations carrying an attack's configuration, which
+		// the attacks check for reverts
+		var annotations map[string]string
+		for key, value := range d.ObjectMeta.Annotations {
+			if (key == "kubernetes.io/ingress.class" && value != "") || strings.Contains(value, SteadybitBlockMarker) {
+				if annotations == nil {
+					annotations = map[string]string{}
+				}
+				annotations[key] = value
 			}
-		} else {
-			d.ObjectMeta.Annotations = nil
 		}
+		d.ObjectMeta.Annotations = annotations
 
 		d.ObjectMeta.ManagedFields = nil
 
-		// Keep the rules and TLS configuration for discovery
+		// Keep the rules, the default backend and TLS configuration for discovery
 		rules := d.Spec.Rules
+		defaultBackend := d.Spec.DefaultBackend
 		tls := d.Spec.TLS
 
 		// Create minimal spec with only what we need
 		d.Spec = networkingv1.IngressSpec{
 			IngressClassName: ingressClassName,
+			DefaultBackend:   defaultBackend,
 			Rules:            rules,
 			TLS:              tls,
 		}
@@ -323,3 +323,29 @@ func TestTransformIngressClass_SpecificBehaviors(t *testing.T) {
 func stringPtr(s string) *string {
 	return new(s)
 }
+
+func TestTransformIngress_keepsOnlyClassAndAttackAnnotations(t *testing.T) {
+	input := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":                       "nginx",
+				"nginx.ingress.kubernetes.io/configuration-snippet": "# Some config\n" + SteadybitBlockMarker + "block - 1234\n",
+				"haproxy.org/backend-config-snippet":                "# Some config\n",
+				"kubectl.kubernetes.io/last-applied-configuration":  "{}",
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+	}
+
+	result, err := transformIngress(input)
+	require.NoError(t, err)
+
+	ingress, ok := result.(*networkingv1.Ingress)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"kubernetes.io/ingress.class":                       "nginx",
+		"nginx.ingress.kubernetes.io/configuration-snippet": "# Some config\n" + SteadybitBlockMarker + "block - 1234\n",
+	}, ingress.Annotations)
+	assert.Nil(t, ingress.ManagedFields)
+}
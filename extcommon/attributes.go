@@ -75,6 +75,55 @@ func (a *attributeDescriber) DescribeAttributes() []discovery_kit_api.AttributeD
 				Other: "Node names",
 			},
 		},
+		{
+			Attribute: "k8s.node.kubelet-version",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Kubelet version",
+				Other: "Kubelet versions",
+			},
+		},
+		{
+			Attribute: "k8s.node.architecture",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Node architecture",
+				Other: "Node architectures",
+			},
+		},
+		{
+			Attribute: "k8s.node.os-image",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Node OS image",
+				Other: "Node OS images",
+			},
+		},
+		{
+			Attribute: "k8s.node.cloud",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Cloud provider",
+				Other: "Cloud providers",
+			},
+		},
+		{
+			Attribute: "k8s.node.region",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Cloud region",
+				Other: "Cloud regions",
+			},
+		},
+		{
+			Attribute: "k8s.node.instance-id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Cloud instance ID",
+				Other: "Cloud instance IDs",
+			},
+		},
+		{
+			Attribute: "k8s.node.taint",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Node taint",
+				Other: "Node taints",
+			},
+		},
 		{
 			Attribute: "k8s.replicaset",
 			Label: discovery_kit_api.PluralLabel{
@@ -82,6 +131,13 @@ func (a *attributeDescriber) DescribeAttributes() []discovery_kit_api.AttributeD
 				Other: "ReplicaSet names",
 			},
 		},
+		{
+			Attribute: "k8s.workload-shard",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Workload shard",
+				Other: "Workload shards",
+			},
+		},
 		{
 			Attribute: "k8s.workload-owner",
 			Label: discovery_kit_api.PluralLabel{
@@ -96,6 +152,48 @@ func (a *attributeDescriber) DescribeAttributes() []discovery_kit_api.AttributeD
 				Other: "Workload types",
 			},
 		},
+		{
+			Attribute: BackendServiceAttribute,
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Backend service",
+				Other: "Backend services",
+			},
+		},
+		{
+			Attribute: BackendServicePortAttribute,
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Backend service port",
+				Other: "Backend service ports",
+			},
+		},
+		{
+			Attribute: BackendDeploymentAttribute,
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Backend Deployment",
+				Other: "Backend Deployments",
+			},
+		},
+		{
+			Attribute: BackendStatefulSetAttribute,
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Backend StatefulSet",
+				Other: "Backend StatefulSets",
+			},
+		},
+		{
+			Attribute: RouteHostnameAttribute,
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Routed hostname",
+				Other: "Routed hostnames",
+			},
+		},
+		{
+			Attribute: RoutePathAttribute,
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Routed path",
+				Other: "Routed paths",
+			},
+		},
 		{
 			Attribute: LivenessProbePathAttribute,
 			Label: discovery_kit_api.PluralLabel{
@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	BackendServiceAttribute     = "k8s.backend.service"
+	BackendServicePortAttribute = "k8s.backend.service.port"
+	BackendDeploymentAttribute  = "k8s.backend.deployment"
+	BackendStatefulSetAttribute = "k8s.backend.statefulset"
+	// RouteHostnameAttribute and RoutePathAttribute hold the hostnames and paths routed to the backends. They are
+	// copied to the Deployments and StatefulSets serving the backends by the route to workload enrichment rules.
+	RouteHostnameAttribute = "k8s.route.hostname"
+	RoutePathAttribute     = "k8s.route.path"
+)
+
+// BackendRef is a Service port a route or an ingress forwards requests to. The port is either a number or a port name.
+type BackendRef struct {
+	Service string
+	Port    string
+}
+
+// RoutedBackends are the backends of a route or an ingress and the hostnames and paths routed to them.
+type RoutedBackends struct {
+	Backends  []BackendRef
+	Hostnames []string
+	Paths     []string
+}
+
+// IngressBackends returns the Service backends of the ingress rules and of the default backend.
+func IngressBackends(ingress *networkingv1.Ingress) RoutedBackends {
+	var result RoutedBackends
+	addBackend := func(backend networkingv1.IngressBackend) {
+		if backend.Service == nil || backend.Service.Name == "" {
+			return
+		}
+		port := backend.Service.Port.Name
+		if backend.Service.Port.Number != 0 {
+			port = strconv.Itoa(int(backend.Service.Port.Number))
+		}
+		result.Backends = append(result.Backends, BackendRef{Service: backend.Service.Name, Port: port})
+	}
+	if ingress.Spec.DefaultBackend != nil {
+		addBackend(*ingress.Spec.DefaultBackend)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			result.Hostnames = append(result.Hostnames, rule.Host)
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			addBackend(path.Backend)
+			if path.Path != "" {
+				result.Paths = append(result.Paths, path.Path)
+			}
+		}
+	}
+	return result
+}
+
+// HTTPRouteBackends returns the Service backendRefs of the rules of a Gateway API HTTPRoute. Backends in other
+// namespaces (allowed by a ReferenceGrant) and backends of other kinds are skipped.
+func HTTPRouteBackends(route *unstructured.Unstructured) RoutedBackends {
+	var result RoutedBackends
+	result.Hostnames, _, _ = unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			continue
+		}
+		backendRefs, _ := ruleMap["backendRefs"].([]any)
+		for _, ref := range backendRefs {
+			refMap, ok := ref.(map[string]any)
+			if !ok {
+				continue
+			}
+			if group, _ := refMap["group"].(string); group != "" {
+				continue
+			}
+			if kind, _ := refMap["kind"].(string); kind != "" && kind != "Service" {
+				continue
+			}
+			if namespace, _ := refMap["namespace"].(string); namespace != "" && namespace != route.GetNamespace() {
+				continue
+			}
+			name, _ := refMap["name"].(string)
+			if name == "" {
+				continue
+			}
+			backend := BackendRef{Service: name}
+			if port, found, err := unstructured.NestedInt64(refMap, "port"); err == nil && found {
+				backend.Port = strconv.FormatInt(port, 10)
+			}
+			result.Backends = append(result.Backends, backend)
+		}
+		matches, _ := ruleMap["matches"].([]any)
+		for _, match := range matches {
+			matchMap, ok := match.(map[string]any)
+			if !ok {
+				continue
+			}
+			if path, found, err := unstructured.NestedString(matchMap, "path", "value"); err == nil && found && path != "" {
+				result.Paths = append(result.Paths, path)
+			}
+		}
+	}
+	return result
+}
+
+// BackendResolver resolves the backend Services of routes and ingresses to the Deployments and StatefulSets whose pods
+// they select. A discovery keeps one resolver and calls EndRun at the end of each run. The Services selecting the pods
+// of a workload are cached until the workload's spec or one of the Services of its namespace changes.
+type BackendResolver struct {
+	k8s              *client.Client
+	workloadServices *ResultCache[[]string]
+	services         map[string]*corev1.Service
+	workloads        map[string][]backendWorkload
+}
+
+type backendWorkload struct {
+	attribute   string
+	name        string
+	fingerprint string
+	services    []string
+}
+
+func NewBackendResolver(k8s *client.Client) *BackendResolver {
+	return &BackendResolver{k8s: k8s, workloadServices: NewResultCache[[]string]()}
+}
+
+func (r *BackendResolver) init() {
+	if r.services != nil {
+		return
+	}
+	r.services = map[string]*corev1.Service{}
+	servicesByNamespace := map[string][]*corev1.Service{}
+	for _, service := range r.k8s.Services() {
+		r.services[service.Namespace+"/"+service.Name] = service
+		servicesByNamespace[service.Namespace] = append(servicesByNamespace[service.Namespace], service)
+	}
+	servicesFingerprints := make(map[string]string, len(servicesByNamespace))
+	for namespace, services := range servicesByNamespace {
+		servicesFingerprints[namespace] = Fingerprint(services...)
+	}
+
+	r.workloads = map[string][]backendWorkload{}
+	addWorkload := func(attribute string, workload metav1.Object, podLabels map[string]string) {
+		namespace := workload.GetNamespace()
+		fingerprint := GenerationFingerprint(workload)
+		services := r.workloadServices.Get(string(workload.GetUID()), Fingerprints(fingerprint, servicesFingerprints[namespace]), func() []string {
+			return servicesSelecting(servicesByNamespace[namespace], podLabels)
+		})
+		r.workloads[namespace] = append(r.workloads[namespace], backendWorkload{
+			attribute:   attribute,
+			name:        workload.GetName(),
+			fingerprint: fingerprint,
+			services:    services,
+		})
+	}
+	for _, deployment := range r.k8s.Deployments() {
+		addWorkload(BackendDeploymentAttribute, deployment, deployment.Spec.Template.Labels)
+	}
+	for _, sts := range r.k8s.StatefulSets() {
+		addWorkload(BackendStatefulSetAttribute, sts, sts.Spec.Template.Labels)
+	}
+}
+
+// EndRun drops the Services and workloads listed during the run and the cached Services of deleted workloads.
+func (r *BackendResolver) EndRun() {
+	r.services = nil
+	r.workloads = nil
+	r.workloadServices.EndRun()
+}
+
+// servicesSelecting returns the names of the Services whose selector matches the pod labels.
+func servicesSelecting(services []*corev1.Service, podLabels map[string]string) []string {
+	var names []string
+	for _, service := range services {
+		if service.Spec.Selector == nil {
+			continue
+		}
+		if labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(podLabels)) {
+			names = append(names, service.Name)
+		}
+	}
+	return names
+}
+
+// Resolve returns the attributes describing the backends and a fingerprint of the Services and workloads they were
+// resolved from.
+func (r *BackendResolver) Resolve(namespace string, routed RoutedBackends) (map[string][]string, string) {
+	r.init()
+	attributes := map[string][]string{}
+	var fingerprints []string
+	backendServices := map[string]bool{}
+	for _, backend := range routed.Backends {
+		backendServices[backend.Service] = true
+		attributes[BackendServiceAttribute] = append(attributes[BackendServiceAttribute], backend.Service)
+		if backend.Port != "" {
+			attributes[BackendServicePortAttribute] = append(attributes[BackendServicePortAttribute], backend.Service+":"+backend.Port)
+		}
+		if service, ok := r.services[namespace+"/"+backend.Service]; ok {
+			fingerprints = append(fingerprints, Fingerprint(service))
+		}
+	}
+	for _, workload := range r.workloads[namespace] {
+		if slices.ContainsFunc(workload.services, func(service string) bool { return backendServices[service] }) {
+			attributes[workload.attribute] = append(attributes[workload.attribute], workload.name)
+			fingerprints = append(fingerprints, workload.fingerprint)
+		}
+	}
+	if len(routed.Hostnames) > 0 {
+		attributes[RouteHostnameAttribute] = routed.Hostnames
+	}
+	if len(routed.Paths) > 0 {
+		attributes[RoutePathAttribute] = routed.Paths
+	}
+	for key, values := range attributes {
+		values = slices.Clone(values)
+		slices.Sort(values)
+		attributes[key] = slices.Compact(values)
+	}
+	slices.Sort(fingerprints)
+	return attributes, strings.Join(fingerprints, ";")
+}
+
+// GetRouteToWorkloadEnrichmentRules returns the rules copying the hostnames and paths of the route or ingress targets
+// of the given type to the Deployments and StatefulSets serving their backends.
+func GetRouteToWorkloadEnrichmentRules(routeTargetType string) []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		getRouteToWorkloadEnrichmentRule(routeTargetType, "deployment", BackendDeploymentAttribute, "k8s.deployment"),
+		getRouteToWorkloadEnrichmentRule(routeTargetType, "statefulset", BackendStatefulSetAttribute, "k8s.statefulset"),
+	}
+}
+
+func getRouteToWorkloadEnrichmentRule(routeTargetType, workloadType, backendAttribute, workloadAttribute string) discovery_kit_api.TargetEnrichmentRule {
+	return discovery_kit_api.TargetEnrichmentRule{
+		Id:      routeTargetType + "-to-" + workloadType,
+		Version: extbuild.GetSemverVersionStringOrUnknown(),
+		Src: discovery_kit_api.SourceOrDestination{
+			Type: routeTargetType,
+			Selector: map[string]string{
+				"k8s.cluster-name": "${dest.k8s.cluster-name}",
+				"k8s.namespace":    "${dest.k8s.namespace}",
+				backendAttribute:   "${dest." + workloadAttribute + "}",
+			},
+		},
+		Dest: discovery_kit_api.SourceOrDestination{
+			Type: "com.steadybit.extension_kubernetes.kubernetes-" + workloadType,
+			Selector: map[string]string{
+				"k8s.cluster-name": "${src.k8s.cluster-name}",
+				"k8s.namespace":    "${src.k8s.namespace}",
+				workloadAttribute:  "${src." + backendAttribute + "}",
+			},
+		},
+		Attributes: []discovery_kit_api.Attribute{
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    RouteHostnameAttribute,
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    RoutePathAttribute,
+			},
+		},
+	}
+}
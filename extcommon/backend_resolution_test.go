@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	kclient "github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIngressBackends(t *testing.T) {
+	ingress := &networkingv1.Ingress{
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: &networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{Name: "fallback", Port: networkingv1.ServiceBackendPort{Name: "http"}},
+			},
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "shop.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{
+								Path:    "/checkout",
+								Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "checkout", Port: networkingv1.ServiceBackendPort{Number: 8080}}},
+							},
+							{
+								Path:    "/assets",
+								Backend: networkingv1.IngressBackend{Resource: &corev1.TypedLocalObjectReference{Kind: "StorageBucket", Name: "assets"}},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, RoutedBackends{
+		Backends:  []BackendRef{{Service: "fallback", Port: "http"}, {Service: "checkout", Port: "8080"}},
+		Hostnames: []string{"shop.example.com"},
+		Paths:     []string{"/checkout", "/assets"},
+	}, IngressBackends(ingress))
+}
+
+func TestHTTPRouteBackends(t *testing.T) {
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "shop", "namespace": "default"},
+		"spec": map[string]any{
+			"hostnames": []any{"shop.example.com"},
+			"rules": []any{
+				map[string]any{
+					"matches": []any{map[string]any{"path": map[string]any{"type": "PathPrefix", "value": "/checkout"}}},
+					"backendRefs": []any{
+						map[string]any{"name": "checkout", "port": int64(8080)},
+						map[string]any{"kind": "Service", "name": "checkout-canary"},
+						map[string]any{"name": "remote", "namespace": "other", "port": int64(80)},
+						map[string]any{"group": "example.com", "kind": "Bucket", "name": "assets"},
+					},
+				},
+			},
+		},
+	}}
+
+	assert.Equal(t, RoutedBackends{
+		Backends:  []BackendRef{{Service: "checkout", Port: "8080"}, {Service: "checkout-canary"}},
+		Hostnames: []string{"shop.example.com"},
+		Paths:     []string{"/checkout"},
+	}, HTTPRouteBackends(route))
+}
+
+func TestBackendResolver_Resolve(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	podLabels := map[string]string{"app": "checkout"}
+	k8s := getTestClient(stopCh,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "default", UID: "svc"},
+			Spec:       corev1.ServiceSpec{Selector: podLabels},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "default", UID: "deploy"},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}}},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-db", Namespace: "default", UID: "sts"},
+			Spec:       appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "db"}}}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "other", UID: "other"},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}}},
+		},
+	)
+
+	attributes, fingerprint := NewBackendResolver(k8s).Resolve("default", RoutedBackends{
+		Backends:  []BackendRef{{Service: "checkout", Port: "8080"}, {Service: "missing"}},
+		Hostnames: []string{"shop.example.com"},
+		Paths:     []string{"/checkout"},
+	})
+
+	assert.Equal(t, map[string][]string{
+		BackendServiceAttribute:     {"checkout", "missing"},
+		BackendServicePortAttribute: {"checkout:8080"},
+		BackendDeploymentAttribute:  {"checkout"},
+		RouteHostnameAttribute:      {"shop.example.com"},
+		RoutePathAttribute:          {"/checkout"},
+	}, attributes)
+	assert.Contains(t, fingerprint, "svc@")
+	assert.Contains(t, fingerprint, "deploy#")
+	assert.NotContains(t, fingerprint, "other#")
+}
+
+func TestBackendResolver_ResolvesAgainAfterChanges(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	podLabels := map[string]string{"app": "checkout"}
+	clientset := testclient.NewClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "default", UID: "svc", ResourceVersion: "1"},
+			Spec:       corev1.ServiceSpec{Selector: podLabels},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "default", UID: "deploy", Generation: 1},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}}},
+		},
+	)
+	k8s := kclient.CreateClient(clientset, stopCh, "/oapi", kclient.MockAllPermitted(), testutil.NewFakeDynamicClient())
+	resolver := NewBackendResolver(k8s)
+	resolve := func() []string {
+		defer resolver.EndRun()
+		attributes, _ := resolver.Resolve("default", RoutedBackends{Backends: []BackendRef{{Service: "checkout"}}})
+		return attributes[BackendDeploymentAttribute]
+	}
+
+	// Given
+	require.Equal(t, []string{"checkout"}, resolve())
+	require.Equal(t, []string{"checkout"}, resolve(), "unchanged workloads are resolved from the cache")
+
+	// When
+	_, err := clientset.CoreV1().Services("default").Update(context.Background(), &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "default", UID: "svc", ResourceVersion: "2"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "checkout-v2"}},
+	}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// Then
+	assert.Eventually(t, func() bool { return len(resolve()) == 0 }, time.Second, 10*time.Millisecond)
+
+	// When
+	_, err = clientset.AppsV1().Deployments("default").Create(context.Background(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout-v2", Namespace: "default", UID: "deploy-v2", Generation: 1},
+		Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "checkout-v2"}}}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Then
+	assert.Eventually(t, func() bool { return slices.Equal([]string{"checkout-v2"}, resolve()) }, time.Second, 10*time.Millisecond)
+}
+
+func TestGetRouteToWorkloadEnrichmentRules(t *testing.T) {
+	rules := GetRouteToWorkloadEnrichmentRules("com.example.route")
+
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "com.example.route-to-deployment", rules[0].Id)
+	assert.Equal(t, "${dest.k8s.deployment}", rules[0].Src.Selector[BackendDeploymentAttribute])
+	assert.Equal(t, "com.steadybit.extension_kubernetes.kubernetes-statefulset", rules[1].Dest.Type)
+	assert.Equal(t, "${src.k8s.backend.statefulset}", rules[1].Dest.Selector["k8s.statefulset"])
+}
@@ -13,20 +13,20 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const tooManyPods = "too-many-pods"
-
-var tooManyPodsAttributes = map[string][]string{
-	"k8s.pod.name":              {tooManyPods},
-	"k8s.container.id":          {tooManyPods},
-	"k8s.container.id.stripped": {tooManyPods},
-	"host.hostname":             {tooManyPods},
-	"host.domainname":           {tooManyPods},
-}
-
+// GetPodBasedAttributes returns the pods, containers and hosts of a workload. Workloads with more than
+// DiscoveryMaxPodCount pods only get the node labels, their pods are listed by the workload shard targets instead.
 func GetPodBasedAttributes(ownerType string, owner metav1.ObjectMeta, pods []*v1.Pod, nodes []*v1.Node) map[string][]string {
 	if len(pods) > extconfig.Config.DiscoveryMaxPodCount {
-		log.Warn().Msgf("%s %s/%s has more than %d pods. Not listing pods, containers and hosts for this %s", ownerType, owner.Namespace, owner.Name, extconfig.Config.DiscoveryMaxPodCount, ownerType)
-		return tooManyPodsAttributes
+		log.Debug().Msgf("%s %s/%s has more than %d pods. Listing pods, containers and hosts in workload shards", ownerType, owner.Namespace, owner.Name, extconfig.Config.DiscoveryMaxPodCount)
+		attributes := map[string][]string{}
+		nodeNames := make(map[string]bool)
+		for _, pod := range pods {
+			if !nodeNames[pod.Spec.NodeName] {
+				nodeNames[pod.Spec.NodeName] = true
+				AddNodeLabels(nodes, pod.Spec.NodeName, attributes)
+			}
+		}
+		return attributes
 	}
 
 	if len(pods) == 0 {
@@ -115,3 +115,10 @@ func GetHostname(node *v1.Node) string {
 	}
 	return node.Name
 }
+
+// SortDedup returns a sorted, de-duplicated copy so multi-valued attributes stay stable across discovery cycles.
+func SortDedup(values []string) []string {
+	out := slices.Clone(values)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -24,8 +24,11 @@ var ArgoRolloutGVK = schema.GroupVersionKind{
 var triggerableUnstructuredGVKs = []schema.GroupVersionKind{
 	ArgoRolloutGVK,
 	{Group: client.GatewayNetworkingGroup, Version: "v1", Kind: "HTTPRoute"},
+	{Group: client.GatewayNetworkingGroup, Version: "v1", Kind: "GRPCRoute"},
 	{Group: client.GatewayNetworkingGroup, Version: "v1", Kind: "Gateway"},
 	{Group: client.GatewayNetworkingGroup, Version: "v1", Kind: "GatewayClass"},
+	{Group: client.TraefikGroup, Version: "v1alpha1", Kind: "IngressRoute"},
+	{Group: client.IstioNetworkingGroup, Version: "v1beta1", Kind: "VirtualService"},
 }
 
 func TriggerOnKubernetesResourceChange(k8s *client.Client, t ...reflect.Type) chan struct{} {
@@ -38,6 +41,18 @@ func TriggerOnKubernetesResourceChange(k8s *client.Client, t ...reflect.Type) ch
 	return chRefresh
 }
 
+// TriggerOnKubernetesSpecChange is like TriggerOnKubernetesResourceChange, but ignores updates which only change the
+// status of an object, e.g. the replica counts of workloads.
+func TriggerOnKubernetesSpecChange(k8s *client.Client, t ...reflect.Type) chan struct{} {
+	chRefresh := make(chan struct{})
+	chNotification := make(chan any)
+
+	k8s.NotifySpecChanges(chNotification)
+	go triggerNotificationsForType(chNotification, chRefresh, t...)
+
+	return chRefresh
+}
+
 func triggerNotificationsForType(in <-chan any, out chan<- struct{}, types ...reflect.Type) {
 	var s []string
 	for _, r := range types {
@@ -18,6 +18,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 )
@@ -28,6 +29,16 @@ type kubeScoreInput interface {
 	GetNamespace() string
 }
 
+// KubeScoreFingerprint identifies the kube-score input of a workload. Status updates of the workload and of its HPA
+// don't affect the score, so their generation is used instead of their resourceVersion.
+func KubeScoreFingerprint(workload metav1.Object, services []*corev1.Service, hpa *autoscalingv2.HorizontalPodAutoscaler) string {
+	hpaFingerprint := ""
+	if hpa != nil {
+		hpaFingerprint = GenerationFingerprint(hpa)
+	}
+	return Fingerprints(GenerationFingerprint(workload), Fingerprint(services...), hpaFingerprint)
+}
+
 func GetKubeScoreForDeployment(deployment *appsv1.Deployment, services []*corev1.Service, hpa *autoscalingv2.HorizontalPodAutoscaler) map[string][]string {
 	deployment.APIVersion = "apps/v1"
 	deployment.Kind = "Deployment"
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"slices"
+
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	v1 "k8s.io/api/core/v1"
+)
+
+const unknownZone = "unknown"
+
+// PodShard is a subset of the pods of a workload with more than DiscoveryMaxPodCount pods.
+type PodShard struct {
+	// Key identifies the shard within the workload, e.g. "node/worker-1", "zone/eu-central-1a" or "zone/eu-central-1a/2".
+	Key string
+	// Name is the human-readable part of the key, e.g. "worker-1", "eu-central-1a" or "eu-central-1a #2".
+	Name string
+	Pods []*v1.Pod
+}
+
+// ShardPods splits the pods of a workload exceeding DiscoveryMaxPodCount into shards of at most DiscoveryMaxPodCount
+// pods. DaemonSet pods are grouped by node, all other pods by zone. Zones with too many pods are further split by
+// hashing the pod names, so a pod stays in its shard as long as the number of shards doesn't change.
+// Returns nil if the workload doesn't exceed the limit.
+func ShardPods(ownerType string, pods []*v1.Pod, nodes []*v1.Node) []PodShard {
+	maxPods := extconfig.Config.DiscoveryMaxPodCount
+	if len(pods) <= maxPods {
+		return nil
+	}
+
+	zones := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		zones[node.Name] = node.Labels[v1.LabelTopologyZone]
+	}
+
+	groups := make(map[string][]*v1.Pod)
+	for _, pod := range pods {
+		var key string
+		if ownerType == "daemonset" {
+			key = "node/" + pod.Spec.NodeName
+		} else if zone := zones[pod.Spec.NodeName]; zone != "" {
+			key = "zone/" + zone
+		} else {
+			key = "zone/" + unknownZone
+		}
+		groups[key] = append(groups[key], pod)
+	}
+
+	var shards []PodShard
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		name := key[len("zone/"):]
+		if ownerType == "daemonset" {
+			name = key[len("node/"):]
+		}
+		buckets := hashPods(groups[key], max(maxPods, 1))
+		if len(buckets) == 1 {
+			shards = append(shards, PodShard{Key: key, Name: name, Pods: buckets[0]})
+			continue
+		}
+		for i, bucket := range buckets {
+			if len(bucket) > 0 {
+				shards = append(shards, PodShard{Key: fmt.Sprintf("%s/%d", key, i+1), Name: fmt.Sprintf("%s #%d", name, i+1), Pods: bucket})
+			}
+		}
+	}
+	return shards
+}
+
+// hashPods distributes the pods by the hash of their names into the smallest number of buckets of at most maxPods pods.
+func hashPods(pods []*v1.Pod, maxPods int) [][]*v1.Pod {
+	if len(pods) <= maxPods {
+		return [][]*v1.Pod{pods}
+	}
+	hashes := make([]uint32, len(pods))
+	for i, pod := range pods {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(pod.Name))
+		hashes[i] = h.Sum32()
+	}
+	for count := (len(pods) + maxPods - 1) / maxPods; ; count++ {
+		buckets := make([][]*v1.Pod, count)
+		fits := true
+		for i, pod := range pods {
+			bucket := hashes[i] % uint32(count)
+			buckets[bucket] = append(buckets[bucket], pod)
+			fits = fits && len(buckets[bucket]) <= maxPods
+		}
+		if fits || count >= len(pods) {
+			return buckets
+		}
+	}
+}
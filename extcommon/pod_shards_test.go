@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestShardPods(t *testing.T) {
+	defer func(config extconfig.Specification) { extconfig.Config = config }(extconfig.Config)
+	extconfig.Config.DiscoveryMaxPodCount = 10
+	nodes := []*v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{v1.LabelTopologyZone: "zone-a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{v1.LabelTopologyZone: "zone-b"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}},
+	}
+	pods := func(count int, nodeName string) []*v1.Pod {
+		result := make([]*v1.Pod, 0, count)
+		for i := range count {
+			result = append(result, &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-pod-%d", nodeName, i)},
+				Spec:       v1.PodSpec{NodeName: nodeName},
+			})
+		}
+		return result
+	}
+	keys := func(shards []PodShard) []string {
+		result := make([]string, 0, len(shards))
+		for _, shard := range shards {
+			result = append(result, shard.Key)
+		}
+		return result
+	}
+
+	t.Run("workloads within the limit are not sharded", func(t *testing.T) {
+		assert.Nil(t, ShardPods("deployment", pods(10, "node-a"), nodes))
+	})
+
+	t.Run("daemonsets are sharded by node", func(t *testing.T) {
+		shards := ShardPods("daemonset", append(append(pods(6, "node-a"), pods(3, "node-b")...), pods(2, "node-c")...), nodes)
+		assert.Equal(t, []string{"node/node-a", "node/node-b", "node/node-c"}, keys(shards))
+		assert.Equal(t, "node-a", shards[0].Name)
+		assert.Len(t, shards[0].Pods, 6)
+	})
+
+	t.Run("other workloads are sharded by zone", func(t *testing.T) {
+		shards := ShardPods("deployment", append(append(pods(6, "node-a"), pods(3, "node-b")...), pods(2, "node-c")...), nodes)
+		assert.Equal(t, []string{"zone/unknown", "zone/zone-a", "zone/zone-b"}, keys(shards))
+		assert.Len(t, shards[1].Pods, 6)
+	})
+
+	t.Run("large zones are split into stable shards", func(t *testing.T) {
+		shards := ShardPods("deployment", pods(35, "node-a"), nodes)
+		require.GreaterOrEqual(t, len(shards), 4)
+		total := 0
+		for _, shard := range shards {
+			assert.LessOrEqual(t, len(shard.Pods), 10)
+			assert.Contains(t, shard.Key, "zone/zone-a/")
+			total += len(shard.Pods)
+		}
+		assert.Equal(t, 35, total)
+		assert.Equal(t, shards, ShardPods("deployment", pods(35, "node-a"), nodes))
+	})
+}
+
+func TestGetPodBasedAttributesOfLargeWorkloadsOnlyContainNodeLabels(t *testing.T) {
+	defer func(config extconfig.Specification) { extconfig.Config = config }(extconfig.Config)
+	extconfig.Config.DiscoveryMaxPodCount = 1
+	extconfig.Config.DiscoveryLabelInheritanceNode = true
+	nodes := []*v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{v1.LabelTopologyZone: "zone-a"}}}}
+	pods := []*v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}, Spec: v1.PodSpec{NodeName: "node-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-2"}, Spec: v1.PodSpec{NodeName: "node-a"}},
+	}
+
+	attributes := GetPodBasedAttributes("deployment", metav1.ObjectMeta{Name: "shop"}, pods, nodes)
+
+	assert.Equal(t, []string{"zone-a"}, attributes["k8s.label.topology.kubernetes.io/zone"])
+	assert.NotContains(t, attributes, "k8s.pod.name")
+	assert.NotContains(t, attributes, "host.hostname")
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// HttpMethods are the HTTP methods supported by the method condition.
+var HttpMethods = []string{"GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH"}
+
+// RequestMatcher restricts a fault to the requests matching the path pattern, the method and all headers. Empty
+// conditions match all requests.
+type RequestMatcher struct {
+	PathPattern string            `json:"pathPattern"`
+	HttpMethod  string            `json:"httpMethod"`
+	HttpHeader  map[string]string `json:"httpHeader"`
+}
+
+func (m RequestMatcher) IsEmpty() bool {
+	return m.PathPattern == "" && m.HttpMethod == "" && len(m.HttpHeader) == 0
+}
+
+// ParseRequestMatcher reads the conditions appended by WithConditionParameters from the action config.
+func ParseRequestMatcher(config map[string]any) (RequestMatcher, error) {
+	matcher := RequestMatcher{PathPattern: extutil.ToString(config["conditionPathPattern"])}
+	if matcher.PathPattern != "" {
+		if _, err := regexp.Compile(matcher.PathPattern); err != nil {
+			return matcher, fmt.Errorf("invalid path pattern: %w", err)
+		}
+	}
+	if method := extutil.ToString(config["conditionHttpMethod"]); method != "" && method != "*" {
+		if !slices.Contains(HttpMethods, method) {
+			return matcher, fmt.Errorf("unsupported HTTP method %q", method)
+		}
+		matcher.HttpMethod = method
+	}
+	if config["conditionHttpHeader"] != nil {
+		header, err := extutil.ToKeyValue(config, "conditionHttpHeader")
+		if err != nil {
+			return matcher, fmt.Errorf("failed to parse HTTP header condition: %w", err)
+		}
+		matcher.HttpHeader = header
+	}
+	return matcher, nil
+}
+
+// PercentageFromConfig extracts the traffic percentage, greater than 0 and at most 100. It defaults to 100 when unset.
+func PercentageFromConfig(config map[string]any) (float64, error) {
+	var percentage float64
+	switch v := config["percentage"].(type) {
+	case nil:
+		return 100, nil
+	case float64:
+		percentage = v
+	case float32:
+		percentage = float64(v)
+	default:
+		percentage = float64(extutil.ToInt64(v))
+	}
+	if percentage <= 0 || percentage > 100 {
+		return 0, fmt.Errorf("percentage must be greater than 0 and at most 100")
+	}
+	return percentage, nil
+}
+
+// WithConditionParameters appends the optional request condition parameters read by ParseRequestMatcher. The
+// pathPatternDescription explains the path condition, which depends on how the attacked resource matches paths. The
+// method condition is only offered with withHttpMethod. Call it after the attack's own parameters.
+func WithConditionParameters(desc action_kit_api.ActionDescription, pathPatternDescription string, withHttpMethod bool) action_kit_api.ActionDescription {
+	desc.Parameters = append(desc.Parameters,
+		action_kit_api.ActionParameter{
+			Name:  "-conditions-separator-",
+			Label: "-",
+			Type:  action_kit_api.ActionParameterTypeSeparator,
+		},
+		action_kit_api.ActionParameter{
+			Name:  "-conditions-header-",
+			Type:  action_kit_api.ActionParameterTypeHeader,
+			Label: "Conditions",
+		},
+		action_kit_api.ActionParameter{
+			Name:        "conditionPathPattern",
+			Label:       "Path Pattern",
+			Description: new(pathPatternDescription),
+			Type:        action_kit_api.ActionParameterTypeRegex,
+			Required:    new(false),
+		},
+	)
+	if withHttpMethod {
+		methodOptions := []action_kit_api.ParameterOption{action_kit_api.ExplicitParameterOption{Label: "*", Value: "*"}}
+		for _, method := range HttpMethods {
+			methodOptions = append(methodOptions, action_kit_api.ExplicitParameterOption{Label: method, Value: method})
+		}
+		desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
+			Name:         "conditionHttpMethod",
+			Label:        "HTTP Method",
+			Description:  new("Optional: only affect requests with this method."),
+			Type:         action_kit_api.ActionParameterTypeString,
+			DefaultValue: new("*"),
+			Required:     new(false),
+			Options:      new(methodOptions),
+		})
+	}
+	desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
+		Name:        "conditionHttpHeader",
+		Label:       "HTTP Header",
+		Description: new("Optional: only affect requests carrying all of these headers with exactly these values."),
+		Type:        action_kit_api.ActionParameterTypeKeyValue,
+		Required:    new(false),
+	})
+	return desc
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"testing"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRequestMatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    RequestMatcher
+		wantErr string
+	}{
+		{
+			name:   "no conditions",
+			config: map[string]any{},
+			want:   RequestMatcher{},
+		},
+		{
+			name: "all conditions",
+			config: map[string]any{
+				"conditionPathPattern": "/api/.*",
+				"conditionHttpMethod":  "POST",
+				"conditionHttpHeader":  []any{map[string]any{"key": "X-Canary", "value": "true"}},
+			},
+			want: RequestMatcher{PathPattern: "/api/.*", HttpMethod: "POST", HttpHeader: map[string]string{"X-Canary": "true"}},
+		},
+		{
+			name:   "any method",
+			config: map[string]any{"conditionHttpMethod": "*"},
+			want:   RequestMatcher{},
+		},
+		{
+			name:    "invalid path pattern",
+			config:  map[string]any{"conditionPathPattern": "/api/("},
+			wantErr: "invalid path pattern",
+		},
+		{
+			name:    "unsupported method",
+			config:  map[string]any{"conditionHttpMethod": "BREW"},
+			wantErr: "unsupported HTTP method \"BREW\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := ParseRequestMatcher(tt.config)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, matcher)
+			assert.Equal(t, tt.want.PathPattern == "" && tt.want.HttpMethod == "" && len(tt.want.HttpHeader) == 0, matcher.IsEmpty())
+		})
+	}
+}
+
+func TestPercentageFromConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    float64
+		wantErr bool
+	}{
+		{name: "defaults to 100", config: map[string]any{}, want: 100},
+		{name: "float", config: map[string]any{"percentage": 12.5}, want: 12.5},
+		{name: "int", config: map[string]any{"percentage": 30}, want: 30},
+		{name: "zero", config: map[string]any{"percentage": float64(0)}, wantErr: true},
+		{name: "beyond 100", config: map[string]any{"percentage": float64(101)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percentage, err := PercentageFromConfig(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, percentage)
+		})
+	}
+}
+
+func TestWithConditionParameters(t *testing.T) {
+	tests := []struct {
+		name           string
+		withHttpMethod bool
+		want           []string
+	}{
+		{
+			name: "without method",
+			want: []string{"-conditions-separator-", "-conditions-header-", "conditionPathPattern", "conditionHttpHeader"},
+		},
+		{
+			name:           "with method",
+			withHttpMethod: true,
+			want:           []string{"-conditions-separator-", "-conditions-header-", "conditionPathPattern", "conditionHttpMethod", "conditionHttpHeader"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := WithConditionParameters(action_kit_api.ActionDescription{}, "Optional: the path.", tt.withHttpMethod)
+
+			var names []string
+			for _, parameter := range desc.Parameters {
+				names = append(names, parameter.Name)
+			}
+			assert.Equal(t, tt.want, names)
+			assert.Equal(t, "Optional: the path.", *desc.Parameters[2].Description)
+		})
+	}
+}
+
+func TestSortDedup(t *testing.T) {
+	values := []string{"b", "a", "b"}
+
+	assert.Equal(t, []string{"a", "b"}, SortDedup(values))
+	assert.Equal(t, []string{"b", "a", "b"}, values, "the input must not be modified")
+	assert.Empty(t, SortDedup(nil))
+}
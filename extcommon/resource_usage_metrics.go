@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"maps"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+)
+
+// BuildPodResourceUsageMetrics produces the pod cpu/memory usage metrics charted by the resource usage
+// widgets. extraLabels are added to the pod labels (e.g. the node or owning workload).
+func BuildPodResourceUsageMetrics(usage client.ResourceUsage, extraLabels map[string]string) []action_kit_api.Metric {
+	labels := map[string]string{
+		"k8s.cluster-name": extconfig.Config.ClusterName,
+		"k8s.namespace":    usage.Namespace,
+		"k8s.pod.name":     usage.Name,
+	}
+	maps.Copy(labels, extraLabels)
+	return []action_kit_api.Metric{
+		{Name: new("pod_cpu_usage_millicores"), Metric: labels, Timestamp: usage.Timestamp, Value: float64(usage.CPU)},
+		{Name: new("pod_memory_usage_bytes"), Metric: labels, Timestamp: usage.Timestamp, Value: float64(usage.Memory)},
+	}
+}
+
+// BuildNodeResourceUsageMetrics produces the node cpu/memory usage metrics charted by the resource usage
+// widgets.
+func BuildNodeResourceUsageMetrics(usage client.ResourceUsage) []action_kit_api.Metric {
+	labels := map[string]string{
+		"k8s.cluster-name": extconfig.Config.ClusterName,
+		"k8s.node.name":    usage.Name,
+	}
+	return []action_kit_api.Metric{
+		{Name: new("node_cpu_usage_millicores"), Metric: labels, Timestamp: usage.Timestamp, Value: float64(usage.CPU)},
+		{Name: new("node_memory_usage_bytes"), Metric: labels, Timestamp: usage.Timestamp, Value: float64(usage.Memory)},
+	}
+}
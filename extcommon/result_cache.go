@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResultCache memoizes values computed during a discovery run, e.g. targets or kube-score results. A value is reused
+// by the next run as long as its fingerprint did not change, i.e. as long as none of the objects it was computed from
+// changed. Values that were not requested during a run are dropped at its end, so deleted objects do not leak.
+type ResultCache[V any] struct {
+	mu       sync.Mutex
+	previous map[string]cachedResult[V]
+	current  map[string]cachedResult[V]
+}
+
+type cachedResult[V any] struct {
+	fingerprint string
+	value       V
+}
+
+func NewResultCache[V any]() *ResultCache[V] {
+	return &ResultCache[V]{
+		previous: map[string]cachedResult[V]{},
+		current:  map[string]cachedResult[V]{},
+	}
+}
+
+// Get returns the cached value for the key if it was computed with the same fingerprint, otherwise it computes it.
+// Cached values are shared between runs and must not be modified by the caller. A nil cache computes every value.
+func (c *ResultCache[V]) Get(key string, fingerprint string, compute func() V) V {
+	if c == nil {
+		return compute()
+	}
+	c.mu.Lock()
+	if result, ok := c.current[key]; ok && result.fingerprint == fingerprint {
+		c.mu.Unlock()
+		return result.value
+	}
+	if result, ok := c.previous[key]; ok && result.fingerprint == fingerprint {
+		c.current[key] = result
+		c.mu.Unlock()
+		return result.value
+	}
+	c.mu.Unlock()
+
+	value := compute()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current[key] = cachedResult[V]{fingerprint: fingerprint, value: value}
+	return value
+}
+
+// EndRun drops all values that were not requested since the previous call to EndRun.
+func (c *ResultCache[V]) EndRun() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.previous = c.current
+	c.current = make(map[string]cachedResult[V], len(c.previous))
+}
+
+// Fingerprint identifies the state of the given objects. It changes whenever one of the objects is modified or
+// replaced, or when objects are added to or removed from the list. The order of the objects does not matter, as
+// listers return them in random order.
+func Fingerprint[T metav1.Object](objects ...T) string {
+	entries := make([]string, 0, len(objects))
+	for _, o := range objects {
+		entries = append(entries, string(o.GetUID())+"@"+o.GetResourceVersion())
+	}
+	slices.Sort(entries)
+	return strings.Join(entries, ";")
+}
+
+// PodsFingerprint identifies the pods and the nodes they are scheduled on, which contribute the host attributes.
+func PodsFingerprint(pods []*corev1.Pod, nodes []*corev1.Node) string {
+	nodeNames := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		nodeNames[pod.Spec.NodeName] = true
+	}
+	podNodes := make([]*corev1.Node, 0, len(nodeNames))
+	for _, node := range nodes {
+		if nodeNames[node.Name] {
+			podNodes = append(podNodes, node)
+		}
+	}
+	return Fingerprints(Fingerprint(pods...), Fingerprint(podNodes...))
+}
+
+// NamespaceFingerprint identifies the namespace if its labels are inherited by the targets.
+func NamespaceFingerprint(k8s *client.Client, namespace string) string {
+	if !extconfig.Config.DiscoveryLabelInheritanceNamespace || !k8s.Permissions().CanReadNamespaces() {
+		return ""
+	}
+	for _, ns := range k8s.Namespaces() {
+		if ns.Name == namespace {
+			return Fingerprint(ns)
+		}
+	}
+	return ""
+}
+
+// Fingerprints joins fingerprints of different object lists.
+func Fingerprints(fingerprints ...string) string {
+	return strings.Join(fingerprints, "|")
+}
+
+// GenerationFingerprint identifies the spec of the object, ignoring status-only updates.
+func GenerationFingerprint(o metav1.Object) string {
+	return string(o.GetUID()) + "#" + strconv.FormatInt(o.GetGeneration(), 10)
+}
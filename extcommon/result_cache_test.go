@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestResultCache(t *testing.T) {
+	cache := NewResultCache[int]()
+	computed := 0
+	compute := func() int {
+		computed++
+		return computed
+	}
+
+	// computes on first use
+	assert.Equal(t, 1, cache.Get("a", "v1", compute))
+	cache.EndRun()
+
+	// reuses the value of the previous run if the fingerprint matches
+	assert.Equal(t, 1, cache.Get("a", "v1", compute))
+	assert.Equal(t, 1, cache.Get("a", "v1", compute))
+	cache.EndRun()
+
+	// recomputes when the fingerprint changed
+	assert.Equal(t, 2, cache.Get("a", "v2", compute))
+	cache.EndRun()
+
+	// drops values not requested during a run
+	cache.EndRun()
+	assert.Equal(t, 3, cache.Get("a", "v2", compute))
+}
+
+func TestResultCache_Nil(t *testing.T) {
+	var cache *ResultCache[string]
+	assert.Equal(t, "value", cache.Get("a", "v1", func() string { return "value" }))
+	cache.EndRun()
+}
+
+func TestFingerprint(t *testing.T) {
+	pod := func(uid, resourceVersion string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: types.UID("uid-" + uid), ResourceVersion: resourceVersion}}
+	}
+
+	assert.Equal(t, Fingerprint(pod("1", "10"), pod("2", "20")), Fingerprint(pod("2", "20"), pod("1", "10")))
+	assert.NotEqual(t, Fingerprint(pod("1", "10"), pod("2", "20")), Fingerprint(pod("1", "10"), pod("2", "21")))
+	assert.NotEqual(t, Fingerprint(pod("1", "10"), pod("2", "20")), Fingerprint(pod("1", "10")))
+	assert.NotEqual(t, Fingerprint(pod("1", "10")), Fingerprint(pod("3", "10")))
+	assert.Empty(t, Fingerprint[*corev1.Pod]())
+}
+
+func TestKubeScoreFingerprintIgnoresStatusUpdates(t *testing.T) {
+	deployment := &metav1.ObjectMeta{UID: "deployment", Generation: 1, ResourceVersion: "10"}
+	before := KubeScoreFingerprint(deployment, nil, nil)
+
+	deployment.ResourceVersion = "11"
+	assert.Equal(t, before, KubeScoreFingerprint(deployment, nil, nil))
+
+	deployment.Generation = 2
+	assert.NotEqual(t, before, KubeScoreFingerprint(deployment, nil, nil))
+}
+
+func TestKubeScoreFingerprintIgnoresHPAStatusUpdates(t *testing.T) {
+	deployment := &metav1.ObjectMeta{UID: "deployment", Generation: 1}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{UID: "hpa", Generation: 1, ResourceVersion: "10"}}
+	before := KubeScoreFingerprint(deployment, nil, hpa)
+
+	hpa.ResourceVersion = "11"
+	assert.Equal(t, before, KubeScoreFingerprint(deployment, nil, hpa))
+
+	hpa.Generation = 2
+	assert.NotEqual(t, before, KubeScoreFingerprint(deployment, nil, hpa))
+	assert.NotEqual(t, before, KubeScoreFingerprint(deployment, nil, nil))
+}
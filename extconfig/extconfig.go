@@ -14,41 +14,51 @@ import (
 // https://github.com/kelseyhightower/envconfig
 type Specification struct {
 	advice_kit_sdk.AdviceConfig
-	ClusterName                             string   `required:"true" split_words:"true"`
-	LabelFilter                             []string `required:"false" split_words:"true" default:"controller-revision-hash,pod-template-generation,pod-template-hash"`
-	AdviceSingleReplicaMinReplicas          int      `json:"adviceSingleReplicaMinReplicas" split_words:"true" required:"false" default:"2"`
-	DisableDiscoveryExcludes                bool     `required:"false" split_words:"true" default:"false"`
-	LogKubernetesHttpRequests               bool     `required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledArgoRollout            bool     `json:"discoveryDisabledArgoRollout" required:"false" split_words:"true" default:"true"`
-	DiscoveryDisabledEnvoyGateway           bool     `json:"discoveryDisabledEnvoyGateway" required:"false" split_words:"true" default:"true"`
-	DiscoveryDisabledCluster                bool     `json:"discoveryDisabledCluster" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledContainer              bool     `json:"discoveryDisabledContainer" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledDaemonSet              bool     `json:"discoveryDisabledDaemonSet" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledDeployment             bool     `json:"discoveryDisabledDeployment" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledIngress                bool     `json:"discoveryDisabledIngress" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledNode                   bool     `json:"discoveryDisabledNode" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledPod                    bool     `json:"discoveryDisabledPod" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledReplicaSet             bool     `json:"discoveryDisabledReplicaSet" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledStatefulSet            bool     `json:"discoveryDisabledStatefulSet" required:"false" split_words:"true" default:"false"`
-	DiscoveryLabelInheritanceNamespace      bool     `json:"discoveryLabelInheritanceNamespace" required:"false" split_words:"true" default:"true"`
-	DiscoveryLabelInheritanceNode           bool     `json:"discoveryLabelInheritanceNode" required:"false" split_words:"true" default:"true"`
-	DiscoveryLabelInheritancePod            bool     `json:"discoveryLabelInheritancePod" required:"false" split_words:"true" default:"true"`
-	DiscoveryAttributesExcludesContainer    []string `json:"discoveryAttributesExcludesContainer" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesDaemonSet    []string `json:"discoveryAttributesExcludesDaemonSet" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesDeployment   []string `json:"discoveryAttributesExcludesDeployment" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesIngress      []string `json:"discoveryAttributesExcludesIngress" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesNode         []string `json:"discoveryAttributesExcludesNode" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesPod          []string `json:"discoveryAttributesExcludesPod" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesReplicaSet   []string `json:"discoveryAttributesExcludesReplicaSet" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesStatefulSet  []string `json:"discoveryAttributesExcludesStatefulSet" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesArgoRollout  []string `json:"discoveryAttributesExcludesArgoRollout" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesEnvoyGateway []string `json:"discoveryAttributesExcludesEnvoyGateway" split_words:"true" required:"false"`
-	DiscoveryMaxPodCount                    int      `json:"discoveryMaxPodCount" split_words:"true" required:"false" default:"50"`
-	DiscoveryRefreshThrottle                int      `json:"DiscoveryRefreshThrottle" required:"false" split_words:"true" default:"20"`
-	DiscoveryInformerResync                 int      `json:"DiscoveryInformerResync" required:"false" split_words:"true" default:"600"`
-	Namespace                               string   `json:"namespace" split_words:"true" required:"false" default:""`
-	NginxDelaySkipImageCheck                bool     `json:"nginxDelaySkipImageCheck" split_words:"true" required:"false" default:"false"`
-	PrintMemoryStatsInterval                int64    `json:"printMemoryStatsInterval" split_words:"true" required:"false" default:"0"`
+	ClusterName                              string   `required:"true" split_words:"true"`
+	LabelFilter                              []string `required:"false" split_words:"true" default:"controller-revision-hash,pod-template-generation,pod-template-hash"`
+	AdviceSingleReplicaMinReplicas           int      `json:"adviceSingleReplicaMinReplicas" split_words:"true" required:"false" default:"2"`
+	DisableDiscoveryExcludes                 bool     `required:"false" split_words:"true" default:"false"`
+	LogKubernetesHttpRequests                bool     `required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledArgoRollout             bool     `json:"discoveryDisabledArgoRollout" required:"false" split_words:"true" default:"true"`
+	DiscoveryDisabledEnvoyGateway            bool     `json:"discoveryDisabledEnvoyGateway" required:"false" split_words:"true" default:"true"`
+	DiscoveryDisabledGatewayApi              bool     `json:"discoveryDisabledGatewayApi" required:"false" split_words:"true" default:"true"`
+	DiscoveryDisabledTraefik                 bool     `json:"discoveryDisabledTraefik" required:"false" split_words:"true" default:"true"`
+	DiscoveryDisabledIstio                   bool     `json:"discoveryDisabledIstio" required:"false" split_words:"true" default:"true"`
+	DiscoveryDisabledCluster                 bool     `json:"discoveryDisabledCluster" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledContainer               bool     `json:"discoveryDisabledContainer" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledDaemonSet               bool     `json:"discoveryDisabledDaemonSet" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledDeployment              bool     `json:"discoveryDisabledDeployment" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledIngress                 bool     `json:"discoveryDisabledIngress" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledNode                    bool     `json:"discoveryDisabledNode" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledPod                     bool     `json:"discoveryDisabledPod" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledReplicaSet              bool     `json:"discoveryDisabledReplicaSet" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledStatefulSet             bool     `json:"discoveryDisabledStatefulSet" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledWorkloadShard           bool     `json:"discoveryDisabledWorkloadShard" required:"false" split_words:"true" default:"false"`
+	DiscoveryLabelInheritanceNamespace       bool     `json:"discoveryLabelInheritanceNamespace" required:"false" split_words:"true" default:"true"`
+	DiscoveryLabelInheritanceNode            bool     `json:"discoveryLabelInheritanceNode" required:"false" split_words:"true" default:"true"`
+	DiscoveryLabelInheritancePod             bool     `json:"discoveryLabelInheritancePod" required:"false" split_words:"true" default:"true"`
+	DiscoveryAttributesExcludesContainer     []string `json:"discoveryAttributesExcludesContainer" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesDaemonSet     []string `json:"discoveryAttributesExcludesDaemonSet" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesDeployment    []string `json:"discoveryAttributesExcludesDeployment" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesIngress       []string `json:"discoveryAttributesExcludesIngress" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesNode          []string `json:"discoveryAttributesExcludesNode" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesPod           []string `json:"discoveryAttributesExcludesPod" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesReplicaSet    []string `json:"discoveryAttributesExcludesReplicaSet" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesStatefulSet   []string `json:"discoveryAttributesExcludesStatefulSet" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesArgoRollout   []string `json:"discoveryAttributesExcludesArgoRollout" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesEnvoyGateway  []string `json:"discoveryAttributesExcludesEnvoyGateway" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesGatewayApi    []string `json:"discoveryAttributesExcludesGatewayApi" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesTraefik       []string `json:"discoveryAttributesExcludesTraefik" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesIstio         []string `json:"discoveryAttributesExcludesIstio" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesWorkloadShard []string `json:"discoveryAttributesExcludesWorkloadShard" split_words:"true" required:"false"`
+	DiscoveryMaxPodCount                     int      `json:"discoveryMaxPodCount" split_words:"true" required:"false" default:"50"`
+	DiscoveryRefreshThrottle                 int      `json:"DiscoveryRefreshThrottle" required:"false" split_words:"true" default:"20"`
+	DiscoveryInformerResync                  int      `json:"DiscoveryInformerResync" required:"false" split_words:"true" default:"600"`
+	Namespace                                string   `json:"namespace" split_words:"true" required:"false" default:""`
+	NginxDelaySkipImageCheck                 bool     `json:"nginxDelaySkipImageCheck" split_words:"true" required:"false" default:"false"`
+	IngressSkipConfigValidation              bool     `json:"ingressSkipConfigValidation" split_words:"true" required:"false" default:"false"`
+	TraefikFaultUrl                          string   `json:"traefikFaultUrl" split_words:"true" required:"false" default:""`
+	PrintMemoryStatsInterval                 int64    `json:"printMemoryStatsInterval" split_words:"true" required:"false" default:"0"`
 }
 
 var (
@@ -22,7 +22,9 @@ import (
 )
 
 type daemonSetDiscovery struct {
-	k8s *client.Client
+	k8s        *client.Client
+	targets    *extcommon.ResultCache[discovery_kit_api.Target]
+	kubeScores *extcommon.ResultCache[map[string][]string]
 }
 
 var (
@@ -31,7 +33,11 @@ var (
 )
 
 func NewDaemonSetDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &daemonSetDiscovery{k8s: k8s}
+	discovery := &daemonSetDiscovery{
+		k8s:        k8s,
+		targets:    extcommon.NewResultCache[discovery_kit_api.Target](),
+		kubeScores: extcommon.NewResultCache[map[string][]string](),
+	}
 	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
 		reflect.TypeFor[corev1.Pod](),
 		reflect.TypeFor[appsv1.DaemonSet](),
@@ -87,45 +93,78 @@ func (d *daemonSetDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit
 		filteredDaemonSets = append(filteredDaemonSets, ds)
 	}
 
+	defer d.targets.EndRun()
+	defer d.kubeScores.EndRun()
+
 	nodes := d.k8s.Nodes()
 	targets := make([]discovery_kit_api.Target, len(filteredDaemonSets))
 	for i, ds := range filteredDaemonSets {
-		attributes := map[string][]string{
-			"k8s.namespace":      {ds.Namespace},
-			"k8s.daemonset":      {ds.Name},
-			"k8s.workload-type":  {"daemonset"},
-			"k8s.workload-owner": {ds.Name},
-			"k8s.cluster-name":   {extconfig.Config.ClusterName},
-			"k8s.distribution":   {d.k8s.Distribution},
+		pods := d.k8s.PodsByOwnerUid(ds.UID, ds.Namespace)
+		services := d.k8s.ServicesMatchingToPodLabels(ds.Namespace, ds.Spec.Template.Labels)
+		var pdbs []*policyv1.PodDisruptionBudget
+		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+			pdbs = d.k8s.PodDisruptionBudgetsForPodLabels(ds.Namespace, ds.Spec.Template.Labels)
 		}
 
-		extcommon.AddLabels(attributes, ds.ObjectMeta.Labels, "k8s.daemonset.label", "k8s.label")
-		extcommon.AddNamespaceLabels(attributes, d.k8s, ds.Namespace)
-
-		extcommon.MergeAttributes(
-			attributes,
-			extcommon.GetPodBasedAttributes("daemonset", ds.ObjectMeta, d.k8s.PodsByOwnerUid(ds.UID, ds.Namespace), nodes),
-			extcommon.GetServiceNames(d.k8s.ServicesMatchingToPodLabels(ds.Namespace, ds.Spec.Template.Labels)),
+		fingerprint := extcommon.Fingerprints(
+			extcommon.Fingerprint(ds),
+			extcommon.PodsFingerprint(pods, nodes),
+			extcommon.Fingerprint(services...),
+			extcommon.Fingerprint(pdbs...),
+			extcommon.NamespaceFingerprint(d.k8s, ds.Namespace),
 		)
+		// kube-score results are requested for every daemonset, cached targets included, so EndRun keeps them
+		kubeScore := d.kubeScore(ds, services)
+		targets[i] = d.targets.Get(string(ds.UID), fingerprint, func() discovery_kit_api.Target {
+			return d.toTarget(ds, pods, nodes, services, pdbs, kubeScore)
+		})
+	}
+	return targets, nil
+}
 
-		if !extconfig.Config.DisableAdvice {
-			extcommon.MergeAttributes(attributes, extcommon.GetKubeScoreForDaemonSet(ds, d.k8s.ServicesMatchingToPodLabels(ds.Namespace, ds.Spec.Template.Labels)))
-		}
+func (d *daemonSetDiscovery) kubeScore(ds *appsv1.DaemonSet, services []*corev1.Service) map[string][]string {
+	if extconfig.Config.DisableAdvice {
+		return nil
+	}
+	return d.kubeScores.Get(string(ds.UID), extcommon.KubeScoreFingerprint(ds, services, nil), func() map[string][]string {
+		return extcommon.GetKubeScoreForDaemonSet(ds, services)
+	})
+}
 
-		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
-			extcommon.AddPdbAttributes(attributes, d.k8s.PodDisruptionBudgetsForPodLabels(ds.Namespace, ds.Spec.Template.Labels))
-		}
+func (d *daemonSetDiscovery) toTarget(ds *appsv1.DaemonSet, pods []*corev1.Pod, nodes []*corev1.Node, services []*corev1.Service, pdbs []*policyv1.PodDisruptionBudget, kubeScore map[string][]string) discovery_kit_api.Target {
+	attributes := map[string][]string{
+		"k8s.namespace":      {ds.Namespace},
+		"k8s.daemonset":      {ds.Name},
+		"k8s.workload-type":  {"daemonset"},
+		"k8s.workload-owner": {ds.Name},
+		"k8s.cluster-name":   {extconfig.Config.ClusterName},
+		"k8s.distribution":   {d.k8s.Distribution},
+	}
 
-		extcommon.AddProbePathAttributes(attributes, ds.Spec.Template.Spec.Containers)
+	extcommon.AddLabels(attributes, ds.ObjectMeta.Labels, "k8s.daemonset.label", "k8s.label")
+	extcommon.AddNamespaceLabels(attributes, d.k8s, ds.Namespace)
 
-		targets[i] = discovery_kit_api.Target{
-			Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, ds.Namespace, ds.Name),
-			TargetType: DaemonSetTargetType,
-			Label:      ds.Name,
-			Attributes: attributes,
-		}
+	extcommon.MergeAttributes(
+		attributes,
+		extcommon.GetPodBasedAttributes("daemonset", ds.ObjectMeta, pods, nodes),
+		extcommon.GetServiceNames(services),
+	)
+
+	extcommon.MergeAttributes(attributes, kubeScore)
+
+	if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+		extcommon.AddPdbAttributes(attributes, pdbs)
+	}
+
+	extcommon.AddProbePathAttributes(attributes, ds.Spec.Template.Spec.Containers)
+
+	target := discovery_kit_api.Target{
+		Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, ds.Namespace, ds.Name),
+		TargetType: DaemonSetTargetType,
+		Label:      ds.Name,
+		Attributes: attributes,
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesDaemonSet), nil
+	return discovery_kit_commons.ApplyAttributeExcludes([]discovery_kit_api.Target{target}, extconfig.Config.DiscoveryAttributesExcludesDaemonSet)[0]
 }
 
 func (d *daemonSetDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
@@ -19,10 +19,15 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 )
 
 type deploymentDiscovery struct {
-	k8s *client.Client
+	k8s        *client.Client
+	targets    *extcommon.ResultCache[discovery_kit_api.Target]
+	kubeScores *extcommon.ResultCache[map[string][]string]
+	// getKubeScore runs kube-score, extcommon.GetKubeScoreForDeployment if nil.
+	getKubeScore func(deployment *appsv1.Deployment, services []*corev1.Service, hpa *autoscalingv2.HorizontalPodAutoscaler) map[string][]string
 }
 
 var (
@@ -31,7 +36,11 @@ var (
 )
 
 func NewDeploymentDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &deploymentDiscovery{k8s: k8s}
+	discovery := &deploymentDiscovery{
+		k8s:        k8s,
+		targets:    extcommon.NewResultCache[discovery_kit_api.Target](),
+		kubeScores: extcommon.NewResultCache[map[string][]string](),
+	}
 	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
 		reflect.TypeFor[corev1.Pod](),
 		reflect.TypeFor[appsv1.Deployment](),
@@ -87,63 +96,101 @@ func (d *deploymentDiscovery) DiscoverTargets(_ context.Context) ([]discovery_ki
 		filteredDeployments = append(filteredDeployments, deployment)
 	}
 
+	defer d.targets.EndRun()
+	defer d.kubeScores.EndRun()
+
 	nodes := d.k8s.Nodes()
 	targets := make([]discovery_kit_api.Target, len(filteredDeployments))
 	for i, deployment := range filteredDeployments {
-		attributes := map[string][]string{
-			"k8s.namespace":                    {deployment.Namespace},
-			"k8s.deployment":                   {deployment.Name},
-			"k8s.workload-type":                {"deployment"},
-			"k8s.workload-owner":               {deployment.Name},
-			"k8s.cluster-name":                 {extconfig.Config.ClusterName},
-			"k8s.distribution":                 {d.k8s.Distribution},
-			"k8s.deployment.min-ready-seconds": {fmt.Sprintf("%d", deployment.Spec.MinReadySeconds)},
-			"k8s.container.name":               {},
-		}
-		if deployment.Spec.Replicas != nil {
-			attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", *deployment.Spec.Replicas)}
-		}
-
-		extcommon.AddLabels(attributes, deployment.ObjectMeta.Labels, "k8s.deployment.label", "k8s.label")
-		extcommon.AddNamespaceLabels(attributes, d.k8s, deployment.Namespace)
-
-		extcommon.MergeAttributes(
-			attributes,
-			extcommon.GetPodBasedAttributes("deployment", deployment.ObjectMeta, d.k8s.PodsOwnedByDeployment(deployment.UID, deployment.Namespace), nodes),
-			extcommon.GetServiceNames(d.k8s.ServicesMatchingToPodLabels(deployment.Namespace, deployment.Spec.Template.Labels)),
-		)
-
+		pods := d.k8s.PodsOwnedByDeployment(deployment.UID, deployment.Namespace)
+		services := d.k8s.ServicesMatchingToPodLabels(deployment.Namespace, deployment.Spec.Template.Labels)
 		var hpas []*autoscalingv2.HorizontalPodAutoscaler
 		if d.k8s.Permissions().CanReadHorizontalPodAutoscalers() {
 			hpas = d.k8s.HorizontalPodAutoscalersByNamespaceKindAndName(deployment.Namespace, "Deployment", deployment.Name)
 		}
-		var firstHpa *autoscalingv2.HorizontalPodAutoscaler
-		if len(hpas) > 0 {
-			firstHpa = hpas[0]
+		var pdbs []*policyv1.PodDisruptionBudget
+		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+			pdbs = d.k8s.PodDisruptionBudgetsForPodLabels(deployment.Namespace, deployment.Spec.Template.Labels)
 		}
 
-		if !extconfig.Config.DisableAdvice {
-			extcommon.MergeAttributes(attributes, extcommon.GetKubeScoreForDeployment(deployment, d.k8s.ServicesMatchingToPodLabels(deployment.Namespace, deployment.Spec.Template.Labels), firstHpa))
-		}
+		fingerprint := extcommon.Fingerprints(
+			extcommon.Fingerprint(deployment),
+			extcommon.PodsFingerprint(pods, nodes),
+			extcommon.Fingerprint(services...),
+			extcommon.Fingerprint(hpas...),
+			extcommon.Fingerprint(pdbs...),
+			extcommon.NamespaceFingerprint(d.k8s, deployment.Namespace),
+		)
+		// kube-score results are requested for every deployment, cached targets included, so EndRun keeps them
+		kubeScore := d.kubeScore(deployment, services, hpas)
+		targets[i] = d.targets.Get(string(deployment.UID), fingerprint, func() discovery_kit_api.Target {
+			return d.toTarget(deployment, pods, nodes, services, hpas, pdbs, kubeScore)
+		})
+	}
+	return targets, nil
+}
 
-		extcommon.AddHpaAttributes(attributes, hpas)
-		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
-			extcommon.AddPdbAttributes(attributes, d.k8s.PodDisruptionBudgetsForPodLabels(deployment.Namespace, deployment.Spec.Template.Labels))
-		}
+func (d *deploymentDiscovery) kubeScore(deployment *appsv1.Deployment, services []*corev1.Service, hpas []*autoscalingv2.HorizontalPodAutoscaler) map[string][]string {
+	if extconfig.Config.DisableAdvice {
+		return nil
+	}
+	var firstHpa *autoscalingv2.HorizontalPodAutoscaler
+	if len(hpas) > 0 {
+		firstHpa = hpas[0]
+	}
+	getKubeScore := d.getKubeScore
+	if getKubeScore == nil {
+		getKubeScore = extcommon.GetKubeScoreForDeployment
+	}
+	return d.kubeScores.Get(string(deployment.UID), extcommon.KubeScoreFingerprint(deployment, services, firstHpa), func() map[string][]string {
+		return getKubeScore(deployment, services, firstHpa)
+	})
+}
 
-		for container := range deployment.Spec.Template.Spec.Containers {
-			attributes["k8s.container.name"] = append(attributes["k8s.container.name"], deployment.Spec.Template.Spec.Containers[container].Name)
-		}
-		extcommon.AddProbePathAttributes(attributes, deployment.Spec.Template.Spec.Containers)
+func (d *deploymentDiscovery) toTarget(deployment *appsv1.Deployment, pods []*corev1.Pod, nodes []*corev1.Node, services []*corev1.Service, hpas []*autoscalingv2.HorizontalPodAutoscaler, pdbs []*policyv1.PodDisruptionBudget, kubeScore map[string][]string) discovery_kit_api.Target {
+	attributes := map[string][]string{
+		"k8s.namespace":                    {deployment.Namespace},
+		"k8s.deployment":                   {deployment.Name},
+		"k8s.workload-type":                {"deployment"},
+		"k8s.workload-owner":               {deployment.Name},
+		"k8s.cluster-name":                 {extconfig.Config.ClusterName},
+		"k8s.distribution":                 {d.k8s.Distribution},
+		"k8s.deployment.min-ready-seconds": {fmt.Sprintf("%d", deployment.Spec.MinReadySeconds)},
+		"k8s.container.name":               {},
+	}
+	if deployment.Spec.Replicas != nil {
+		attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", *deployment.Spec.Replicas)}
+	}
 
-		targets[i] = discovery_kit_api.Target{
-			Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, deployment.Namespace, deployment.Name),
-			TargetType: DeploymentTargetType,
-			Label:      deployment.Name,
-			Attributes: attributes,
-		}
+	extcommon.AddLabels(attributes, deployment.ObjectMeta.Labels, "k8s.deployment.label", "k8s.label")
+	extcommon.AddNamespaceLabels(attributes, d.k8s, deployment.Namespace)
+
+	extcommon.MergeAttributes(
+		attributes,
+		extcommon.GetPodBasedAttributes("deployment", deployment.ObjectMeta, pods, nodes),
+		extcommon.GetServiceNames(services),
+	)
+
+	extcommon.MergeAttributes(attributes, kubeScore)
+
+	extcommon.AddHpaAttributes(attributes, hpas)
+	if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+		extcommon.AddPdbAttributes(attributes, pdbs)
+	}
+
+	for container := range deployment.Spec.Template.Spec.Containers {
+		attributes["k8s.container.name"] = append(attributes["k8s.container.name"], deployment.Spec.Template.Spec.Containers[container].Name)
+	}
+	extcommon.AddProbePathAttributes(attributes, deployment.Spec.Template.Spec.Containers)
+
+	target := discovery_kit_api.Target{
+		Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, deployment.Namespace, deployment.Name),
+		TargetType: DeploymentTargetType,
+		Label:      deployment.Name,
+		Attributes: attributes,
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesDeployment), nil
+	// excludes are applied per target, as cached targets must not be modified once they are handed out
+	return discovery_kit_commons.ApplyAttributeExcludes([]discovery_kit_api.Target{target}, extconfig.Config.DiscoveryAttributesExcludesDeployment)[0]
 }
 
 func (d *deploymentDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
@@ -10,7 +10,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/steadybit/extension-kubernetes/v2/extconfig"
 	"github.com/steadybit/extension-kubernetes/v2/testutil"
 	"github.com/stretchr/testify/assert"
@@ -766,6 +768,76 @@ func Test_getDiscoveredDeploymentsShouldNotIgnoreLabeledDeploymentsIfExcludesDis
 	}, 5*time.Second, 100*time.Millisecond)
 }
 
+func Test_deploymentDiscoveryRunsKubeScoreOncePerGeneration(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	shop := testDeployment(func(deployment *appsv1.Deployment) {
+		deployment.Generation = 1
+		deployment.ResourceVersion = "1"
+	})
+	cart := testDeployment(func(deployment *appsv1.Deployment) {
+		deployment.Name = "cart"
+		deployment.UID = "deployment-cart-uid"
+		deployment.Generation = 1
+		deployment.ResourceVersion = "1"
+	})
+	clientset := testclient.NewClientset(shop, cart)
+	k8s := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted(), testutil.NewFakeDynamicClient())
+	extconfig.Config.DisableAdvice = false
+
+	runs := map[string]int{}
+	d := &deploymentDiscovery{
+		k8s:        k8s,
+		targets:    extcommon.NewResultCache[discovery_kit_api.Target](),
+		kubeScores: extcommon.NewResultCache[map[string][]string](),
+		getKubeScore: func(deployment *appsv1.Deployment, _ []*corev1.Service, _ *autoscalingv2.HorizontalPodAutoscaler) map[string][]string {
+			runs[extcommon.GenerationFingerprint(deployment)]++
+			return map[string][]string{}
+		},
+	}
+	discover := func(resourceVersion string) {
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			deployment := k8s.DeploymentByNamespaceAndName("default", "shop")
+			if assert.NotNil(c, deployment) {
+				assert.Equal(c, resourceVersion, deployment.ResourceVersion)
+			}
+		}, 5*time.Second, 10*time.Millisecond)
+		targets, err := d.DiscoverTargets(context.Background())
+		require.NoError(t, err)
+		require.Len(t, targets, 2)
+	}
+
+	// When
+	discover("1")
+	discover("1")
+
+	// Then
+	assert.Equal(t, map[string]int{"deployment-shop-uid#1": 1, "deployment-cart-uid#1": 1}, runs)
+
+	// When the status changes, the target is rebuilt from the cached kube-score
+	shop.ResourceVersion = "2"
+	shop.Status.ReadyReplicas = 2
+	_, err := clientset.AppsV1().Deployments("default").UpdateStatus(context.Background(), shop, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	discover("2")
+
+	// Then
+	assert.Equal(t, map[string]int{"deployment-shop-uid#1": 1, "deployment-cart-uid#1": 1}, runs)
+
+	// When the spec changes
+	shop.ResourceVersion = "3"
+	shop.Generation = 2
+	shop.Spec.MinReadySeconds = 20
+	_, err = clientset.AppsV1().Deployments("default").Update(context.Background(), shop, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	discover("3")
+	discover("3")
+
+	// Then
+	assert.Equal(t, map[string]int{"deployment-shop-uid#1": 1, "deployment-shop-uid#2": 1, "deployment-cart-uid#1": 1}, runs)
+}
+
 func getTestClient(stopCh <-chan struct{}, objects ...runtime.Object) *client.Client {
 	dynamicClient := testutil.NewFakeDynamicClient()
 	return client.CreateClient(testclient.NewClientset(objects...), stopCh, "", client.MockAllPermitted(), dynamicClient)
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extdiff
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const LogType = "RESOURCE_DIFF"
+
+type ResourceDiffAction struct {
+	actionId           string
+	targetType         string
+	targetTypeLabel    string
+	selectionTemplates *action_kit_api.TargetSelectionTemplates
+	// resource resolves the object whose spec is captured from the target.
+	resource func(request action_kit_api.PrepareActionRequestBody) Resource
+}
+
+// Resource references the captured object. Namespace is empty for cluster-scoped resources.
+type Resource struct {
+	Kind      string                      `json:"kind"`
+	GVR       schema.GroupVersionResource `json:"gvr"`
+	Namespace string                      `json:"namespace"`
+	Name      string                      `json:"name"`
+}
+
+type ResourceDiffState struct {
+	End            time.Time
+	Resource       Resource
+	CaptureChanges bool
+	// Initial is the snapshot taken when the step started, Last the most recent one.
+	Initial string
+	Last    string
+}
+
+type ResourceDiffConfig struct {
+	Duration       int
+	CaptureChanges bool
+}
+
+var _ action_kit_sdk.Action[ResourceDiffState] = (*ResourceDiffAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[ResourceDiffState] = (*ResourceDiffAction)(nil)
+var _ action_kit_sdk.ActionWithStop[ResourceDiffState] = (*ResourceDiffAction)(nil)
+
+func (f ResourceDiffAction) NewEmptyState() ResourceDiffState {
+	return ResourceDiffState{}
+}
+
+func (f ResourceDiffAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          f.actionId,
+		Label:       "Capture " + f.targetTypeLabel + " Diff",
+		Description: "Captures the spec of the " + f.targetTypeLabel + " at the start and the end of the step and reports the changes as unified diff.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new("data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%3Cpath%20d%3D%22M6%202h9l5%205v15H6V2zm8%201.5V8h4.5L14%203.5zM9%2011h2V9h1.5v2h2v1.5h-2v2H11v-2H9V11zm0%206h5.5v1.5H9V17z%22%20fill%3D%22currentColor%22%2F%3E%3C%2Fsvg%3E"),
+		Technology:  new("Kubernetes"),
+
+		TimeControl: action_kit_api.TimeControlInternal,
+		Kind:        action_kit_api.Other,
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType:          f.targetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.QuantityRestrictionExactlyOne),
+			SelectionTemplates:  f.selectionTemplates,
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new(""),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:         "captureChanges",
+				Label:        "Report every change",
+				Description:  new("Report a diff whenever the spec changes during the step, not only the overall diff at the end."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: new("false"),
+				Order:        new(2),
+				Required:     new(false),
+				Advanced:     new(true),
+			},
+		},
+		Widgets: new([]action_kit_api.Widget{
+			action_kit_api.LogWidget{
+				Type:    action_kit_api.ComSteadybitWidgetLog,
+				Title:   f.targetTypeLabel + " Diff",
+				LogType: LogType,
+			},
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("2s"),
+		}),
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f ResourceDiffAction) Prepare(_ context.Context, state *ResourceDiffState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config ResourceDiffConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.End = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.CaptureChanges = config.CaptureChanges
+	state.Resource = f.resource(request)
+	return nil, nil
+}
+
+func (f ResourceDiffAction) Start(ctx context.Context, state *ResourceDiffState) (*action_kit_api.StartResult, error) {
+	return startInternal(ctx, client.K8S, state)
+}
+
+func startInternal(ctx context.Context, k8s *client.Client, state *ResourceDiffState) (*action_kit_api.StartResult, error) {
+	snapshot, err := state.snapshot(ctx, k8s)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to capture %s.", state.Resource.displayName()), err)
+	}
+	state.Initial = snapshot
+	state.Last = snapshot
+	return nil, nil
+}
+
+func (f ResourceDiffAction) Status(ctx context.Context, state *ResourceDiffState) (*action_kit_api.StatusResult, error) {
+	return statusInternal(ctx, client.K8S, state), nil
+}
+
+func statusInternal(ctx context.Context, k8s *client.Client, state *ResourceDiffState) *action_kit_api.StatusResult {
+	result := &action_kit_api.StatusResult{
+		Completed: time.Now().After(state.End),
+	}
+	if !state.CaptureChanges {
+		return result
+	}
+
+	snapshot, err := state.snapshot(ctx, k8s)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to capture %s", state.Resource.displayName())
+		return result
+	}
+	if diff := (client.UnifiedDiff{OldLabel: "previous", NewLabel: "current", A: state.Last, B: snapshot}).String(); diff != "" {
+		result.Messages = new([]action_kit_api.Message{state.Resource.diffMessage("changed", diff)})
+	}
+	state.Last = snapshot
+	return result
+}
+
+func (f ResourceDiffAction) Stop(ctx context.Context, state *ResourceDiffState) (*action_kit_api.StopResult, error) {
+	return stopInternal(ctx, client.K8S, state)
+}
+
+func stopInternal(ctx context.Context, k8s *client.Client, state *ResourceDiffState) (*action_kit_api.StopResult, error) {
+	if state.Initial == "" {
+		// the step failed before the initial snapshot was taken
+		return nil, nil
+	}
+
+	final, err := state.snapshot(ctx, k8s)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to capture %s.", state.Resource.displayName()), err)
+	}
+
+	diff := client.UnifiedDiff{OldLabel: "start", NewLabel: "end", A: state.Initial, B: final}.String()
+	if diff == "" {
+		return new(action_kit_api.StopResult{
+			Messages: new([]action_kit_api.Message{{
+				Message: fmt.Sprintf("No changes to %s.", state.Resource.displayName()),
+				Type:    new(LogType),
+				Level:   extutil.Ptr(action_kit_api.Info),
+			}}),
+		}), nil
+	}
+
+	return new(action_kit_api.StopResult{
+		Messages: new([]action_kit_api.Message{state.Resource.diffMessage("changed during the step", diff)}),
+		Artifacts: new([]action_kit_api.Artifact{{
+			Label: state.Resource.artifactLabel(),
+			Data:  base64.StdEncoding.EncodeToString([]byte(diff)),
+		}}),
+	}), nil
+}
+
+func (s *ResourceDiffState) snapshot(ctx context.Context, k8s *client.Client) (string, error) {
+	return k8s.ResourceSnapshot(ctx, s.Resource.GVR, s.Resource.Namespace, s.Resource.Name)
+}
+
+func (r Resource) displayName() string {
+	if r.Namespace == "" {
+		return strings.ToLower(r.Kind) + "/" + r.Name
+	}
+	return strings.ToLower(r.Kind) + "/" + r.Namespace + "/" + r.Name
+}
+
+func (r Resource) artifactLabel() string {
+	return strings.ReplaceAll(r.displayName(), "/", "-") + ".diff"
+}
+
+func (r Resource) diffMessage(what string, diff string) action_kit_api.Message {
+	return action_kit_api.Message{
+		Message:   fmt.Sprintf("%s %s:\n%s", r.displayName(), what, diff),
+		Type:      new(LogType),
+		Level:     extutil.Ptr(action_kit_api.Info),
+		Timestamp: new(time.Now()),
+		Fields: &action_kit_api.MessageFields{
+			"kind":      r.Kind,
+			"namespace": r.Namespace,
+			"name":      r.Name,
+		},
+	}
+}
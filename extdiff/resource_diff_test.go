@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extdiff
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPrepareResolvesResource(t *testing.T) {
+	// Given
+	action := NewNginxIngressDiffAction()
+	state := action.NewEmptyState()
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"duration":       1000 * 30,
+			"captureChanges": true,
+		},
+		Target: &action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace": {"shop"},
+				"k8s.ingress":   {"checkout"},
+			},
+		},
+	}
+
+	// When
+	result, err := action.Prepare(context.TODO(), &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.True(t, state.CaptureChanges)
+	require.Equal(t, Resource{Kind: "Ingress", GVR: client.IngressGVR, Namespace: "shop", Name: "checkout"}, state.Resource)
+}
+
+func TestDiffOfChangedResource(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	dynamicClient := testutil.NewFakeDynamicClient()
+	deployment := createDeployment(t, dynamicClient, "nginx:1.25")
+	k8s := client.CreateClient(testclient.NewClientset(), stopCh, "", client.MockAllPermitted(), dynamicClient)
+	state := ResourceDiffState{
+		End:            time.Now().Add(time.Minute),
+		Resource:       Resource{Kind: "Deployment", GVR: client.DeploymentGVR, Namespace: "shop", Name: "checkout"},
+		CaptureChanges: true,
+	}
+
+	// When
+	_, err := startInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+	require.NotContains(t, state.Initial, "status")
+	require.NotContains(t, state.Initial, "resourceVersion")
+
+	result := statusInternal(context.Background(), k8s, &state)
+	require.Nil(t, result.Messages)
+
+	updateImage(t, dynamicClient, deployment, "nginx:1.26")
+	result = statusInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.Len(t, *result.Messages, 1)
+	require.Contains(t, (*result.Messages)[0].Message, "-      - image: nginx:1.25")
+	require.Contains(t, (*result.Messages)[0].Message, "+      - image: nginx:1.26")
+
+	stopResult, err := stopInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+	require.Len(t, *stopResult.Artifacts, 1)
+	artifact := (*stopResult.Artifacts)[0]
+	require.Equal(t, "deployment-shop-checkout.diff", artifact.Label)
+	diff, err := base64.StdEncoding.DecodeString(artifact.Data)
+	require.NoError(t, err)
+	require.Contains(t, string(diff), "+      - image: nginx:1.26")
+}
+
+func TestStopReportsNoChanges(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	dynamicClient := testutil.NewFakeDynamicClient()
+	createDeployment(t, dynamicClient, "nginx:1.25")
+	k8s := client.CreateClient(testclient.NewClientset(), stopCh, "", client.MockAllPermitted(), dynamicClient)
+	state := ResourceDiffState{
+		End:      time.Now().Add(time.Minute),
+		Resource: Resource{Kind: "Deployment", GVR: client.DeploymentGVR, Namespace: "shop", Name: "checkout"},
+	}
+	_, err := startInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+
+	// When
+	result, err := stopInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result.Artifacts)
+	require.Equal(t, "No changes to deployment/shop/checkout.", (*result.Messages)[0].Message)
+}
+
+func createDeployment(t *testing.T, dynamicClient *fake.FakeDynamicClient, image string) *unstructured.Unstructured {
+	deployment := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":            "checkout",
+			"namespace":       "shop",
+			"resourceVersion": "1",
+		},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "nginx", "image": image},
+					},
+				},
+			},
+		},
+		"status": map[string]any{"replicas": int64(1)},
+	}}
+	created, err := dynamicClient.Resource(client.DeploymentGVR).Namespace("shop").Create(context.Background(), deployment, metav1.CreateOptions{})
+	require.NoError(t, err)
+	return created
+}
+
+func updateImage(t *testing.T, dynamicClient *fake.FakeDynamicClient, deployment *unstructured.Unstructured, image string) {
+	updated := deployment.DeepCopy()
+	require.NoError(t, unstructured.SetNestedSlice(updated.Object, []any{
+		map[string]any{"name": "nginx", "image": image},
+	}, "spec", "template", "spec", "containers"))
+	_, err := dynamicClient.Resource(client.DeploymentGVR).Namespace("shop").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+}
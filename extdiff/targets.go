@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extdiff
+
+import (
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extdaemonset"
+	"github.com/steadybit/extension-kubernetes/v2/extdeployment"
+	"github.com/steadybit/extension-kubernetes/v2/extenvoygateway"
+	"github.com/steadybit/extension-kubernetes/v2/extingress"
+	"github.com/steadybit/extension-kubernetes/v2/extnode"
+	"github.com/steadybit/extension-kubernetes/v2/extstatefulset"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	DeploymentDiffActionId     = "com.steadybit.extension_kubernetes.resource_diff_deployment"
+	StatefulSetDiffActionId    = "com.steadybit.extension_kubernetes.resource_diff_statefulset"
+	DaemonSetDiffActionId      = "com.steadybit.extension_kubernetes.resource_diff_daemonset"
+	NginxIngressDiffActionId   = "com.steadybit.extension_kubernetes.resource_diff_nginx_ingress"
+	HAProxyIngressDiffActionId = "com.steadybit.extension_kubernetes.resource_diff_haproxy_ingress"
+	HttpRouteDiffActionId      = "com.steadybit.extension_kubernetes.resource_diff_envoy_gateway_http_route"
+	NodeDiffActionId           = "com.steadybit.extension_kubernetes.resource_diff_node"
+)
+
+func NewDeploymentDiffAction() action_kit_sdk.Action[ResourceDiffState] {
+	return newNamespacedDiffAction(DeploymentDiffActionId, extdeployment.DeploymentTargetType, "Deployment", "Deployment", "k8s.deployment", client.DeploymentGVR)
+}
+
+func NewStatefulSetDiffAction() action_kit_sdk.Action[ResourceDiffState] {
+	return newNamespacedDiffAction(StatefulSetDiffActionId, extstatefulset.StatefulSetTargetType, "StatefulSet", "StatefulSet", "k8s.statefulset", client.StatefulSetGVR)
+}
+
+func NewDaemonSetDiffAction() action_kit_sdk.Action[ResourceDiffState] {
+	return newNamespacedDiffAction(DaemonSetDiffActionId, extdaemonset.DaemonSetTargetType, "DaemonSet", "DaemonSet", "k8s.daemonset", client.DaemonSetGVR)
+}
+
+func NewNginxIngressDiffAction() action_kit_sdk.Action[ResourceDiffState] {
+	return newNamespacedDiffAction(NginxIngressDiffActionId, extingress.NginxIngressTargetType, "NGINX Ingress", "Ingress", "k8s.ingress", client.IngressGVR)
+}
+
+func NewHAProxyIngressDiffAction() action_kit_sdk.Action[ResourceDiffState] {
+	return newNamespacedDiffAction(HAProxyIngressDiffActionId, extingress.HAProxyIngressTargetType, "HAProxy Ingress", "Ingress", "k8s.ingress", client.IngressGVR)
+}
+
+func NewHttpRouteDiffAction() action_kit_sdk.Action[ResourceDiffState] {
+	return newNamespacedDiffAction(HttpRouteDiffActionId, extenvoygateway.EnvoyGatewayHttpRouteTargetType, "HTTPRoute", "HTTPRoute", "k8s.envoy-gateway.http-route", client.HTTPRouteGVR)
+}
+
+func NewNodeDiffAction() action_kit_sdk.Action[ResourceDiffState] {
+	return ResourceDiffAction{
+		actionId:        NodeDiffActionId,
+		targetType:      extnode.NodeTargetType,
+		targetTypeLabel: "Node",
+		selectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+			{
+				Label:       "node name",
+				Description: new("Find node by cluster and name"),
+				Query:       "k8s.cluster-name=\"\" AND k8s.node.name=\"\"",
+			},
+		}),
+		resource: func(request action_kit_api.PrepareActionRequestBody) Resource {
+			return Resource{
+				Kind: "Node",
+				GVR:  client.NodeGVR,
+				Name: request.Target.Attributes["k8s.node.name"][0],
+			}
+		},
+	}
+}
+
+// newNamespacedDiffAction creates a diff action for a namespaced target identified by the given name attribute.
+func newNamespacedDiffAction(actionId, targetType, label, kind, nameAttribute string, gvr schema.GroupVersionResource) ResourceDiffAction {
+	return ResourceDiffAction{
+		actionId:        actionId,
+		targetType:      targetType,
+		targetTypeLabel: label,
+		selectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+			{
+				Label:       strings.ToLower(label),
+				Description: new("Find " + label + " by cluster, namespace and name"),
+				Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND " + nameAttribute + "=\"\"",
+			},
+		}),
+		resource: func(request action_kit_api.PrepareActionRequestBody) Resource {
+			return Resource{
+				Kind:      kind,
+				GVR:       gvr,
+				Namespace: request.Target.Attributes["k8s.namespace"][0],
+				Name:      request.Target.Attributes[nameAttribute][0],
+			}
+		},
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 )
 
 // sentinelStatus is the internal HTTP status the attack aborts with: Envoy aborts with this code and
@@ -25,6 +26,7 @@ func NewAbortAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
 		k8s:              k8s,
 		description:      getAbortDescription(),
 		subtype:          "abort",
+		routeKind:        httpRouteKind,
 		buildFaultSpecFn: buildAbortFaultSpec,
 	}
 }
@@ -35,6 +37,7 @@ func getAbortDescription() action_kit_api.ActionDescription {
 		"Envoy Abort Traffic",
 		"Abort a percentage of the traffic on an Envoy Gateway HTTP route with a given HTTP status code. Optionally overwrite the response body returned to clients.",
 	)
+	desc = withPercentageParameter(desc)
 	desc.Parameters = append(desc.Parameters,
 		action_kit_api.ActionParameter{
 			Name:         "statusCode",
@@ -63,7 +66,7 @@ func getAbortDescription() action_kit_api.ActionDescription {
 			Advanced:     new(true),
 		},
 	)
-	return withSectionNameParameter(desc)
+	return withSectionNameParameter(extcommon.WithConditionParameters(desc, conditionPathPatternDescription, true))
 }
 
 func buildAbortFaultSpec(config map[string]any) (map[string]any, error) {
@@ -76,7 +79,10 @@ func buildAbortFaultSpec(config map[string]any) (map[string]any, error) {
 	if statusCode == sentinelStatus {
 		return nil, fmt.Errorf("statusCode %d is reserved for the internal sentinel; choose a different status code", sentinelStatus)
 	}
-	percentage := percentageFromConfig(config)
+	percentage, err := extcommon.PercentageFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
 	body := extutil.ToString(config["body"])
 	contentType := extutil.ToString(config["contentType"])
 	if contentType == "" {
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extenvoygateway
+
+import (
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+)
+
+func NewCircuitBreakerAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &backendTrafficPolicyAction{
+		k8s:              k8s,
+		description:      getCircuitBreakerDescription(),
+		subtype:          "circuit-breaker",
+		routeKind:        httpRouteKind,
+		buildFaultSpecFn: buildCircuitBreakerSpec,
+	}
+}
+
+func getCircuitBreakerDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		CircuitBreakerActionId,
+		"Envoy Circuit Breaker",
+		"Set aggressive circuit breaker thresholds on an Envoy Gateway HTTP route. Requests exceeding the thresholds are answered with HTTP 503.",
+	)
+	desc.Parameters = append(desc.Parameters,
+		action_kit_api.ActionParameter{
+			Name:         "maxConnections",
+			Label:        "Max Connections",
+			Description:  new("The maximum number of connections Envoy establishes to the backends."),
+			Type:         action_kit_api.ActionParameterTypeInteger,
+			DefaultValue: new("1"),
+			Required:     new(true),
+			MinValue:     new(0),
+		},
+		action_kit_api.ActionParameter{
+			Name:         "maxPendingRequests",
+			Label:        "Max Pending Requests",
+			Description:  new("The maximum number of requests waiting for a connection to the backends."),
+			Type:         action_kit_api.ActionParameterTypeInteger,
+			DefaultValue: new("1"),
+			Required:     new(true),
+			MinValue:     new(0),
+		},
+		action_kit_api.ActionParameter{
+			Name:         "maxParallelRequests",
+			Label:        "Max Parallel Requests",
+			Description:  new("The maximum number of parallel requests Envoy sends to the backends."),
+			Type:         action_kit_api.ActionParameterTypeInteger,
+			DefaultValue: new("1"),
+			Required:     new(true),
+			MinValue:     new(0),
+		},
+	)
+	return withSectionNameParameter(desc)
+}
+
+func buildCircuitBreakerSpec(config map[string]any) (map[string]any, error) {
+	circuitBreaker := map[string]any{}
+	for _, name := range []string{"maxConnections", "maxPendingRequests", "maxParallelRequests"} {
+		value := extutil.ToInt64(config[name])
+		if value < 0 {
+			return nil, fmt.Errorf("%s must not be negative", name)
+		}
+		circuitBreaker[name] = value
+	}
+	return map[string]any{"circuitBreaker": circuitBreaker}, nil
+}
@@ -13,11 +13,13 @@ import (
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-// ActionState is shared by all three Envoy Gateway HTTPRoute attacks.
+// ActionState is shared by all Envoy Gateway HTTPRoute and GRPCRoute attacks.
 type ActionState struct {
 	Namespace   string         `json:"namespace"`
 	RouteName   string         `json:"routeName"`
@@ -25,14 +27,20 @@ type ActionState struct {
 	PolicyName  string         `json:"policyName"`
 	ExecutionId string         `json:"executionId"`
 	FaultSpec   map[string]any `json:"faultSpec"`
+	// Matcher holds the optional request conditions. Attacks with conditions inject route rules named
+	// RuleNamePrefix followed by an index, RuleNames holds the names of the injected rules.
+	Matcher        extcommon.RequestMatcher `json:"matcher"`
+	RuleNamePrefix string                   `json:"ruleNamePrefix"`
+	RuleNames      []string                 `json:"ruleNames"`
 }
 
 // backendTrafficPolicyAction is the common attack implementation. Each attack supplies a description
-// and a buildFaultSpecFn that produces the faultInjection/responseOverride portion of the BTP spec.
+// and a buildFaultSpecFn that produces the attack's portion of the BTP spec (e.g. faultInjection).
 type backendTrafficPolicyAction struct {
 	k8s              *client.Client
 	description      action_kit_api.ActionDescription
 	subtype          string
+	routeKind        string
 	buildFaultSpecFn func(config map[string]any) (map[string]any, error)
 }
 
@@ -46,9 +54,10 @@ func (a *backendTrafficPolicyAction) Describe() action_kit_api.ActionDescription
 
 func (a *backendTrafficPolicyAction) Prepare(ctx context.Context, state *ActionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
 	namespace := request.Target.Attributes["k8s.namespace"]
-	routeName := request.Target.Attributes[attrHttpRoute]
+	routeAttribute := a.routeAttribute()
+	routeName := request.Target.Attributes[routeAttribute]
 	if len(namespace) == 0 || len(routeName) == 0 {
-		return nil, extension_kit.ToError("Missing required target attributes k8s.namespace and/or k8s.envoy-gateway.http-route.", nil)
+		return nil, extension_kit.ToError(fmt.Sprintf("Missing required target attributes k8s.namespace and/or %s.", routeAttribute), nil)
 	}
 
 	state.Namespace = namespace[0]
@@ -63,6 +72,27 @@ func (a *backendTrafficPolicyAction) Prepare(ctx context.Context, state *ActionS
 	}
 	state.FaultSpec = faultSpec
 
+	state.Matcher, err = extcommon.ParseRequestMatcher(request.Config)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to parse request conditions: %v", err), err)
+	}
+	if !state.Matcher.IsEmpty() {
+		if !a.k8s.Permissions().IsModifyHttpRoutesPermitted() {
+			return nil, extension_kit.ToError("Request conditions require the permission to update HTTPRoutes.", nil)
+		}
+		state.RuleNamePrefix = state.PolicyName + "-"
+		route, err := a.k8s.GetHTTPRoute(ctx, state.Namespace, state.RouteName)
+		if err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to fetch HTTPRoute %s/%s: %v", state.Namespace, state.RouteName, err), err)
+		}
+		// Inject the rules into the fetched copy only, so a route which cannot be combined with the conditions
+		// fails the preparation instead of the start.
+		if _, err := a.injectRules(route, state); err != nil {
+			return nil, extension_kit.ToError(err.Error(), err)
+		}
+		state.RuleNames = nil
+	}
+
 	if err := a.checkConflict(ctx, state); err != nil {
 		return nil, err
 	}
@@ -80,18 +110,30 @@ func (a *backendTrafficPolicyAction) Start(ctx context.Context, state *ActionSta
 		return nil, err
 	}
 
-	policy := buildBackendTrafficPolicy(state.Namespace, state.PolicyName, state.ExecutionId, state.RouteName, state.SectionName, state.FaultSpec)
+	if state.RuleNamePrefix != "" {
+		err := a.k8s.UpdateHTTPRoute(ctx, state.Namespace, state.RouteName, func(route *unstructured.Unstructured) (bool, error) {
+			return a.injectRules(route, state)
+		})
+		if err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to add the request conditions to HTTPRoute %s/%s: %v", state.Namespace, state.RouteName, err), err)
+		}
+	}
+
+	policy := buildBackendTrafficPolicy(state.Namespace, state.PolicyName, state.ExecutionId, a.routeKind, state.RouteName, policySectionNames(state), state.FaultSpec)
 	_, err := a.k8s.DynamicClient().Resource(client.BackendTrafficPolicyGVR).Namespace(state.Namespace).Create(ctx, policy, metav1.CreateOptions{})
 	if err != nil {
+		if removeErr := a.removeRules(ctx, state); removeErr != nil {
+			log.Warn().Err(removeErr).Msgf("Failed to remove the request conditions from HTTPRoute %s/%s", state.Namespace, state.RouteName)
+		}
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to create BackendTrafficPolicy %s/%s: %v", state.Namespace, state.PolicyName, err), err)
 	}
 
-	log.Info().Msgf("Created BackendTrafficPolicy %s/%s targeting HTTPRoute %s", state.Namespace, state.PolicyName, state.RouteName)
+	log.Info().Msgf("Created BackendTrafficPolicy %s/%s targeting %s %s", state.Namespace, state.PolicyName, a.routeKind, state.RouteName)
 	return &action_kit_api.StartResult{
 		Messages: new([]action_kit_api.Message{
 			{
 				Level:   extutil.Ptr(action_kit_api.Info),
-				Message: fmt.Sprintf("Applied BackendTrafficPolicy %s to HTTPRoute %s/%s", state.PolicyName, state.Namespace, state.RouteName),
+				Message: fmt.Sprintf("Applied BackendTrafficPolicy %s to %s %s/%s", state.PolicyName, a.routeKind, state.Namespace, state.RouteName),
 			},
 		}),
 	}, nil
@@ -102,45 +144,84 @@ func (a *backendTrafficPolicyAction) Stop(ctx context.Context, state *ActionStat
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to delete BackendTrafficPolicy %s/%s: %v", state.Namespace, state.PolicyName, err), err)
 	}
+	if err := a.removeRules(ctx, state); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to remove the request conditions from HTTPRoute %s/%s: %v", state.Namespace, state.RouteName, err), err)
+	}
 
 	log.Info().Msgf("Removed BackendTrafficPolicy %s/%s", state.Namespace, state.PolicyName)
 	return nil, nil
 }
 
+// injectRules fails if another attack already injected its rules into the route or modifies its rules. Otherwise, it
+// injects the rules restricted by the request conditions of this attack.
+func (a *backendTrafficPolicyAction) injectRules(route *unstructured.Unstructured, state *ActionState) (bool, error) {
+	if executionId := route.GetAnnotations()[executionAnnotationKey]; executionId != "" {
+		return false, fmt.Errorf("another attack is already running on HTTPRoute %s/%s (execution %s). Wait for it to finish or target a different route",
+			state.Namespace, state.RouteName, executionId)
+	}
+	if conflict := findInjectedRule(route, state.RuleNamePrefix); conflict != "" {
+		return false, fmt.Errorf("another attack is already running on HTTPRoute %s/%s (rule %s). Wait for it to finish or target a different route",
+			state.Namespace, state.RouteName, conflict)
+	}
+	names, changed, err := injectMatcherRules(route, state.RuleNamePrefix, state.SectionName, state.Matcher)
+	if err != nil {
+		return false, err
+	}
+	state.RuleNames = names
+	return changed, nil
+}
+
+// removeRules removes the rules injected for the request conditions, if any.
+func (a *backendTrafficPolicyAction) removeRules(ctx context.Context, state *ActionState) error {
+	if state.RuleNamePrefix == "" {
+		return nil
+	}
+	err := a.k8s.UpdateHTTPRoute(ctx, state.Namespace, state.RouteName, func(route *unstructured.Unstructured) (bool, error) {
+		return removeMatcherRules(route, state.RuleNamePrefix), nil
+	})
+	if k8sErrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// policySectionNames returns the route rules the policy targets, none meaning the whole route.
+func policySectionNames(state *ActionState) []string {
+	if state.RuleNamePrefix != "" {
+		return state.RuleNames
+	}
+	if state.SectionName != "" {
+		return []string{state.SectionName}
+	}
+	return nil
+}
+
+// routeAttribute returns the target attribute holding the name of the attacked route.
+func (a *backendTrafficPolicyAction) routeAttribute() string {
+	if a.routeKind == grpcRouteKind {
+		return attrGrpcRoute
+	}
+	return attrHttpRoute
+}
+
 func (a *backendTrafficPolicyAction) checkConflict(ctx context.Context, state *ActionState) error {
 	list, err := a.k8s.DynamicClient().Resource(client.BackendTrafficPolicyGVR).Namespace(state.Namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return extension_kit.ToError(fmt.Sprintf("Failed to list existing BackendTrafficPolicies in namespace %s: %v", state.Namespace, err), err)
 	}
-	if conflict := findConflictingPolicy(list.Items, state.RouteName, state.SectionName, state.PolicyName); conflict != "" {
+	if conflict := findConflictingPolicy(list.Items, a.routeKind, state.RouteName, state.SectionName, state.PolicyName); conflict != "" {
 		return extension_kit.ToError(fmt.Sprintf(
-			"An existing BackendTrafficPolicy %q already targets HTTPRoute %s/%s. Envoy Gateway resolves conflicts oldest-wins, so this attack would have no effect. Remove the existing policy or target a different route.",
-			conflict, state.Namespace, state.RouteName), nil)
+			"An existing BackendTrafficPolicy %q already targets %s %s/%s. Envoy Gateway resolves conflicts oldest-wins, so this attack would have no effect. Remove the existing policy or target a different route.",
+			conflict, a.routeKind, state.Namespace, state.RouteName), nil)
 	}
 	return nil
 }
 
-// percentageFromConfig extracts the traffic percentage as a float (Envoy Gateway's percentage field
-// is a float32 with 0.0001% accuracy). Defaults to 100 when unset.
-func percentageFromConfig(config map[string]any) float64 {
-	switch v := config["percentage"].(type) {
-	case float64:
-		return v
-	case float32:
-		return float64(v)
-	case int:
-		return float64(v)
-	case int64:
-		return float64(v)
-	case nil:
-		return 100
-	default:
-		return float64(extutil.ToInt64(v))
-	}
-}
+// conditionPathPatternDescription describes the path condition, which must stay within the path prefix of the rules.
+const conditionPathPatternDescription = "Optional: only affect requests whose path matches this regular expression (RE2, matching the whole path). For rules matching on a path prefix, the pattern must start with that prefix."
 
-// getCommonActionDescription returns the base action description with duration, percentage and the
-// advanced sectionName parameter, plus the HTTPRoute target selection.
+// getCommonActionDescription returns the base action description with the duration parameter and the
+// HTTPRoute target selection. Call withSectionNameParameter after adding the attack's own parameters.
 func getCommonActionDescription(id, label, description string) action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:          id,
@@ -170,14 +251,6 @@ func getCommonActionDescription(id, label, description string) action_kit_api.Ac
 				DefaultValue: new("30s"),
 				Required:     new(true),
 			},
-			{
-				Name:         "percentage",
-				Label:        "Traffic Percentage",
-				Description:  new("The percentage of requests the fault is applied to."),
-				Type:         action_kit_api.ActionParameterTypePercentage,
-				DefaultValue: new("50"),
-				Required:     new(true),
-			},
 		},
 		Prepare: action_kit_api.MutatingEndpointReference{},
 		Start:   action_kit_api.MutatingEndpointReference{},
@@ -185,6 +258,38 @@ func getCommonActionDescription(id, label, description string) action_kit_api.Ac
 	}
 }
 
+// getCommonGrpcActionDescription returns the base action description with the duration parameter and the
+// GRPCRoute target selection.
+func getCommonGrpcActionDescription(id, label, description string) action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(id, label, description)
+	desc.TargetSelection = new(action_kit_api.TargetSelection{
+		TargetType: EnvoyGatewayGrpcRouteTargetType,
+		SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+			{
+				Label:       "gRPC route",
+				Description: new("Find gRPC route by cluster, namespace and route name"),
+				Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.envoy-gateway.grpc-route=\"\"",
+			},
+		}),
+	})
+	desc.Parameters[0].Description = new("The duration of the attack. The gRPC route will be affected for the specified duration.")
+	return desc
+}
+
+// withPercentageParameter appends the percentage parameter for attacks applying a fault to a share of
+// the requests.
+func withPercentageParameter(desc action_kit_api.ActionDescription) action_kit_api.ActionDescription {
+	desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
+		Name:         "percentage",
+		Label:        "Traffic Percentage",
+		Description:  new("The percentage of requests the fault is applied to."),
+		Type:         action_kit_api.ActionParameterTypePercentage,
+		DefaultValue: new("50"),
+		Required:     new(true),
+	})
+	return desc
+}
+
 // withSectionNameParameter appends the advanced sectionName parameter. Call it after the attack's own
 // parameters so the advanced parameter renders last.
 func withSectionNameParameter(desc action_kit_api.ActionDescription) action_kit_api.ActionDescription {
@@ -10,6 +10,7 @@ import (
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 )
 
 func NewDelayAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
@@ -17,6 +18,7 @@ func NewDelayAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
 		k8s:              k8s,
 		description:      getDelayDescription(),
 		subtype:          "delay",
+		routeKind:        httpRouteKind,
 		buildFaultSpecFn: buildDelayFaultSpec,
 	}
 }
@@ -27,6 +29,7 @@ func getDelayDescription() action_kit_api.ActionDescription {
 		"Envoy Delay Traffic",
 		"Inject a fixed delay into a percentage of the traffic on an Envoy Gateway HTTP route using a BackendTrafficPolicy.",
 	)
+	desc = withPercentageParameter(desc)
 	desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
 		Name:         "delay",
 		Label:        "Delay",
@@ -35,7 +38,7 @@ func getDelayDescription() action_kit_api.ActionDescription {
 		DefaultValue: new("500ms"),
 		Required:     new(true),
 	})
-	return withSectionNameParameter(desc)
+	return withSectionNameParameter(extcommon.WithConditionParameters(desc, conditionPathPatternDescription, true))
 }
 
 func buildDelayFaultSpec(config map[string]any) (map[string]any, error) {
@@ -43,11 +46,15 @@ func buildDelayFaultSpec(config map[string]any) (map[string]any, error) {
 	if delayMs <= 0 {
 		return nil, fmt.Errorf("delay must be greater than zero")
 	}
+	percentage, err := extcommon.PercentageFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
 	return map[string]any{
 		"faultInjection": map[string]any{
 			"delay": map[string]any{
 				"fixedDelay": fmt.Sprintf("%dms", delayMs),
-				"percentage": percentageFromConfig(config),
+				"percentage": percentage,
 			},
 		},
 	}, nil
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extenvoygateway
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+)
+
+func NewDisableRetriesAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &backendTrafficPolicyAction{
+		k8s:              k8s,
+		description:      getDisableRetriesDescription(),
+		subtype:          "disable-retries",
+		routeKind:        httpRouteKind,
+		buildFaultSpecFn: buildDisableRetriesSpec,
+	}
+}
+
+func getDisableRetriesDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		DisableRetriesActionId,
+		"Envoy Disable Retries",
+		"Disable the retries of failed requests on an Envoy Gateway HTTP route, exposing clients to every backend failure.",
+	)
+	return withSectionNameParameter(desc)
+}
+
+func buildDisableRetriesSpec(_ map[string]any) (map[string]any, error) {
+	return map[string]any{
+		"retry": map[string]any{
+			"numRetries": int64(0),
+		},
+	}, nil
+}
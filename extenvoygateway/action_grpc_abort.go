@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extenvoygateway
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+)
+
+// grpcStatusCodes are the gRPC status codes an aborted call can fail with, indexed by code. OK (0) is
+// omitted as it would not abort the call.
+var grpcStatusCodes = []string{
+	1:  "CANCELLED",
+	2:  "UNKNOWN",
+	3:  "INVALID_ARGUMENT",
+	4:  "DEADLINE_EXCEEDED",
+	5:  "NOT_FOUND",
+	6:  "ALREADY_EXISTS",
+	7:  "PERMISSION_DENIED",
+	8:  "RESOURCE_EXHAUSTED",
+	9:  "FAILED_PRECONDITION",
+	10: "ABORTED",
+	11: "OUT_OF_RANGE",
+	12: "UNIMPLEMENTED",
+	13: "INTERNAL",
+	14: "UNAVAILABLE",
+	15: "DATA_LOSS",
+	16: "UNAUTHENTICATED",
+}
+
+func NewGrpcAbortAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &backendTrafficPolicyAction{
+		k8s:              k8s,
+		description:      getGrpcAbortDescription(),
+		subtype:          "grpc-abort",
+		routeKind:        grpcRouteKind,
+		buildFaultSpecFn: buildGrpcAbortFaultSpec,
+	}
+}
+
+func getGrpcAbortDescription() action_kit_api.ActionDescription {
+	desc := getCommonGrpcActionDescription(
+		GrpcAbortActionId,
+		"Envoy Abort gRPC Traffic",
+		"Abort a percentage of the calls on an Envoy Gateway gRPC route with a given gRPC status code.",
+	)
+	desc = withPercentageParameter(desc)
+	var statusOptions []action_kit_api.ParameterOption
+	for code, name := range grpcStatusCodes {
+		if name != "" {
+			statusOptions = append(statusOptions, action_kit_api.ExplicitParameterOption{
+				Label: fmt.Sprintf("%d %s", code, name),
+				Value: strconv.Itoa(code),
+			})
+		}
+	}
+	desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
+		Name:         "grpcStatus",
+		Label:        "gRPC Status Code",
+		Description:  new("The gRPC status code returned for aborted calls."),
+		Type:         action_kit_api.ActionParameterTypeInteger,
+		DefaultValue: new("14"),
+		Required:     new(true),
+		Options:      new(statusOptions),
+	})
+	return withSectionNameParameter(desc)
+}
+
+func buildGrpcAbortFaultSpec(config map[string]any) (map[string]any, error) {
+	grpcStatus := extutil.ToInt64(config["grpcStatus"])
+	if grpcStatus < 1 || grpcStatus >= int64(len(grpcStatusCodes)) {
+		return nil, fmt.Errorf("grpcStatus must be between 1 and %d", len(grpcStatusCodes)-1)
+	}
+	percentage, err := extcommon.PercentageFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	// Unlike HTTP aborts, gRPC aborts carry no body, so the status is set directly without a response
+	// override.
+	return map[string]any{
+		"faultInjection": map[string]any{
+			"abort": map[string]any{
+				"grpcStatus": grpcStatus,
+				"percentage": percentage,
+			},
+		},
+	}, nil
+}
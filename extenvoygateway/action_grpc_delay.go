@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extenvoygateway
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+)
+
+func NewGrpcDelayAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &backendTrafficPolicyAction{
+		k8s:              k8s,
+		description:      getGrpcDelayDescription(),
+		subtype:          "grpc-delay",
+		routeKind:        grpcRouteKind,
+		buildFaultSpecFn: buildDelayFaultSpec,
+	}
+}
+
+func getGrpcDelayDescription() action_kit_api.ActionDescription {
+	desc := getCommonGrpcActionDescription(
+		GrpcDelayActionId,
+		"Envoy Delay gRPC Traffic",
+		"Inject a fixed delay into a percentage of the calls on an Envoy Gateway gRPC route using a BackendTrafficPolicy.",
+	)
+	desc = withPercentageParameter(desc)
+	desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
+		Name:         "delay",
+		Label:        "Delay",
+		Description:  new("The fixed delay to inject into matching calls."),
+		Type:         action_kit_api.ActionParameterTypeDuration,
+		DefaultValue: new("500ms"),
+		Required:     new(true),
+	})
+	return withSectionNameParameter(desc)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extenvoygateway
+
+import (
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+)
+
+func NewRateLimitAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &backendTrafficPolicyAction{
+		k8s:              k8s,
+		description:      getRateLimitDescription(),
+		subtype:          "rate-limit",
+		routeKind:        httpRouteKind,
+		buildFaultSpecFn: buildRateLimitSpec,
+	}
+}
+
+func getRateLimitDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		RateLimitActionId,
+		"Envoy Rate Limit Traffic",
+		"Apply a local rate limit to an Envoy Gateway HTTP route. Requests exceeding the limit are answered with HTTP 429.",
+	)
+	desc.Parameters = append(desc.Parameters,
+		action_kit_api.ActionParameter{
+			Name:         "requests",
+			Label:        "Requests",
+			Description:  new("The number of requests allowed per time unit."),
+			Type:         action_kit_api.ActionParameterTypeInteger,
+			DefaultValue: new("1"),
+			Required:     new(true),
+			MinValue:     new(1),
+		},
+		action_kit_api.ActionParameter{
+			Name:         "unit",
+			Label:        "Time Unit",
+			Description:  new("The time unit of the rate limit."),
+			Type:         action_kit_api.ActionParameterTypeString,
+			DefaultValue: new("Second"),
+			Required:     new(true),
+			Options: new([]action_kit_api.ParameterOption{
+				action_kit_api.ExplicitParameterOption{Label: "Second", Value: "Second"},
+				action_kit_api.ExplicitParameterOption{Label: "Minute", Value: "Minute"},
+				action_kit_api.ExplicitParameterOption{Label: "Hour", Value: "Hour"},
+			}),
+		},
+	)
+	return withSectionNameParameter(desc)
+}
+
+func buildRateLimitSpec(config map[string]any) (map[string]any, error) {
+	requests := extutil.ToInt64(config["requests"])
+	if requests < 1 {
+		return nil, fmt.Errorf("requests must be at least 1")
+	}
+	unit := extutil.ToString(config["unit"])
+	switch unit {
+	case "":
+		unit = "Second"
+	case "Second", "Minute", "Hour":
+	default:
+		return nil, fmt.Errorf("unknown time unit %q", unit)
+	}
+
+	// A local rate limit rule without client selectors applies to all requests of the route, each Envoy
+	// replica enforcing the limit on its own.
+	return map[string]any{
+		"rateLimit": map[string]any{
+			"type": "Local",
+			"local": map[string]any{
+				"rules": []any{
+					map[string]any{
+						"limit": map[string]any{
+							"requests": requests,
+							"unit":     unit,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extenvoygateway
+
+import (
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+)
+
+func NewTimeoutAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &backendTrafficPolicyAction{
+		k8s:              k8s,
+		description:      getTimeoutDescription(),
+		subtype:          "timeout",
+		routeKind:        httpRouteKind,
+		buildFaultSpecFn: buildTimeoutSpec,
+	}
+}
+
+func getTimeoutDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		TimeoutActionId,
+		"Envoy Shrink Timeouts",
+		"Shrink the upstream timeouts of an Envoy Gateway HTTP route. Requests taking longer than the request timeout are answered with HTTP 504.",
+	)
+	desc.Parameters = append(desc.Parameters,
+		action_kit_api.ActionParameter{
+			Name:         "requestTimeout",
+			Label:        "Request Timeout",
+			Description:  new("The time until a request to the backend must be completed."),
+			Type:         action_kit_api.ActionParameterTypeDuration,
+			DefaultValue: new("100ms"),
+			Required:     new(true),
+		},
+		action_kit_api.ActionParameter{
+			Name:        "connectTimeout",
+			Label:       "Connect Timeout",
+			Description: new("Optional: the time until a connection to the backend must be established."),
+			Type:        action_kit_api.ActionParameterTypeDuration,
+			Required:    new(false),
+			Advanced:    new(true),
+		},
+	)
+	return withSectionNameParameter(desc)
+}
+
+func buildTimeoutSpec(config map[string]any) (map[string]any, error) {
+	requestTimeoutMs := extutil.ToInt64(config["requestTimeout"])
+	if requestTimeoutMs <= 0 {
+		return nil, fmt.Errorf("requestTimeout must be greater than zero")
+	}
+	timeout := map[string]any{
+		"http": map[string]any{
+			"requestTimeout": fmt.Sprintf("%dms", requestTimeoutMs),
+		},
+	}
+	if connectTimeoutMs := extutil.ToInt64(config["connectTimeout"]); connectTimeoutMs > 0 {
+		timeout["tcp"] = map[string]any{
+			"connectTimeout": fmt.Sprintf("%dms", connectTimeoutMs),
+		}
+	}
+	return map[string]any{"timeout": timeout}, nil
+}
@@ -4,51 +4,90 @@
 package extenvoygateway
 
 import (
+	"cmp"
+	"fmt"
 	"maps"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
 	// EnvoyGatewayHttpRouteTargetType is the discovery target type for HTTPRoutes managed by Envoy Gateway.
 	EnvoyGatewayHttpRouteTargetType = "com.steadybit.extension_kubernetes.envoy-gateway-http-route"
 
+	// EnvoyGatewayGrpcRouteTargetType is the discovery target type for GRPCRoutes managed by Envoy Gateway.
+	EnvoyGatewayGrpcRouteTargetType = "com.steadybit.extension_kubernetes.envoy-gateway-grpc-route"
+
 	// attrHttpRoute is the discovery attribute holding the HTTPRoute name.
 	attrHttpRoute = "k8s.envoy-gateway.http-route"
+	// attrGrpcRoute is the discovery attribute holding the GRPCRoute name.
+	attrGrpcRoute = "k8s.envoy-gateway.grpc-route"
 
 	DelayActionId = "com.steadybit.extension_kubernetes.envoy-gateway-http-route-delay"
 	AbortActionId = "com.steadybit.extension_kubernetes.envoy-gateway-http-route-abort"
 
+	RateLimitActionId      = "com.steadybit.extension_kubernetes.envoy-gateway-http-route-rate-limit"
+	TimeoutActionId        = "com.steadybit.extension_kubernetes.envoy-gateway-http-route-timeout"
+	DisableRetriesActionId = "com.steadybit.extension_kubernetes.envoy-gateway-http-route-disable-retries"
+	CircuitBreakerActionId = "com.steadybit.extension_kubernetes.envoy-gateway-http-route-circuit-breaker"
+
+	GrpcDelayActionId = "com.steadybit.extension_kubernetes.envoy-gateway-grpc-route-delay"
+	GrpcAbortActionId = "com.steadybit.extension_kubernetes.envoy-gateway-grpc-route-abort"
+
 	// envoyGatewayControllerName identifies GatewayClasses managed by Envoy Gateway.
 	envoyGatewayControllerName = "gateway.envoyproxy.io/gatewayclass-controller"
 
 	gatewayAPIGroup   = "gateway.networking.k8s.io"
 	httpRouteKind     = "HTTPRoute"
+	grpcRouteKind     = "GRPCRoute"
 	btpAPIVersion     = "gateway.envoyproxy.io/v1alpha1"
 	btpKind           = "BackendTrafficPolicy"
 	managedByLabelKey = "steadybit.com/managed-by"
 	managedByValue    = "extension-kubernetes"
 	executionLabelKey = "steadybit.com/execution-id"
 
+	// injectedRuleNamePrefix marks the HTTPRoute rules injected by attacks with request conditions.
+	injectedRuleNamePrefix = "steadybit-"
+
+	// executionAnnotationKey marks an HTTPRoute whose rules are modified by a Gateway API attack.
+	executionAnnotationKey = "steadybit.com/execution-id"
+
+	// maxHttpRouteRules is the maximum number of rules of an HTTPRoute allowed by the Gateway API.
+	maxHttpRouteRules = 16
+
 	// EnvoyGatewayIcon is the Envoy Gateway logo (monochrome, currentColor).
 	EnvoyGatewayIcon = "data:image/svg+xml,%3Csvg%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20d%3D%22M4.20117%2012.9791L2.70606%2013.6373L2.78125%2016.8659L5.8252%2018.7926L7.1709%2018.1989L8.38672%2018.9694L6.14746%2019.9528C6.13256%2019.9629%206.11752%2019.9682%206.09766%2019.9733C5.9289%2020.0289%205.7205%2019.9986%205.55176%2019.8922L1.90234%2017.5807C1.7137%2017.4591%201.59389%2017.2617%201.58887%2017.0641L1.5%2013.1862C1.49518%2012.9887%201.60447%2012.816%201.78809%2012.735L4.1709%2011.6862L4.20117%2012.9791Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3Cpath%20d%3D%22M8.01465%2010.2164L6.1377%2011.0426L6.23731%2015.4166L10.3535%2018.0221L12.1611%2017.2262L13.5811%2018.1237L10.7305%2019.3756C10.7107%2019.3857%2010.6906%2019.395%2010.6709%2019.4C10.4673%2019.4608%2010.2289%2019.431%2010.0303%2019.3043L5.2041%2016.2477C4.98572%2016.1109%204.84685%2015.8831%204.8418%2015.65L4.72754%2010.5202C4.72271%2010.2872%204.85202%2010.09%205.06543%209.99378L7.97949%208.71058L8.01465%2010.2164Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3Cpath%20d%3D%22M15.2988%2011.0475C15.5173%2011.1894%2015.6562%2011.4179%2015.6611%2011.651L15.7656%2016.3287L15.6914%2016.359L14.3506%2015.5133L14.2666%2011.8844L10.7559%209.66371L10.7256%208.30531L10.8652%208.24476L15.2988%2011.0475Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M15.0107%204.03773C15.2441%203.9617%2015.5177%204.00226%2015.7461%204.14418L21.9326%208.06214C22.1859%208.21927%2022.3456%208.48334%2022.3506%208.74183L22.499%2015.3102C22.504%2015.5737%2022.3555%2015.8072%2022.1123%2015.9137L16.0732%2018.569C16.0486%2018.5791%2016.0286%2018.5893%2016.0039%2018.5944C15.7755%2018.6703%2015.5028%2018.6298%2015.2744%2018.4879L9.08691%2014.5748C8.83394%2014.4177%208.67495%2014.1597%208.66992%2013.8912L8.51563%207.32191C8.51082%207.05351%208.66022%206.82578%208.90332%206.71937L14.9414%204.06312C14.9661%204.05302%2014.986%204.04282%2015.0107%204.03773ZM10.1348%207.91566L10.2686%2013.6276L15.6465%2017.0289L20.8906%2014.7223L20.7559%209.01039L15.3789%205.60902L10.1348%207.91566Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3Cpath%20d%3D%22M8.78418%2015.0875L9.95117%2015.8268L9.98047%2017.0895L8.76465%2016.319L8.73438%2015.0514C8.74927%2015.0615%208.76928%2015.0774%208.78418%2015.0875Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3Cpath%20d%3D%22M8.06445%2012.568L8.09375%2013.8551L6.76367%2013.0143L6.7334%2011.7272L8.06445%2012.568Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3C%2Fsvg%3E"
 )
 
 // buildBackendTrafficPolicy builds an unstructured Envoy Gateway BackendTrafficPolicy object.
-// faultSpec is merged into spec alongside the targetRefs. sectionName is optional (empty = whole route).
-func buildBackendTrafficPolicy(namespace, name, executionId, routeName, sectionName string, faultSpec map[string]any) *unstructured.Unstructured {
-	targetRef := map[string]any{
-		"group": gatewayAPIGroup,
-		"kind":  httpRouteKind,
-		"name":  routeName,
+// faultSpec is merged into spec alongside the targetRefs. The policy targets the given rules
+// (sectionNames) of the route of routeKind, or the whole route if none are given.
+func buildBackendTrafficPolicy(namespace, name, executionId, routeKind, routeName string, sectionNames []string, faultSpec map[string]any) *unstructured.Unstructured {
+	if len(sectionNames) == 0 {
+		sectionNames = []string{""}
 	}
-	if sectionName != "" {
-		targetRef["sectionName"] = sectionName
+	var targetRefs []any
+	for _, sectionName := range sectionNames {
+		targetRef := map[string]any{
+			"group": gatewayAPIGroup,
+			"kind":  routeKind,
+			"name":  routeName,
+		}
+		if sectionName != "" {
+			targetRef["sectionName"] = sectionName
+		}
+		targetRefs = append(targetRefs, targetRef)
 	}
 
 	spec := map[string]any{
-		"targetRefs": []any{targetRef},
+		"targetRefs": targetRefs,
 	}
 	maps.Copy(spec, faultSpec)
 
@@ -70,10 +109,10 @@ func buildBackendTrafficPolicy(namespace, name, executionId, routeName, sectionN
 }
 
 // findConflictingPolicy returns the name of an existing BackendTrafficPolicy that already targets the
-// given HTTPRoute (and section), if any. Envoy Gateway resolves conflicts oldest-wins, so a pre-existing
+// given route (and section), if any. Envoy Gateway resolves conflicts oldest-wins, so a pre-existing
 // policy would silently shadow our attack — callers should fail when this returns a non-empty name.
 // ownName is excluded from the check so re-running Start against our own just-created policy is a no-op.
-func findConflictingPolicy(policies []unstructured.Unstructured, routeName, sectionName, ownName string) string {
+func findConflictingPolicy(policies []unstructured.Unstructured, routeKind, routeName, sectionName, ownName string) string {
 	for i := range policies {
 		policy := &policies[i]
 		if policy.GetName() == ownName {
@@ -88,7 +127,7 @@ func findConflictingPolicy(policies []unstructured.Unstructured, routeName, sect
 			if !ok {
 				continue
 			}
-			if targetRefMatchesRoute(refMap, routeName, sectionName) {
+			if targetRefMatchesRoute(refMap, routeKind, routeName, sectionName) {
 				return policy.GetName()
 			}
 		}
@@ -96,10 +135,10 @@ func findConflictingPolicy(policies []unstructured.Unstructured, routeName, sect
 	return ""
 }
 
-func targetRefMatchesRoute(ref map[string]any, routeName, sectionName string) bool {
+func targetRefMatchesRoute(ref map[string]any, routeKind, routeName, sectionName string) bool {
 	kind, _ := ref["kind"].(string)
 	name, _ := ref["name"].(string)
-	if kind != httpRouteKind || name != routeName {
+	if kind != routeKind || name != routeName {
 		return false
 	}
 	refSection, _ := ref["sectionName"].(string)
@@ -119,3 +158,211 @@ func objectMetaFromUnstructured(obj *unstructured.Unstructured) metav1.ObjectMet
 		Labels:      obj.GetLabels(),
 	}
 }
+
+// restrictMatches combines the matcher with the match entries of a route rule. The Gateway API ORs the entries of a rule and
+// ANDs the conditions within an entry, so the matcher's conditions are added to every entry. Entries which cannot
+// match any of the requested requests are dropped, conditions which cannot be combined with an entry fail.
+func restrictMatches(m extcommon.RequestMatcher, matches []any) ([]any, error) {
+	if len(matches) == 0 {
+		matches = []any{map[string]any{}}
+	}
+	var result []any
+	for _, match := range matches {
+		entry, ok := match.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected match entry %v", match)
+		}
+		matching, err := restrictEntry(m, entry)
+		if err != nil {
+			return nil, err
+		}
+		if matching {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func restrictEntry(m extcommon.RequestMatcher, entry map[string]any) (bool, error) {
+	if m.PathPattern != "" {
+		path, _ := entry["path"].(map[string]any)
+		pathType := cmp.Or(extutil.ToString(path["type"]), "PathPrefix")
+		pathValue := cmp.Or(extutil.ToString(path["value"]), "/")
+		switch pathType {
+		case "Exact":
+			pattern, err := regexp.Compile("^(?:" + m.PathPattern + ")$")
+			if err != nil {
+				return false, fmt.Errorf("invalid path pattern: %w", err)
+			}
+			if !pattern.MatchString(pathValue) {
+				return false, nil
+			}
+		case "PathPrefix":
+			inside, outside, err := patternWithinPathPrefix(m.PathPattern, pathValue)
+			if err != nil {
+				return false, err
+			}
+			if outside {
+				return false, nil
+			}
+			if !inside {
+				return false, fmt.Errorf("the path pattern %q cannot be combined with the path prefix %q of the rule. Start the pattern with the path prefix", m.PathPattern, pathValue)
+			}
+			entry["path"] = map[string]any{"type": "RegularExpression", "value": m.PathPattern}
+		default:
+			return false, fmt.Errorf("the rule already matches on a %s path and cannot be combined with a path condition", pathType)
+		}
+	}
+
+	if m.HttpMethod != "" {
+		if method := extutil.ToString(entry["method"]); method != "" && method != m.HttpMethod {
+			return false, nil
+		}
+		entry["method"] = m.HttpMethod
+	}
+
+	if len(m.HttpHeader) > 0 {
+		headers, _ := entry["headers"].([]any)
+		for _, name := range slices.Sorted(maps.Keys(m.HttpHeader)) {
+			index := slices.IndexFunc(headers, func(header any) bool {
+				headerMap, _ := header.(map[string]any)
+				return strings.EqualFold(extutil.ToString(headerMap["name"]), name)
+			})
+			if index < 0 {
+				headers = append(headers, map[string]any{"type": "Exact", "name": name, "value": m.HttpHeader[name]})
+				continue
+			}
+			header, _ := headers[index].(map[string]any)
+			if headerType := cmp.Or(extutil.ToString(header["type"]), "Exact"); headerType != "Exact" {
+				return false, fmt.Errorf("the rule already matches on header %q using a %s and cannot be combined with a header condition on it", name, headerType)
+			}
+			if extutil.ToString(header["value"]) != m.HttpHeader[name] {
+				return false, nil
+			}
+		}
+		entry["headers"] = headers
+	}
+	return true, nil
+}
+
+// patternWithinPathPrefix reports whether all paths matched by the (fully matching) pattern are within the path
+// prefix (inside) or none of them is (outside), based on the literal prefix of the pattern. If neither can be decided,
+// the pattern and the prefix cannot be combined.
+func patternWithinPathPrefix(pattern, pathPrefix string) (inside bool, outside bool, err error) {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, false, fmt.Errorf("invalid path pattern: %w", err)
+	}
+	base := strings.TrimSuffix(pathPrefix, "/")
+	if base == "" {
+		return true, false, nil
+	}
+	literal, complete := compiled.LiteralPrefix()
+	switch {
+	case strings.HasPrefix(literal, base+"/") || (complete && literal == base):
+		return true, false, nil
+	case strings.HasPrefix(literal, base) && len(literal) > len(base):
+		// e.g. the pattern /apis/.* and the prefix /api
+		return false, true, nil
+	case !strings.HasPrefix(literal, base) && !strings.HasPrefix(base, literal):
+		return false, true, nil
+	}
+	return false, false, nil
+}
+
+// injectMatcherRules prepends a copy of the HTTPRoute rules (or of the rule named sectionName) restricted by the
+// matcher. The Gateway API grants precedence to more specific matches and, between equally specific matches of a
+// route, to the first rule, so the copies receive the requests matched by the matcher and forward them like the
+// original rules. The copies are named namePrefix followed by the index of their rule. Returns the names of the
+// injected rules and whether the route was changed.
+func injectMatcherRules(route *unstructured.Unstructured, namePrefix, sectionName string, matcher extcommon.RequestMatcher) ([]string, bool, error) {
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	if names := ruleNamesWithPrefix(rules, namePrefix); len(names) > 0 {
+		return names, false, nil
+	}
+
+	var injected []any
+	var names []string
+	sectionFound := false
+	for i, rule := range rules {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			return nil, false, fmt.Errorf("unexpected rule %v", rule)
+		}
+		if sectionName != "" {
+			if ruleMap["name"] != sectionName {
+				continue
+			}
+			sectionFound = true
+		}
+		matches, _ := runtime.DeepCopyJSONValue(ruleMap["matches"]).([]any)
+		matches, err := restrictMatches(matcher, matches)
+		if err != nil {
+			return nil, false, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		injectedRule := runtime.DeepCopyJSONValue(ruleMap).(map[string]any)
+		injectedRule["matches"] = matches
+		injectedRule["name"] = fmt.Sprintf("%s%d", namePrefix, i)
+		injected = append(injected, injectedRule)
+		names = append(names, injectedRule["name"].(string))
+	}
+	if sectionName != "" && !sectionFound {
+		return nil, false, fmt.Errorf("HTTPRoute %s/%s has no rule named %q", route.GetNamespace(), route.GetName(), sectionName)
+	}
+	if len(injected) == 0 {
+		return nil, false, fmt.Errorf("no rule of HTTPRoute %s/%s matches the request conditions", route.GetNamespace(), route.GetName())
+	}
+	if len(rules)+len(injected) > maxHttpRouteRules {
+		return nil, false, fmt.Errorf("HTTPRoute %s/%s would exceed the maximum of %d rules. Restrict the attack to a single rule using the route rule name",
+			route.GetNamespace(), route.GetName(), maxHttpRouteRules)
+	}
+
+	if err := unstructured.SetNestedSlice(route.Object, append(injected, rules...), "spec", "rules"); err != nil {
+		return nil, false, err
+	}
+	return names, true, nil
+}
+
+// removeMatcherRules removes the rules injected by injectMatcherRules, restoring the original rules.
+func removeMatcherRules(route *unstructured.Unstructured, namePrefix string) bool {
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	remaining := slices.DeleteFunc(slices.Clone(rules), func(rule any) bool {
+		return strings.HasPrefix(ruleName(rule), namePrefix)
+	})
+	if len(remaining) == len(rules) {
+		return false
+	}
+	_ = unstructured.SetNestedSlice(route.Object, remaining, "spec", "rules")
+	return true
+}
+
+// findInjectedRule returns the name of a rule injected by another attack, if any. ownPrefix is excluded from the
+// check, so re-running Start for the same execution is a no-op.
+func findInjectedRule(route *unstructured.Unstructured, ownPrefix string) string {
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for _, rule := range rules {
+		if name := ruleName(rule); strings.HasPrefix(name, injectedRuleNamePrefix) && !strings.HasPrefix(name, ownPrefix) {
+			return name
+		}
+	}
+	return ""
+}
+
+func ruleNamesWithPrefix(rules []any, namePrefix string) []string {
+	var names []string
+	for _, rule := range rules {
+		if name := ruleName(rule); strings.HasPrefix(name, namePrefix) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func ruleName(rule any) string {
+	ruleMap, _ := rule.(map[string]any)
+	name, _ := ruleMap["name"].(string)
+	return name
+}
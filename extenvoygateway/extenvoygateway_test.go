@@ -5,12 +5,14 @@ package extenvoygateway
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/steadybit/extension-kubernetes/v2/extconfig"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,10 +91,71 @@ func Test_buildAbortFaultSpec_withBody(t *testing.T) {
 	assert.Equal(t, `{"error":"chaos"}`, body["inline"])
 }
 
-func Test_percentageFromConfig_defaultsTo100(t *testing.T) {
-	assert.Equal(t, float64(100), percentageFromConfig(map[string]any{}))
-	assert.Equal(t, float64(30), percentageFromConfig(map[string]any{"percentage": float64(30)}))
-	assert.Equal(t, float64(30), percentageFromConfig(map[string]any{"percentage": 30}))
+func Test_buildRateLimitSpec(t *testing.T) {
+	spec, err := buildRateLimitSpec(map[string]any{"requests": float64(5), "unit": "Minute"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"rateLimit": map[string]any{
+			"type": "Local",
+			"local": map[string]any{
+				"rules": []any{map[string]any{"limit": map[string]any{"requests": int64(5), "unit": "Minute"}}},
+			},
+		},
+	}, spec)
+
+	_, err = buildRateLimitSpec(map[string]any{"requests": float64(0)})
+	assert.Error(t, err)
+	_, err = buildRateLimitSpec(map[string]any{"requests": float64(1), "unit": "Day"})
+	assert.Error(t, err)
+}
+
+func Test_buildTimeoutSpec(t *testing.T) {
+	spec, err := buildTimeoutSpec(map[string]any{"requestTimeout": float64(100)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"timeout": map[string]any{"http": map[string]any{"requestTimeout": "100ms"}}}, spec)
+
+	spec, err = buildTimeoutSpec(map[string]any{"requestTimeout": float64(100), "connectTimeout": float64(10)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"connectTimeout": "10ms"}, spec["timeout"].(map[string]any)["tcp"])
+
+	_, err = buildTimeoutSpec(map[string]any{"requestTimeout": float64(0)})
+	assert.Error(t, err)
+}
+
+func Test_buildGrpcAbortFaultSpec(t *testing.T) {
+	spec, err := buildGrpcAbortFaultSpec(map[string]any{"grpcStatus": float64(4), "percentage": float64(25)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"faultInjection": map[string]any{"abort": map[string]any{"grpcStatus": int64(4), "percentage": float64(25)}},
+	}, spec)
+
+	_, err = buildGrpcAbortFaultSpec(map[string]any{"grpcStatus": float64(0)})
+	assert.Error(t, err)
+	_, err = buildGrpcAbortFaultSpec(map[string]any{"grpcStatus": float64(17)})
+	assert.Error(t, err)
+}
+
+func Test_buildCircuitBreakerSpec(t *testing.T) {
+	spec, err := buildCircuitBreakerSpec(map[string]any{"maxConnections": float64(1), "maxPendingRequests": float64(0), "maxParallelRequests": float64(2)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"circuitBreaker": map[string]any{"maxConnections": int64(1), "maxPendingRequests": int64(0), "maxParallelRequests": int64(2)},
+	}, spec)
+
+	_, err = buildCircuitBreakerSpec(map[string]any{"maxConnections": float64(-1)})
+	assert.Error(t, err)
+}
+
+func Test_percentageParameter_onlyForFaultInjection(t *testing.T) {
+	hasPercentage := func(desc action_kit_api.ActionDescription) bool {
+		return slices.ContainsFunc(desc.Parameters, func(p action_kit_api.ActionParameter) bool { return p.Name == "percentage" })
+	}
+	assert.True(t, hasPercentage(getDelayDescription()))
+	assert.True(t, hasPercentage(getAbortDescription()))
+	assert.False(t, hasPercentage(getRateLimitDescription()))
+	assert.False(t, hasPercentage(getTimeoutDescription()))
+	assert.False(t, hasPercentage(getDisableRetriesDescription()))
+	assert.False(t, hasPercentage(getCircuitBreakerDescription()))
 }
 
 // --- Conflict detection ------------------------------------------------------
@@ -112,22 +175,22 @@ func Test_findConflictingPolicy(t *testing.T) {
 	existing := []unstructured.Unstructured{policyTargeting("other", "shop", "")}
 
 	// Whole-route conflict.
-	assert.Equal(t, "other", findConflictingPolicy(existing, "shop", "", "mine"))
+	assert.Equal(t, "other", findConflictingPolicy(existing, httpRouteKind, "shop", "", "mine"))
 	// Different route -> no conflict.
-	assert.Equal(t, "", findConflictingPolicy(existing, "cart", "", "mine"))
+	assert.Equal(t, "", findConflictingPolicy(existing, httpRouteKind, "cart", "", "mine"))
 	// Our own policy is ignored.
-	assert.Equal(t, "", findConflictingPolicy([]unstructured.Unstructured{policyTargeting("mine", "shop", "")}, "shop", "", "mine"))
+	assert.Equal(t, "", findConflictingPolicy([]unstructured.Unstructured{policyTargeting("mine", "shop", "")}, httpRouteKind, "shop", "", "mine"))
 
 	// Section-scoped existing policy only conflicts with same section.
 	sectioned := []unstructured.Unstructured{policyTargeting("sec", "shop", "rule-a")}
-	assert.Equal(t, "sec", findConflictingPolicy(sectioned, "shop", "rule-a", "mine"))
-	assert.Equal(t, "", findConflictingPolicy(sectioned, "shop", "rule-b", "mine"))
+	assert.Equal(t, "sec", findConflictingPolicy(sectioned, httpRouteKind, "shop", "rule-a", "mine"))
+	assert.Equal(t, "", findConflictingPolicy(sectioned, httpRouteKind, "shop", "rule-b", "mine"))
 	// A whole-route attack conflicts with a section-scoped existing policy.
-	assert.Equal(t, "sec", findConflictingPolicy(sectioned, "shop", "", "mine"))
+	assert.Equal(t, "sec", findConflictingPolicy(sectioned, httpRouteKind, "shop", "", "mine"))
 }
 
 func Test_buildBackendTrafficPolicy(t *testing.T) {
-	policy := buildBackendTrafficPolicy("default", "steadybit-delay-abc", "abc", "shop", "rule-a", map[string]any{
+	policy := buildBackendTrafficPolicy("default", "steadybit-delay-abc", "abc", httpRouteKind, "shop", []string{"rule-a"}, map[string]any{
 		"faultInjection": map[string]any{"delay": map[string]any{"fixedDelay": "5s"}},
 	})
 
@@ -144,10 +207,109 @@ func Test_buildBackendTrafficPolicy(t *testing.T) {
 	assert.Equal(t, "rule-a", ref["sectionName"])
 }
 
+// --- Request conditions ------------------------------------------------------
+
+func Test_patternWithinPathPrefix(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, prefix string
+		inside, outside bool
+	}{
+		{"/checkout.*", "/", true, false},
+		{".*checkout", "/", true, false},
+		{"/api/checkout.*", "/api", true, false},
+		{"/api/checkout.*", "/api/", true, false},
+		{"/api", "/api", true, false},
+		{"/apis/.*", "/api", false, true},
+		{"/web/.*", "/api", false, true},
+		{"/api.*", "/api", false, false},
+		{".*checkout", "/api", false, false},
+	} {
+		inside, outside, err := patternWithinPathPrefix(tc.pattern, tc.prefix)
+		require.NoError(t, err)
+		assert.Equal(t, tc.inside, inside, "%s within %s", tc.pattern, tc.prefix)
+		assert.Equal(t, tc.outside, outside, "%s outside %s", tc.pattern, tc.prefix)
+	}
+}
+
+func Test_restrictMatches(t *testing.T) {
+	matcher := extcommon.RequestMatcher{PathPattern: "/api/checkout.*", HttpMethod: "POST", HttpHeader: map[string]string{"X-Canary": "true"}}
+	matches, err := restrictMatches(matcher, []any{
+		map[string]any{"path": map[string]any{"type": "PathPrefix", "value": "/api"}},
+		map[string]any{"path": map[string]any{"type": "PathPrefix", "value": "/web"}},
+		map[string]any{"path": map[string]any{"type": "Exact", "value": "/api/checkout"}, "method": "GET"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{
+			"path":    map[string]any{"type": "RegularExpression", "value": "/api/checkout.*"},
+			"method":  "POST",
+			"headers": []any{map[string]any{"type": "Exact", "name": "X-Canary", "value": "true"}},
+		},
+	}, matches, "entries which cannot match the conditions are dropped")
+
+	// A rule without matches matches all requests
+	matches, err = restrictMatches(extcommon.RequestMatcher{HttpHeader: map[string]string{"X-Canary": "true"}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"headers": []any{map[string]any{"type": "Exact", "name": "X-Canary", "value": "true"}}}}, matches)
+
+	_, err = restrictMatches(extcommon.RequestMatcher{PathPattern: "/checkout"}, []any{map[string]any{"path": map[string]any{"type": "RegularExpression", "value": "/.*"}}})
+	assert.ErrorContains(t, err, "RegularExpression path")
+	_, err = restrictMatches(extcommon.RequestMatcher{PathPattern: ".*checkout"}, []any{map[string]any{"path": map[string]any{"type": "PathPrefix", "value": "/api"}}})
+	assert.ErrorContains(t, err, "cannot be combined with the path prefix")
+	_, err = restrictMatches(extcommon.RequestMatcher{HttpHeader: map[string]string{"x-canary": "true"}}, []any{map[string]any{"headers": []any{map[string]any{"type": "RegularExpression", "name": "X-Canary", "value": ".*"}}}})
+	assert.ErrorContains(t, err, "already matches on header")
+}
+
+func routeWithRules(rules ...any) *unstructured.Unstructured {
+	route := httpRoute("default", "shop", "eg-gw", []string{"shop.example.com"})
+	route.Object["spec"].(map[string]any)["rules"] = rules
+	return route
+}
+
+func Test_injectMatcherRules(t *testing.T) {
+	route := routeWithRules(
+		map[string]any{"name": "api", "matches": []any{map[string]any{"path": map[string]any{"type": "PathPrefix", "value": "/api"}}}, "backendRefs": []any{map[string]any{"name": "api"}}},
+		map[string]any{"name": "web", "matches": []any{map[string]any{"path": map[string]any{"type": "PathPrefix", "value": "/web"}}}, "backendRefs": []any{map[string]any{"name": "web"}}},
+	)
+	original, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	matcher := extcommon.RequestMatcher{PathPattern: "/api/checkout"}
+
+	names, changed, err := injectMatcherRules(route, "steadybit-delay-abc-", "", matcher)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"steadybit-delay-abc-0"}, names)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	require.Len(t, rules, 3)
+	assert.Equal(t, map[string]any{
+		"name":        "steadybit-delay-abc-0",
+		"matches":     []any{map[string]any{"path": map[string]any{"type": "RegularExpression", "value": "/api/checkout"}}},
+		"backendRefs": []any{map[string]any{"name": "api"}},
+	}, rules[0])
+	assert.Equal(t, original, rules[1:])
+
+	// Injecting again is a no-op
+	names, changed, err = injectMatcherRules(route, "steadybit-delay-abc-", "", matcher)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, []string{"steadybit-delay-abc-0"}, names)
+	assert.Equal(t, "steadybit-delay-abc-0", findInjectedRule(route, "steadybit-abort-def-"))
+	assert.Equal(t, "", findInjectedRule(route, "steadybit-delay-abc-"))
+
+	assert.True(t, removeMatcherRules(route, "steadybit-delay-abc-"))
+	rules, _, _ = unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Equal(t, original, rules)
+
+	_, _, err = injectMatcherRules(route, "steadybit-delay-abc-", "", extcommon.RequestMatcher{PathPattern: "/other"})
+	assert.ErrorContains(t, err, "no rule of HTTPRoute default/shop matches the request conditions")
+	_, _, err = injectMatcherRules(route, "steadybit-delay-abc-", "missing", matcher)
+	assert.ErrorContains(t, err, `has no rule named "missing"`)
+}
+
 // --- Discovery ---------------------------------------------------------------
 
 var (
 	httpRouteGVK    = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	grpcRouteGVK    = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GRPCRoute"}
 	gatewayGVK      = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
 	gatewayClassGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GatewayClass"}
 	btpGVK          = schema.GroupVersionKind{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "BackendTrafficPolicy"}
@@ -156,13 +318,15 @@ var (
 func getTestClient(stopCh <-chan struct{}) (*client.Client, dynamic.Interface) {
 	extconfig.Config.DiscoveryDisabledEnvoyGateway = false
 	// The global config struct is zero-valued in tests; the real "disabled by default" comes from the
-	// envconfig tag, so explicitly disable Argo Rollouts here to avoid its informer panicking on a
-	// dynamic client that doesn't register the rollout list kind.
+	// envconfig tag, so explicitly disable Argo Rollouts, Traefik and Istio here to avoid their informers
+	// panicking on a dynamic client that doesn't register their list kinds.
 	extconfig.Config.DiscoveryDisabledArgoRollout = true
+	extconfig.Config.DiscoveryDisabledTraefik = true
+	extconfig.Config.DiscoveryDisabledIstio = true
 	extconfig.Config.ClusterName = "test-cluster"
 
 	scheme := runtime.NewScheme()
-	for _, gvk := range []schema.GroupVersionKind{httpRouteGVK, gatewayGVK, gatewayClassGVK, btpGVK} {
+	for _, gvk := range []schema.GroupVersionKind{httpRouteGVK, grpcRouteGVK, gatewayGVK, gatewayClassGVK, btpGVK} {
 		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
 		scheme.AddKnownTypeWithName(listGVK(gvk), &unstructured.UnstructuredList{})
 	}
@@ -170,6 +334,7 @@ func getTestClient(stopCh <-chan struct{}) (*client.Client, dynamic.Interface) {
 	// resource name to its list kind explicitly.
 	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
 		client.HTTPRouteGVR:            "HTTPRouteList",
+		client.GRPCRouteGVR:            "GRPCRouteList",
 		client.GatewayGVR:              "GatewayList",
 		client.GatewayClassGVR:         "GatewayClassList",
 		client.BackendTrafficPolicyGVR: "BackendTrafficPolicyList",
@@ -234,7 +399,7 @@ func Test_httpRouteDiscovery_filtersByEnvoyGatewayClass(t *testing.T) {
 	create(t, dc, client.HTTPRouteGVR, httpRoute("default", "shop", "eg-gw", []string{"shop.example.com"}))
 	create(t, dc, client.HTTPRouteGVR, httpRoute("default", "other", "other-gw", []string{"other.example.com"}))
 
-	discovery := &httpRouteDiscovery{k8s: k8sClient}
+	discovery := &httpRouteDiscovery{k8s: k8sClient, backends: extcommon.NewBackendResolver(k8sClient)}
 
 	assert.EventuallyWithT(t, func(c *assert.CollectT) {
 		targets, err := discovery.DiscoverTargets(context.Background())
@@ -251,6 +416,57 @@ func Test_httpRouteDiscovery_filtersByEnvoyGatewayClass(t *testing.T) {
 	}, 3*time.Second, 50*time.Millisecond)
 }
 
+func grpcRoute(namespace, name, gatewayName string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1", "kind": "GRPCRoute",
+		"metadata": map[string]any{"name": name, "namespace": namespace},
+		"spec": map[string]any{
+			"parentRefs": []any{map[string]any{"name": gatewayName}},
+			"rules": []any{
+				map[string]any{
+					"name": "checkout",
+					"matches": []any{
+						map[string]any{"method": map[string]any{"service": "shop.Checkout", "method": "Pay"}},
+						map[string]any{"method": map[string]any{"type": "RegularExpression", "service": "shop\\..*"}},
+					},
+				},
+				map[string]any{
+					"matches": []any{map[string]any{"method": map[string]any{"type": "Exact", "service": "shop.Cart"}}},
+				},
+			},
+		},
+	}}
+}
+
+func Test_grpcRouteDiscovery_filtersByEnvoyGatewayClass(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+
+	create(t, dc, client.GatewayClassGVR, gatewayClass("eg", envoyGatewayControllerName))
+	create(t, dc, client.GatewayClassGVR, gatewayClass("nginx", "example.com/other-controller"))
+	create(t, dc, client.GatewayGVR, gateway("default", "eg-gw", "eg"))
+	create(t, dc, client.GatewayGVR, gateway("default", "other-gw", "nginx"))
+	create(t, dc, client.GRPCRouteGVR, grpcRoute("default", "shop", "eg-gw"))
+	create(t, dc, client.GRPCRouteGVR, grpcRoute("default", "other", "other-gw"))
+
+	discovery := &grpcRouteDiscovery{k8s: k8sClient}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		targets, err := discovery.DiscoverTargets(context.Background())
+		assert.NoError(c, err)
+		require.Len(c, targets, 1)
+		target := targets[0]
+		assert.Equal(c, EnvoyGatewayGrpcRouteTargetType, target.TargetType)
+		assert.Equal(c, "shop", target.Label)
+		assert.Equal(c, []string{"shop"}, target.Attributes["k8s.envoy-gateway.grpc-route"])
+		assert.Equal(c, []string{"checkout"}, target.Attributes["k8s.envoy-gateway.grpc-route.rule"])
+		assert.Equal(c, []string{"shop.Cart", "shop.Checkout"}, target.Attributes["k8s.envoy-gateway.grpc-route.service"])
+		assert.Equal(c, []string{"shop.Checkout/Pay"}, target.Attributes["k8s.envoy-gateway.grpc-route.method"])
+		assert.Equal(c, []string{"eg-gw"}, target.Attributes["k8s.envoy-gateway.gateway"])
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
 // --- Action lifecycle --------------------------------------------------------
 
 func newDelayRequest(executionId uuid.UUID) action_kit_api.PrepareActionRequestBody {
@@ -312,3 +528,95 @@ func Test_action_prepare_failsOnConflictingPolicy(t *testing.T) {
 	_, err := action.Prepare(context.Background(), &state, req)
 	assert.Error(t, err, "prepare should fail when a policy already targets the route")
 }
+
+func Test_action_lifecycle_withRequestConditions(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+	original := routeWithRules(map[string]any{"backendRefs": []any{map[string]any{"name": "shop", "port": int64(8080)}}})
+	create(t, dc, client.HTTPRouteGVR, original)
+
+	action := NewDelayAction(k8sClient).(*backendTrafficPolicyAction)
+	executionId := uuid.New()
+	req := newDelayRequest(executionId)
+	req.Config["conditionPathPattern"] = "/checkout.*"
+	req.Config["conditionHttpHeader"] = []any{map[string]any{"key": "X-Canary", "value": "true"}}
+
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, req)
+	require.NoError(t, err)
+	assert.Equal(t, state.PolicyName+"-", state.RuleNamePrefix)
+	assert.Nil(t, state.RuleNames)
+
+	_, err = action.Start(context.Background(), &state)
+	require.NoError(t, err)
+
+	route, err := k8sClient.GetHTTPRoute(context.Background(), "default", "shop")
+	require.NoError(t, err)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	require.Len(t, rules, 2)
+	assert.Equal(t, state.RuleNamePrefix+"0", ruleName(rules[0]))
+	assert.Equal(t, []any{map[string]any{
+		"path":    map[string]any{"type": "RegularExpression", "value": "/checkout.*"},
+		"headers": []any{map[string]any{"type": "Exact", "name": "X-Canary", "value": "true"}},
+	}}, rules[0].(map[string]any)["matches"])
+
+	policy, err := dc.Resource(client.BackendTrafficPolicyGVR).Namespace("default").Get(context.Background(), state.PolicyName, metav1.GetOptions{})
+	require.NoError(t, err)
+	targetRefs, _, _ := unstructured.NestedSlice(policy.Object, "spec", "targetRefs")
+	require.Len(t, targetRefs, 1)
+	assert.Equal(t, state.RuleNamePrefix+"0", targetRefs[0].(map[string]any)["sectionName"])
+
+	_, err = action.Stop(context.Background(), &state)
+	require.NoError(t, err)
+
+	route, err = k8sClient.GetHTTPRoute(context.Background(), "default", "shop")
+	require.NoError(t, err)
+	rules, _, _ = unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Equal(t, original.Object["spec"].(map[string]any)["rules"], rules)
+}
+
+func Test_grpcAction_lifecycle_targetsGrpcRoute(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+
+	action := NewGrpcAbortAction(k8sClient).(*backendTrafficPolicyAction)
+	executionId := uuid.New()
+	req := action_kit_api.PrepareActionRequestBody{
+		ExecutionId: executionId,
+		Config:      map[string]any{"duration": float64(30000), "percentage": float64(50), "grpcStatus": float64(14)},
+		Target: new(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":                {"default"},
+				"k8s.envoy-gateway.grpc-route": {"shop"},
+			},
+		}),
+	}
+
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, req)
+	require.NoError(t, err)
+	assert.Equal(t, "steadybit-grpc-abort-"+executionId.String(), state.PolicyName)
+
+	_, err = action.Start(context.Background(), &state)
+	require.NoError(t, err)
+
+	created, err := dc.Resource(client.BackendTrafficPolicyGVR).Namespace("default").Get(context.Background(), state.PolicyName, metav1.GetOptions{})
+	require.NoError(t, err)
+	targetRefs, _, _ := unstructured.NestedSlice(created.Object, "spec", "targetRefs")
+	require.Len(t, targetRefs, 1)
+	assert.Equal(t, grpcRouteKind, targetRefs[0].(map[string]any)["kind"])
+	assert.Equal(t, "shop", targetRefs[0].(map[string]any)["name"])
+
+	// An HTTPRoute of the same name is a different target.
+	httpAction := NewDelayAction(k8sClient).(*backendTrafficPolicyAction)
+	httpState := httpAction.NewEmptyState()
+	_, err = httpAction.Prepare(context.Background(), &httpState, newDelayRequest(uuid.New()))
+	require.NoError(t, err)
+
+	_, err = action.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	_, err = dc.Resource(client.BackendTrafficPolicyGVR).Namespace("default").Get(context.Background(), state.PolicyName, metav1.GetOptions{})
+	assert.Error(t, err, "policy should be deleted on stop")
+}
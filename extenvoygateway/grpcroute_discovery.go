@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extenvoygateway
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type grpcRouteDiscovery struct {
+	k8s *client.Client
+}
+
+var _ discovery_kit_sdk.TargetDescriber = (*grpcRouteDiscovery)(nil)
+
+func NewGrpcRouteDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &grpcRouteDiscovery{k8s: k8s}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+		reflect.TypeFor[unstructured.Unstructured](),
+	)
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, 5*time.Second),
+	)
+}
+
+func (d *grpcRouteDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: EnvoyGatewayGrpcRouteTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("30s"),
+		},
+	}
+}
+
+func (d *grpcRouteDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       EnvoyGatewayGrpcRouteTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Envoy gRPC Route", Other: "Envoy gRPC Routes"},
+		Category: new("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(EnvoyGatewayIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: attrGrpcRoute},
+				{Attribute: "k8s.envoy-gateway.grpc-route.service"},
+				{Attribute: "k8s.envoy-gateway.gateway"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{Attribute: attrGrpcRoute, Direction: "ASC"},
+			},
+		},
+	}
+}
+
+func (d *grpcRouteDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	envoyGatewayClasses := envoyManagedGatewayClasses(d.k8s)
+	if len(envoyGatewayClasses) == 0 {
+		return []discovery_kit_api.Target{}, nil
+	}
+	gatewayToClass := gatewayToGatewayClass(d.k8s)
+
+	var targets []discovery_kit_api.Target
+	for _, route := range d.k8s.GRPCRoutes() {
+		if client.IsExcludedFromDiscovery(objectMetaFromUnstructured(route)) {
+			continue
+		}
+
+		matchingGateways, gatewayClass := resolveEnvoyGateways(route, gatewayToClass, envoyGatewayClasses)
+		if len(matchingGateways) == 0 {
+			continue
+		}
+
+		targets = append(targets, d.toTarget(route, matchingGateways, gatewayClass))
+	}
+
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesEnvoyGateway), nil
+}
+
+func (d *grpcRouteDiscovery) toTarget(route *unstructured.Unstructured, gateways []gatewayRef, gatewayClass string) discovery_kit_api.Target {
+	namespace := route.GetNamespace()
+	name := route.GetName()
+
+	attributes := map[string][]string{
+		"k8s.namespace":                  {namespace},
+		"k8s.cluster-name":               {extconfig.Config.ClusterName},
+		"k8s.distribution":               {d.k8s.Distribution},
+		attrGrpcRoute:                    {name},
+		"k8s.envoy-gateway.gatewayclass": {gatewayClass},
+	}
+
+	if hostnames, found, err := unstructured.NestedStringSlice(route.Object, "spec", "hostnames"); err == nil && found && len(hostnames) > 0 {
+		attributes["k8s.envoy-gateway.grpc-route.hostname"] = extcommon.SortDedup(hostnames)
+	}
+
+	var gatewayNames, gatewayNamespaces []string
+	for _, gw := range gateways {
+		gatewayNames = append(gatewayNames, gw.name)
+		gatewayNamespaces = append(gatewayNamespaces, gw.namespace)
+	}
+	attributes["k8s.envoy-gateway.gateway"] = gatewayNames
+	attributes["k8s.envoy-gateway.gateway.namespace"] = gatewayNamespaces
+
+	if ruleNames := ruleNames(route); len(ruleNames) > 0 {
+		attributes["k8s.envoy-gateway.grpc-route.rule"] = extcommon.SortDedup(ruleNames)
+	}
+
+	services, methods := grpcMethodMatches(route)
+	if len(services) > 0 {
+		attributes["k8s.envoy-gateway.grpc-route.service"] = extcommon.SortDedup(services)
+	}
+	if len(methods) > 0 {
+		attributes["k8s.envoy-gateway.grpc-route.method"] = extcommon.SortDedup(methods)
+	}
+
+	for key, value := range route.GetLabels() {
+		if !slices.Contains(extconfig.Config.LabelFilter, key) {
+			attributes[fmt.Sprintf("k8s.envoy-gateway.grpc-route.label.%v", key)] = []string{value}
+			attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+		}
+	}
+
+	extcommon.AddNamespaceLabels(attributes, d.k8s, namespace)
+
+	return discovery_kit_api.Target{
+		Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, namespace, name),
+		TargetType: EnvoyGatewayGrpcRouteTargetType,
+		Label:      name,
+		Attributes: attributes,
+	}
+}
+
+// grpcMethodMatches returns the gRPC services and methods ("service/method") matched exactly by the route
+// rules. Regular expression matches are skipped as they do not name a concrete service or method.
+func grpcMethodMatches(route *unstructured.Unstructured) ([]string, []string) {
+	rules, found, err := unstructured.NestedSlice(route.Object, "spec", "rules")
+	if err != nil || !found {
+		return nil, nil
+	}
+	var services, methods []string
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			continue
+		}
+		matches, _ := ruleMap["matches"].([]any)
+		for _, match := range matches {
+			matchMap, ok := match.(map[string]any)
+			if !ok {
+				continue
+			}
+			method, ok := matchMap["method"].(map[string]any)
+			if !ok {
+				continue
+			}
+			if matchType, _ := method["type"].(string); matchType != "" && matchType != "Exact" {
+				continue
+			}
+			service, _ := method["service"].(string)
+			if service == "" {
+				continue
+			}
+			services = append(services, service)
+			if name, _ := method["method"].(string); name != "" {
+				methods = append(methods, service+"/"+name)
+			}
+		}
+	}
+	return services, methods
+}
@@ -18,19 +18,28 @@ import (
 	"github.com/steadybit/extension-kubernetes/v2/client"
 	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type httpRouteDiscovery struct {
-	k8s *client.Client
+	k8s      *client.Client
+	backends *extcommon.BackendResolver
 }
 
-var _ discovery_kit_sdk.TargetDescriber = (*httpRouteDiscovery)(nil)
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*httpRouteDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*httpRouteDiscovery)(nil)
+)
 
 func NewHttpRouteDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &httpRouteDiscovery{k8s: k8s}
-	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+	discovery := &httpRouteDiscovery{k8s: k8s, backends: extcommon.NewBackendResolver(k8s)}
+	chRefresh := extcommon.TriggerOnKubernetesSpecChange(k8s,
 		reflect.TypeFor[unstructured.Unstructured](),
+		reflect.TypeFor[corev1.Service](),
+		reflect.TypeFor[appsv1.Deployment](),
+		reflect.TypeFor[appsv1.StatefulSet](),
 	)
 	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
 		discovery_kit_sdk.WithRefreshTargetsNow(),
@@ -69,18 +78,19 @@ func (d *httpRouteDiscovery) DescribeTarget() discovery_kit_api.TargetDescriptio
 	}
 }
 
-// gatewayRef identifies a Gateway referenced by an HTTPRoute parentRef.
+// gatewayRef identifies a Gateway referenced by a route parentRef.
 type gatewayRef struct {
 	namespace string
 	name      string
 }
 
 func (d *httpRouteDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
-	envoyGatewayClasses := d.envoyManagedGatewayClasses()
+	envoyGatewayClasses := envoyManagedGatewayClasses(d.k8s)
 	if len(envoyGatewayClasses) == 0 {
 		return []discovery_kit_api.Target{}, nil
 	}
-	gatewayToClass := d.gatewayToGatewayClass()
+	defer d.backends.EndRun()
+	gatewayToClass := gatewayToGatewayClass(d.k8s)
 
 	var targets []discovery_kit_api.Target
 	for _, route := range d.k8s.HTTPRoutes() {
@@ -88,21 +98,26 @@ func (d *httpRouteDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit
 			continue
 		}
 
-		matchingGateways, gatewayClass := d.resolveEnvoyGateways(route, gatewayToClass, envoyGatewayClasses)
+		matchingGateways, gatewayClass := resolveEnvoyGateways(route, gatewayToClass, envoyGatewayClasses)
 		if len(matchingGateways) == 0 {
 			continue
 		}
 
-		targets = append(targets, d.toTarget(route, matchingGateways, gatewayClass))
+		backendAttributes, _ := d.backends.Resolve(route.GetNamespace(), extcommon.HTTPRouteBackends(route))
+		targets = append(targets, d.toTarget(route, matchingGateways, gatewayClass, backendAttributes))
 	}
 
 	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesEnvoyGateway), nil
 }
 
+func (d *httpRouteDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return extcommon.GetRouteToWorkloadEnrichmentRules(EnvoyGatewayHttpRouteTargetType)
+}
+
 // envoyManagedGatewayClasses returns the set of GatewayClass names whose controllerName is Envoy Gateway's.
-func (d *httpRouteDiscovery) envoyManagedGatewayClasses() map[string]bool {
+func envoyManagedGatewayClasses(k8s *client.Client) map[string]bool {
 	result := map[string]bool{}
-	for _, gc := range d.k8s.GatewayClasses() {
+	for _, gc := range k8s.GatewayClasses() {
 		controllerName, found, err := unstructured.NestedString(gc.Object, "spec", "controllerName")
 		if err == nil && found && controllerName == envoyGatewayControllerName {
 			result[gc.GetName()] = true
@@ -112,9 +127,9 @@ func (d *httpRouteDiscovery) envoyManagedGatewayClasses() map[string]bool {
 }
 
 // gatewayToGatewayClass maps each Gateway (namespace/name) to its gatewayClassName.
-func (d *httpRouteDiscovery) gatewayToGatewayClass() map[gatewayRef]string {
+func gatewayToGatewayClass(k8s *client.Client) map[gatewayRef]string {
 	result := map[gatewayRef]string{}
-	for _, gw := range d.k8s.Gateways() {
+	for _, gw := range k8s.Gateways() {
 		className, found, err := unstructured.NestedString(gw.Object, "spec", "gatewayClassName")
 		if err == nil && found {
 			result[gatewayRef{namespace: gw.GetNamespace(), name: gw.GetName()}] = className
@@ -123,7 +138,7 @@ func (d *httpRouteDiscovery) gatewayToGatewayClass() map[gatewayRef]string {
 	return result
 }
 
-// parseGatewayParentRef extracts a Gateway reference from an HTTPRoute parentRef entry, applying
+// parseGatewayParentRef extracts a Gateway reference from a route parentRef entry, applying
 // Gateway API defaulting: kind defaults to Gateway (non-Gateway kinds are skipped) and namespace
 // defaults to the route's namespace. Returns false when the entry is not a usable Gateway reference.
 func parseGatewayParentRef(ref any, routeNamespace string) (gatewayRef, bool) {
@@ -147,7 +162,7 @@ func parseGatewayParentRef(ref any, routeNamespace string) (gatewayRef, bool) {
 
 // resolveEnvoyGateways walks the route's parentRefs and returns the Gateways that belong to an Envoy
 // Gateway GatewayClass, plus the resolved GatewayClass name.
-func (d *httpRouteDiscovery) resolveEnvoyGateways(route *unstructured.Unstructured, gatewayToClass map[gatewayRef]string, envoyGatewayClasses map[string]bool) ([]gatewayRef, string) {
+func resolveEnvoyGateways(route *unstructured.Unstructured, gatewayToClass map[gatewayRef]string, envoyGatewayClasses map[string]bool) ([]gatewayRef, string) {
 	parentRefs, found, err := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
 	if err != nil || !found {
 		return nil, ""
@@ -182,7 +197,7 @@ func (d *httpRouteDiscovery) resolveEnvoyGateways(route *unstructured.Unstructur
 	return matching, gatewayClass
 }
 
-func (d *httpRouteDiscovery) toTarget(route *unstructured.Unstructured, gateways []gatewayRef, gatewayClass string) discovery_kit_api.Target {
+func (d *httpRouteDiscovery) toTarget(route *unstructured.Unstructured, gateways []gatewayRef, gatewayClass string, backendAttributes map[string][]string) discovery_kit_api.Target {
 	namespace := route.GetNamespace()
 	name := route.GetName()
 
@@ -195,7 +210,7 @@ func (d *httpRouteDiscovery) toTarget(route *unstructured.Unstructured, gateways
 	}
 
 	if hostnames, found, err := unstructured.NestedStringSlice(route.Object, "spec", "hostnames"); err == nil && found && len(hostnames) > 0 {
-		attributes["k8s.envoy-gateway.http-route.hostname"] = sortDedup(hostnames)
+		attributes["k8s.envoy-gateway.http-route.hostname"] = extcommon.SortDedup(hostnames)
 	}
 
 	var gatewayNames, gatewayNamespaces []string
@@ -207,9 +222,11 @@ func (d *httpRouteDiscovery) toTarget(route *unstructured.Unstructured, gateways
 	attributes["k8s.envoy-gateway.gateway.namespace"] = gatewayNamespaces
 
 	if ruleNames := ruleNames(route); len(ruleNames) > 0 {
-		attributes["k8s.envoy-gateway.http-route.rule"] = sortDedup(ruleNames)
+		attributes["k8s.envoy-gateway.http-route.rule"] = extcommon.SortDedup(ruleNames)
 	}
 
+	extcommon.MergeAttributes(attributes, backendAttributes)
+
 	for key, value := range route.GetLabels() {
 		if !slices.Contains(extconfig.Config.LabelFilter, key) {
 			attributes[fmt.Sprintf("k8s.envoy-gateway.http-route.label.%v", key)] = []string{value}
@@ -227,14 +244,6 @@ func (d *httpRouteDiscovery) toTarget(route *unstructured.Unstructured, gateways
 	}
 }
 
-// sortDedup returns a sorted, de-duplicated copy so multi-valued attributes stay stable across
-// discovery cycles and don't churn the platform's target store.
-func sortDedup(values []string) []string {
-	out := slices.Clone(values)
-	slices.Sort(out)
-	return slices.Compact(out)
-}
-
 // ruleNames returns the names of named route rules (eligible for sectionName targeting).
 func ruleNames(route *unstructured.Unstructured) []string {
 	rules, found, err := unstructured.NestedSlice(route.Object, "spec", "rules")
@@ -247,7 +256,8 @@ func ruleNames(route *unstructured.Unstructured) []string {
 		if !ok {
 			continue
 		}
-		if name, ok := ruleMap["name"].(string); ok && name != "" {
+		// Rules injected by attacks with request conditions are temporary and not eligible.
+		if name, ok := ruleMap["name"].(string); ok && name != "" && !strings.HasPrefix(name, injectedRuleNamePrefix) {
 			names = append(names, name)
 		}
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/steadybit/extension-kubernetes/v2/client"
 	"github.com/steadybit/extension-kubernetes/v2/testutil"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
@@ -95,3 +96,99 @@ func prepareTest(stopCh chan struct{}) (*K8sEventsState, *client.Client) {
 	dynamicClient := testutil.NewFakeDynamicClient()
 	return &state, client.CreateClient(clientset, stopCh, "", client.MockAllPermitted(), dynamicClient)
 }
+
+func TestStatusOnlyReturnsEventsOfTargetDeployment(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	now := metav1.Time{Time: time.Now()}
+	clientset := testclient.NewClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop", UID: "deployment-uid"}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "checkout-abc", Namespace: "shop", UID: "rs-uid", OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "checkout", UID: "deployment-uid"}}}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-abc-1", Namespace: "shop", OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "checkout-abc", UID: "rs-uid"}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "cart-1", Namespace: "shop"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		newEvent("e1", "shop", "Pod", "checkout-abc-1", corev1.EventTypeWarning, "BackOff", now),
+		newEvent("e2", "shop", "Pod", "checkout-abc-1", corev1.EventTypeNormal, "Pulled", now),
+		newEvent("e3", "shop", "ReplicaSet", "checkout-abc", corev1.EventTypeNormal, "SuccessfulCreate", now),
+		newEvent("e4", "shop", "Deployment", "checkout", corev1.EventTypeWarning, "ScalingReplicaSet", now),
+		newEvent("e5", "shop", "Pod", "cart-1", corev1.EventTypeWarning, "BackOff", now),
+		newEvent("e6", "other", "Pod", "checkout-abc-1", corev1.EventTypeWarning, "BackOff", now),
+	)
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted(), testutil.NewFakeDynamicClient())
+
+	action := NewDeploymentEventsAction().(K8sEventsAction)
+	state := action.NewEmptyState()
+	_, err := prepareInternal(k8sClient, action.scope, &state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"duration": 60000, "eventTypes": []any{"Warning"}},
+		Target: &action_kit_api.Target{Attributes: map[string][]string{
+			"k8s.namespace":  {"shop"},
+			"k8s.deployment": {"checkout"},
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"shop/checkout-abc-1"}, state.Scope.Pods)
+	state.LastEventOffset = time.Since(referenceTime) - time.Minute
+
+	// When
+	result := statusInternal(k8sClient, &state)
+
+	// Then
+	var names []string
+	for _, message := range *result.Messages {
+		names = append(names, message.Message)
+	}
+	require.ElementsMatch(t, []string{"e1", "e4"}, names)
+}
+
+func TestScopeRemembersDeletedPodsOfNode(t *testing.T) {
+	scope := EventScope{Kind: "Node", Name: "node-1", Pods: []string{"shop/gone-1"}}
+	event := newEvent("e1", "shop", "Pod", "gone-1", corev1.EventTypeNormal, "Killing", metav1.Now())
+
+	require.True(t, scope.matches(nil, event))
+	require.True(t, scope.matches(nil, newEvent("e2", "default", "Node", "node-1", corev1.EventTypeNormal, "NodeNotReady", metav1.Now())))
+	require.False(t, scope.matches(nil, newEvent("e3", "default", "Node", "node-2", corev1.EventTypeNormal, "NodeNotReady", metav1.Now())))
+}
+
+func TestClusterScopeFiltersByNamespace(t *testing.T) {
+	scope := EventScope{Namespace: "shop"}
+
+	require.True(t, scope.matches(nil, newEvent("e1", "shop", "Pod", "a", corev1.EventTypeNormal, "Pulled", metav1.Now())))
+	require.False(t, scope.matches(nil, newEvent("e2", "other", "Pod", "a", corev1.EventTypeNormal, "Pulled", metav1.Now())))
+}
+
+func TestEventsToMessagesUsesSeriesTimestampAndCount(t *testing.T) {
+	observed := time.Date(2026, 1, 1, 10, 5, 0, 0, time.UTC)
+	events := []corev1.Event{{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "shop"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "checkout-1"},
+		Message:        "Back-off restarting failed container",
+		Reason:         "BackOff",
+		Type:           corev1.EventTypeWarning,
+		EventTime:      metav1.MicroTime{Time: observed.Add(-5 * time.Minute)},
+		Series:         &corev1.EventSeries{Count: 4, LastObservedTime: metav1.MicroTime{Time: observed}},
+	}}
+
+	messages := *eventsToMessages(&events)
+
+	require.Len(t, messages, 1)
+	require.Equal(t, observed, *messages[0].Timestamp)
+	require.Equal(t, "4", (*messages[0].Fields)["count"])
+}
+
+func newEvent(message, namespace, kind, name, eventType, reason string, timestamp metav1.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: message, Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name, Namespace: namespace},
+		Message:        message,
+		Type:           eventType,
+		Reason:         reason,
+		LastTimestamp:  timestamp,
+	}
+}
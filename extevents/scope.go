@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extevents
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EventScope narrows the collected events to a single target. Events are in scope if their involved object is the
+// target itself or one of the pods (and for deployments: replicasets) belonging to it. An empty Kind matches every
+// object, optionally restricted to a namespace.
+type EventScope struct {
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Pods remembers the pods (namespace/name) seen belonging to the target, so events of pods which are already
+	// deleted (e.g. because the attack killed them) are still collected.
+	Pods []string `json:"pods,omitempty"`
+}
+
+func (s *K8sEventsState) filter(k8s *client.Client, events *[]corev1.Event) *[]corev1.Event {
+	if s.Scope.Kind == "" && s.Scope.Namespace == "" && len(s.Types) == 0 && len(s.Reasons) == 0 {
+		return events
+	}
+
+	result := make([]corev1.Event, 0, len(*events))
+	for _, event := range *events {
+		if len(s.Types) > 0 && !containsFold(s.Types, event.Type) {
+			continue
+		}
+		if len(s.Reasons) > 0 && !containsFold(s.Reasons, event.Reason) {
+			continue
+		}
+		if !s.Scope.matches(k8s, &event) {
+			continue
+		}
+		result = append(result, event)
+	}
+	return &result
+}
+
+func (s *EventScope) matches(k8s *client.Client, event *corev1.Event) bool {
+	involved := event.InvolvedObject
+	if s.Kind == "" {
+		return s.Namespace == "" || involved.Namespace == s.Namespace || event.Namespace == s.Namespace
+	}
+
+	// Nodes are cluster-scoped, their pods may live in any namespace.
+	if s.Kind == "Node" {
+		if involved.Kind == "Node" {
+			return involved.Name == s.Name
+		}
+		return involved.Kind == "Pod" && s.isOwnPod(k8s, involved.Namespace, involved.Name)
+	}
+
+	if involved.Namespace != s.Namespace {
+		return false
+	}
+	if strings.EqualFold(involved.Kind, s.Kind) && involved.Name == s.Name {
+		return true
+	}
+	switch involved.Kind {
+	case "Pod":
+		return s.isOwnPod(k8s, involved.Namespace, involved.Name)
+	case "ReplicaSet":
+		return s.Kind == "Deployment" && s.ownsReplicaSet(k8s, involved.Name)
+	}
+	return false
+}
+
+func (s *EventScope) isOwnPod(k8s *client.Client, namespace, name string) bool {
+	key := namespace + "/" + name
+	if slices.Contains(s.Pods, key) {
+		return true
+	}
+	pod := k8s.PodByNamespaceAndName(namespace, name)
+	if pod == nil || !s.ownsPod(k8s, pod) {
+		return false
+	}
+	s.Pods = append(s.Pods, key)
+	return true
+}
+
+func (s *EventScope) ownsPod(k8s *client.Client, pod *corev1.Pod) bool {
+	switch s.Kind {
+	case "Node":
+		return pod.Spec.NodeName == s.Name
+	case "Pod":
+		return pod.Namespace == s.Namespace && pod.Name == s.Name
+	}
+	if pod.Namespace != s.Namespace {
+		return false
+	}
+	owner := client.OwnerReference{Name: s.Name, Kind: strings.ToLower(s.Kind)}
+	return slices.Contains(client.OwnerReferences(k8s, &pod.ObjectMeta).OwnerRefs, owner)
+}
+
+func (s *EventScope) ownsReplicaSet(k8s *client.Client, name string) bool {
+	replicaSet := k8s.ReplicaSetByNamespaceAndName(s.Namespace, name)
+	if replicaSet == nil {
+		return false
+	}
+	for _, ref := range replicaSet.OwnerReferences {
+		if ref.Kind == "Deployment" && ref.Name == s.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// rememberCurrentPods records the pods currently belonging to the target, so their events are still collected
+// after they have been deleted.
+func (s *EventScope) rememberCurrentPods(k8s *client.Client) {
+	if s.Kind == "" || s.Kind == "Pod" {
+		return
+	}
+	for _, pod := range k8s.Pods() {
+		if s.ownsPod(k8s, pod) {
+			s.Pods = append(s.Pods, pod.Namespace+"/"+pod.Name)
+		}
+	}
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool {
+		return strings.EqualFold(v, value)
+	})
+}
+
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if trimmed := strings.TrimSpace(v); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extevents
+
+import (
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/v2/extdaemonset"
+	"github.com/steadybit/extension-kubernetes/v2/extdeployment"
+	"github.com/steadybit/extension-kubernetes/v2/extnode"
+	"github.com/steadybit/extension-kubernetes/v2/extpod"
+	"github.com/steadybit/extension-kubernetes/v2/extstatefulset"
+)
+
+const (
+	DeploymentEventsActionId  = "com.steadybit.extension_kubernetes.kubernetes_logs_deployment"
+	StatefulSetEventsActionId = "com.steadybit.extension_kubernetes.kubernetes_logs_statefulset"
+	DaemonSetEventsActionId   = "com.steadybit.extension_kubernetes.kubernetes_logs_daemonset"
+	PodEventsActionId         = "com.steadybit.extension_kubernetes.kubernetes_logs_pod"
+	NodeEventsActionId        = "com.steadybit.extension_kubernetes.kubernetes_logs_node"
+)
+
+func NewDeploymentEventsAction() action_kit_sdk.Action[K8sEventsState] {
+	return newWorkloadEventsAction(DeploymentEventsActionId, extdeployment.DeploymentTargetType, "Deployment", "k8s.deployment")
+}
+
+func NewStatefulSetEventsAction() action_kit_sdk.Action[K8sEventsState] {
+	return newWorkloadEventsAction(StatefulSetEventsActionId, extstatefulset.StatefulSetTargetType, "StatefulSet", "k8s.statefulset")
+}
+
+func NewDaemonSetEventsAction() action_kit_sdk.Action[K8sEventsState] {
+	return newWorkloadEventsAction(DaemonSetEventsActionId, extdaemonset.DaemonSetTargetType, "DaemonSet", "k8s.daemonset")
+}
+
+func NewPodEventsAction() action_kit_sdk.Action[K8sEventsState] {
+	return newWorkloadEventsAction(PodEventsActionId, extpod.PodTargetType, "Pod", "k8s.pod.name")
+}
+
+func NewNodeEventsAction() action_kit_sdk.Action[K8sEventsState] {
+	return K8sEventsAction{
+		actionId:        NodeEventsActionId,
+		targetType:      extnode.NodeTargetType,
+		targetTypeLabel: "Node",
+		selectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+			{
+				Label:       "node name",
+				Description: new("Find node by cluster and name"),
+				Query:       "k8s.cluster-name=\"\" AND k8s.node.name=\"\"",
+			},
+		}),
+		scope: func(request action_kit_api.PrepareActionRequestBody) EventScope {
+			return EventScope{
+				Kind: "Node",
+				Name: request.Target.Attributes["k8s.node.name"][0],
+			}
+		},
+	}
+}
+
+// newWorkloadEventsAction creates an events action for a namespaced target identified by the given name attribute.
+func newWorkloadEventsAction(actionId, targetType, kind, nameAttribute string) K8sEventsAction {
+	return K8sEventsAction{
+		actionId:        actionId,
+		targetType:      targetType,
+		targetTypeLabel: kind,
+		selectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+			{
+				Label:       strings.ToLower(kind),
+				Description: new("Find " + kind + " by cluster, namespace and name"),
+				Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND " + nameAttribute + "=\"\"",
+			},
+		}),
+		scope: func(request action_kit_api.PrepareActionRequestBody) EventScope {
+			return EventScope{
+				Kind:      kind,
+				Namespace: request.Target.Attributes["k8s.namespace"][0],
+				Name:      request.Target.Attributes[nameAttribute][0],
+			}
+		},
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extgatewayapi
+
+import (
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+)
+
+func NewBlackholeAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &ruleAction{
+		k8s:           k8s,
+		description:   getBlackholeDescription(),
+		ruleField:     "backendRefs",
+		parseConfigFn: parseBlackholeConfig,
+		modifyFn:      blackholeBackendRefs,
+	}
+}
+
+func getBlackholeDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		BlackholeActionId,
+		"HTTP Route Blackhole Traffic",
+		"Send a percentage of the traffic on a Gateway API HTTP route to a non-existent backend, which the gateway answers with HTTP 500.",
+	)
+	desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
+		Name:         "percentage",
+		Label:        "Traffic Percentage",
+		Description:  new("The percentage of requests sent to the blackhole backend."),
+		Type:         action_kit_api.ActionParameterTypePercentage,
+		DefaultValue: new("50"),
+		MinValue:     new(1),
+		MaxValue:     new(100),
+		Required:     new(true),
+	})
+	return withSectionNameParameter(desc)
+}
+
+func parseBlackholeConfig(state *ActionState, config map[string]any) error {
+	state.Percentage = extutil.ToInt(config["percentage"])
+	if state.Percentage < 1 || state.Percentage > 100 {
+		return fmt.Errorf("percentage must be between 1 and 100")
+	}
+	return nil
+}
+
+// blackholeServiceName is the name of the non-existent Service the blackhole backendRef points to.
+func blackholeServiceName(executionId string) string {
+	return "steadybit-blackhole-" + executionId
+}
+
+func blackholeBackendRefs(state *ActionState, value any) (any, bool, error) {
+	backendRefs, _ := value.([]any)
+	return withBlackholeBackend(backendRefs, blackholeServiceName(state.ExecutionId), state.Percentage)
+}
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extgatewayapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ActionState is shared by all Gateway API HTTPRoute attacks.
+type ActionState struct {
+	Namespace   string `json:"namespace"`
+	RouteName   string `json:"routeName"`
+	SectionName string `json:"sectionName"`
+	ExecutionId string `json:"executionId"`
+	// Percentage is the share of the traffic sent to the blackhole backend.
+	Percentage int `json:"percentage"`
+	// FilterType, SetHeaders and RemoveHeaders describe the header modifications.
+	FilterType    string            `json:"filterType"`
+	SetHeaders    map[string]string `json:"setHeaders"`
+	RemoveHeaders []string          `json:"removeHeaders"`
+	// OriginalValues holds, per index of the modified rules, the value of the modified rule field before the attack.
+	// A nil value means the field was not set.
+	OriginalValues map[string]any `json:"originalValues"`
+}
+
+// ruleAction is the common attack implementation. It modifies a single field of the HTTPRoute rules for the duration
+// of the attack and restores the original values afterward. Each attack supplies a description, the rule field it
+// modifies, a parseConfigFn storing its configuration in the state and a modifyFn computing the new field value.
+type ruleAction struct {
+	k8s           *client.Client
+	description   action_kit_api.ActionDescription
+	ruleField     string
+	parseConfigFn func(state *ActionState, config map[string]any) error
+	// modifyFn returns the new value of the rule field, or false if the rule cannot be attacked.
+	modifyFn func(state *ActionState, value any) (any, bool, error)
+}
+
+func (a *ruleAction) NewEmptyState() ActionState {
+	return ActionState{}
+}
+
+func (a *ruleAction) Describe() action_kit_api.ActionDescription {
+	return a.description
+}
+
+func (a *ruleAction) Prepare(ctx context.Context, state *ActionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	namespace := request.Target.Attributes["k8s.namespace"]
+	routeName := request.Target.Attributes[attrHttpRoute]
+	if len(namespace) == 0 || len(routeName) == 0 {
+		return nil, extension_kit.ToError("Missing required target attributes k8s.namespace and/or k8s.gateway-api.http-route.", nil)
+	}
+
+	state.Namespace = namespace[0]
+	state.RouteName = routeName[0]
+	state.SectionName = extutil.ToString(request.Config["sectionName"])
+	state.ExecutionId = request.ExecutionId.String()
+
+	if err := a.parseConfigFn(state, request.Config); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to parse attack configuration: %v", err), err)
+	}
+
+	route, err := a.k8s.GetHTTPRoute(ctx, state.Namespace, state.RouteName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to fetch HTTPRoute %s/%s: %v", state.Namespace, state.RouteName, err), err)
+	}
+	// Apply the attack to the fetched copy only, so a route which cannot be attacked fails the preparation instead of
+	// the start. The original values are recorded again in Start.
+	if _, err := a.apply(route, state); err != nil {
+		return nil, extension_kit.ToError(err.Error(), err)
+	}
+	state.OriginalValues = nil
+	return nil, nil
+}
+
+func (a *ruleAction) Start(ctx context.Context, state *ActionState) (*action_kit_api.StartResult, error) {
+	err := a.k8s.UpdateHTTPRoute(ctx, state.Namespace, state.RouteName, func(route *unstructured.Unstructured) (bool, error) {
+		// Re-check on the latest version, a concurrent attack on the same HTTPRoute may have started since Prepare.
+		return a.apply(route, state)
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to modify HTTPRoute %s/%s: %v", state.Namespace, state.RouteName, err), err)
+	}
+
+	log.Info().Msgf("Modified %d rule(s) of HTTPRoute %s/%s", len(state.OriginalValues), state.Namespace, state.RouteName)
+	return &action_kit_api.StartResult{
+		Messages: new([]action_kit_api.Message{
+			{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("Modified %d rule(s) of HTTPRoute %s/%s", len(state.OriginalValues), state.Namespace, state.RouteName),
+			},
+		}),
+	}, nil
+}
+
+func (a *ruleAction) Stop(ctx context.Context, state *ActionState) (*action_kit_api.StopResult, error) {
+	err := a.k8s.UpdateHTTPRoute(ctx, state.Namespace, state.RouteName, func(route *unstructured.Unstructured) (bool, error) {
+		return a.restore(route, state)
+	})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore HTTPRoute %s/%s: %v", state.Namespace, state.RouteName, err), err)
+	}
+
+	log.Info().Msgf("Restored HTTPRoute %s/%s", state.Namespace, state.RouteName)
+	return nil, nil
+}
+
+// apply modifies the selected rules of the route, records their original values in the state and marks the route
+// with the execution id. It fails if another attack is already running on the route.
+func (a *ruleAction) apply(route *unstructured.Unstructured, state *ActionState) (bool, error) {
+	switch executionId := route.GetAnnotations()[executionAnnotationKey]; executionId {
+	case "":
+	case state.ExecutionId:
+		return false, nil
+	default:
+		return false, fmt.Errorf("another attack is already running on HTTPRoute %s/%s (execution %s). Wait for it to finish or target a different route",
+			state.Namespace, state.RouteName, executionId)
+	}
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	// The original values are restored by rule index, so the rules must not change while the attack is running.
+	for _, rule := range rules {
+		if ruleMap, ok := rule.(map[string]any); ok {
+			if name, _ := ruleMap["name"].(string); strings.HasPrefix(name, injectedRuleNamePrefix) {
+				return false, fmt.Errorf("another attack is already running on HTTPRoute %s/%s (rule %s). Wait for it to finish or target a different route",
+					state.Namespace, state.RouteName, name)
+			}
+		}
+	}
+	originalValues := map[string]any{}
+	sectionFound := false
+	for i, rule := range rules {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			continue
+		}
+		if state.SectionName != "" {
+			if ruleMap["name"] != state.SectionName {
+				continue
+			}
+			sectionFound = true
+		}
+		value := ruleMap[a.ruleField]
+		modified, ok, err := a.modifyFn(state, value)
+		if err != nil {
+			return false, fmt.Errorf("rule %d of HTTPRoute %s/%s: %w", i, state.Namespace, state.RouteName, err)
+		}
+		if !ok {
+			continue
+		}
+		originalValues[strconv.Itoa(i)] = runtime.DeepCopyJSONValue(value)
+		ruleMap[a.ruleField] = modified
+	}
+	if state.SectionName != "" && !sectionFound {
+		return false, fmt.Errorf("HTTPRoute %s/%s has no rule named %q", state.Namespace, state.RouteName, state.SectionName)
+	}
+	if len(originalValues) == 0 {
+		return false, fmt.Errorf("HTTPRoute %s/%s has no rule this attack can be applied to", state.Namespace, state.RouteName)
+	}
+
+	if err := unstructured.SetNestedSlice(route.Object, rules, "spec", "rules"); err != nil {
+		return false, err
+	}
+	annotations := route.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[executionAnnotationKey] = state.ExecutionId
+	route.SetAnnotations(annotations)
+	state.OriginalValues = originalValues
+	return true, nil
+}
+
+// restore writes the original values back into the rules and removes the execution mark. A route which is not marked
+// with the execution id is left untouched.
+func (a *ruleAction) restore(route *unstructured.Unstructured, state *ActionState) (bool, error) {
+	annotations := route.GetAnnotations()
+	if annotations[executionAnnotationKey] != state.ExecutionId {
+		return false, nil
+	}
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for index, value := range state.OriginalValues {
+		i, err := strconv.Atoi(index)
+		if err != nil || i >= len(rules) {
+			continue
+		}
+		ruleMap, ok := rules[i].(map[string]any)
+		if !ok {
+			continue
+		}
+		if value == nil {
+			delete(ruleMap, a.ruleField)
+		} else {
+			ruleMap[a.ruleField] = value
+		}
+	}
+	if err := unstructured.SetNestedSlice(route.Object, rules, "spec", "rules"); err != nil {
+		return false, err
+	}
+	delete(annotations, executionAnnotationKey)
+	route.SetAnnotations(annotations)
+	return true, nil
+}
+
+// getCommonActionDescription returns the base action description with the duration parameter and the HTTPRoute
+// target selection. Call withSectionNameParameter after adding the attack's own parameters.
+func getCommonActionDescription(id, label, description string) action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          id,
+		Label:       label,
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Description: description,
+		Technology:  new("Kubernetes"),
+		Icon:        new(GatewayApiIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType: GatewayApiHttpRouteTargetType,
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "HTTP route",
+					Description: new("Find HTTP route by cluster, namespace and route name"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.gateway-api.http-route=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new("The duration of the attack. The HTTP route will be affected for the specified duration."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Required:     new(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+// withSectionNameParameter appends the advanced sectionName parameter. Call it after the attack's own parameters so
+// the advanced parameter renders last.
+func withSectionNameParameter(desc action_kit_api.ActionDescription) action_kit_api.ActionDescription {
+	desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
+		Name:        "sectionName",
+		Label:       "Route Rule Name",
+		Description: new("Optional: restrict the attack to a single named route rule (spec.rules[].name) instead of the whole route."),
+		Type:        action_kit_api.ActionParameterTypeString,
+		Required:    new(false),
+		Advanced:    new(true),
+	})
+	return desc
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extgatewayapi
+
+import (
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+)
+
+const (
+	requestHeaderModifier  = "RequestHeaderModifier"
+	responseHeaderModifier = "ResponseHeaderModifier"
+)
+
+func NewHeaderModifierAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &ruleAction{
+		k8s:           k8s,
+		description:   getHeaderModifierDescription(),
+		ruleField:     "filters",
+		parseConfigFn: parseHeaderModifierConfig,
+		modifyFn:      headerModifierFilters,
+	}
+}
+
+func getHeaderModifierDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		HeaderModifierActionId,
+		"HTTP Route Modify Headers",
+		"Set or remove request or response headers on a Gateway API HTTP route.",
+	)
+	desc.Parameters = append(desc.Parameters,
+		action_kit_api.ActionParameter{
+			Name:         "direction",
+			Label:        "Direction",
+			Description:  new("Whether the headers of the requests sent to the backends or of the responses sent to the clients are modified."),
+			Type:         action_kit_api.ActionParameterTypeString,
+			DefaultValue: new("request"),
+			Required:     new(true),
+			Options: new([]action_kit_api.ParameterOption{
+				action_kit_api.ExplicitParameterOption{Label: "Request", Value: "request"},
+				action_kit_api.ExplicitParameterOption{Label: "Response", Value: "response"},
+			}),
+		},
+		action_kit_api.ActionParameter{
+			Name:        "setHeaders",
+			Label:       "Set Headers",
+			Description: new("Headers to set, overwriting existing values."),
+			Type:        action_kit_api.ActionParameterTypeKeyValue,
+			Required:    new(false),
+		},
+		action_kit_api.ActionParameter{
+			Name:        "removeHeaders",
+			Label:       "Remove Headers",
+			Description: new("Names of the headers to remove."),
+			Type:        action_kit_api.ActionParameterTypeStringArray,
+			Required:    new(false),
+		},
+	)
+	return withSectionNameParameter(desc)
+}
+
+func parseHeaderModifierConfig(state *ActionState, config map[string]any) error {
+	switch direction := extutil.ToString(config["direction"]); direction {
+	case "", "request":
+		state.FilterType = requestHeaderModifier
+	case "response":
+		state.FilterType = responseHeaderModifier
+	default:
+		return fmt.Errorf("unknown direction %q", direction)
+	}
+
+	if config["setHeaders"] != nil {
+		setHeaders, err := extutil.ToKeyValue(config, "setHeaders")
+		if err != nil {
+			return fmt.Errorf("failed to parse headers to set: %w", err)
+		}
+		state.SetHeaders = setHeaders
+	}
+	state.RemoveHeaders = extutil.ToStringArray(config["removeHeaders"])
+
+	if len(state.SetHeaders) == 0 && len(state.RemoveHeaders) == 0 {
+		return fmt.Errorf("at least one header to set or remove is required")
+	}
+	return nil
+}
+
+func headerModifierFilters(state *ActionState, value any) (any, bool, error) {
+	filters, _ := value.([]any)
+	modified, err := withHeaderModifier(filters, state.FilterType, state.SetHeaders, state.RemoveHeaders)
+	return modified, err == nil, err
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extgatewayapi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/steadybit/extension-kit/extutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// GatewayApiHttpRouteTargetType is the discovery target type for HTTPRoutes of any Gateway API implementation.
+	GatewayApiHttpRouteTargetType = "com.steadybit.extension_kubernetes.gateway-api-http-route"
+
+	// attrHttpRoute is the discovery attribute holding the HTTPRoute name.
+	attrHttpRoute = "k8s.gateway-api.http-route"
+
+	BlackholeActionId      = "com.steadybit.extension_kubernetes.gateway-api-http-route-blackhole"
+	HeaderModifierActionId = "com.steadybit.extension_kubernetes.gateway-api-http-route-header-modifier"
+
+	// executionAnnotationKey marks an HTTPRoute modified by an attack. A route carrying it is already under attack.
+	executionAnnotationKey = "steadybit.com/execution-id"
+
+	// injectedRuleNamePrefix marks the temporary HTTPRoute rules injected by other attacks, e.g. the Envoy Gateway
+	// attacks with request conditions.
+	injectedRuleNamePrefix = "steadybit-"
+
+	// maxBackendWeight is the maximum weight of a backendRef allowed by the Gateway API.
+	maxBackendWeight = 1000000
+
+	// GatewayApiIcon is a monochrome route icon (currentColor).
+	GatewayApiIcon = "data:image/svg+xml,%3Csvg%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M5.25%203A2.25%202.25%200%201%200%205.25%207.5A2.25%202.25%200%201%200%205.25%203ZM6%209V15A2.25%202.25%200%201%201%204.5%2015V9H6ZM18.75%203A2.25%202.25%200%201%200%2018.75%207.5A2.25%202.25%200%201%200%2018.75%203ZM18%209V11.25C18%2012.49%2016.99%2013.5%2015.75%2013.5H8.25V12H15.75C16.16%2012%2016.5%2011.66%2016.5%2011.25V9H18ZM5.25%2016.5A2.25%202.25%200%201%200%205.25%2021A2.25%202.25%200%201%200%205.25%2016.5Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3C%2Fsvg%3E"
+)
+
+// withBlackholeBackend adds a backendRef to the non-existent Service blackholeService, receiving percentage of the
+// traffic of the rule. The Gateway API requires implementations to answer requests routed to an unresolvable backend
+// with a 500 status code, so this is portable across implementations. The weights of the existing backendRefs
+// (default 1) are scaled to keep their share of the remaining traffic. Returns false if the rule has no backends.
+func withBlackholeBackend(backendRefs []any, blackholeService string, percentage int) ([]any, bool, error) {
+	var total int64
+	weights := make([]int64, len(backendRefs))
+	for i, ref := range backendRefs {
+		refMap, ok := ref.(map[string]any)
+		if !ok {
+			return nil, false, fmt.Errorf("unexpected backendRef %v", ref)
+		}
+		weights[i] = 1
+		if weight, found, err := unstructured.NestedFieldNoCopy(refMap, "weight"); err == nil && found {
+			weights[i] = extutil.ToInt64(weight)
+		}
+		total += weights[i]
+	}
+	if total == 0 {
+		return nil, false, nil
+	}
+
+	blackholeWeight := total * int64(percentage)
+	for i := range weights {
+		weights[i] *= int64(100 - percentage)
+	}
+	divisor := gcd(blackholeWeight, weights...)
+	blackholeWeight /= divisor
+	if blackholeWeight > maxBackendWeight || slices.Max(weights)/divisor > maxBackendWeight {
+		return nil, false, fmt.Errorf("the backend weights cannot be scaled within the maximum weight of %d", maxBackendWeight)
+	}
+
+	result := make([]any, 0, len(backendRefs)+1)
+	for i, ref := range backendRefs {
+		refMap := runtime.DeepCopyJSONValue(ref).(map[string]any)
+		refMap["weight"] = weights[i] / divisor
+		result = append(result, refMap)
+	}
+	result = append(result, map[string]any{
+		"name":   blackholeService,
+		"port":   int64(80),
+		"weight": blackholeWeight,
+	})
+	return result, true, nil
+}
+
+// headerModifierFilterField returns the field holding the configuration of a header modifier filter, e.g.
+// requestHeaderModifier for the RequestHeaderModifier filter.
+func headerModifierFilterField(filterType string) string {
+	return strings.ToLower(filterType[:1]) + filterType[1:]
+}
+
+// withHeaderModifier merges the set and remove header modifications into the filter of the given type, adding the
+// filter if the rule does not have one yet. The Gateway API allows only one filter of each type per rule, so
+// modifications of an existing filter are merged: a set header replaces one of the same name.
+func withHeaderModifier(filters []any, filterType string, set map[string]string, remove []string) ([]any, error) {
+	field := headerModifierFilterField(filterType)
+	result, _ := runtime.DeepCopyJSONValue(filters).([]any)
+
+	var modifier map[string]any
+	for _, filter := range result {
+		filterMap, ok := filter.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected filter %v", filter)
+		}
+		if filterMap["type"] == filterType {
+			modifier, _ = filterMap[field].(map[string]any)
+			if modifier == nil {
+				modifier = map[string]any{}
+				filterMap[field] = modifier
+			}
+			break
+		}
+	}
+	if modifier == nil {
+		modifier = map[string]any{}
+		result = append(result, map[string]any{"type": filterType, field: modifier})
+	}
+
+	if len(set) > 0 {
+		names := slices.Sorted(maps.Keys(set))
+		existing, _ := modifier["set"].([]any)
+		merged := slices.DeleteFunc(existing, func(header any) bool {
+			headerMap, _ := header.(map[string]any)
+			return containsFold(names, extutil.ToString(headerMap["name"]))
+		})
+		for _, name := range names {
+			merged = append(merged, map[string]any{"name": name, "value": set[name]})
+		}
+		modifier["set"] = merged
+	}
+	if len(remove) > 0 {
+		existing, _ := modifier["remove"].([]any)
+		for _, name := range remove {
+			if !slices.ContainsFunc(existing, func(header any) bool { return strings.EqualFold(extutil.ToString(header), name) }) {
+				existing = append(existing, name)
+			}
+		}
+		modifier["remove"] = existing
+	}
+	return result, nil
+}
+
+// gatewayRef identifies a Gateway referenced by an HTTPRoute parentRef.
+type gatewayRef struct {
+	namespace string
+	name      string
+}
+
+// parseGatewayParentRef extracts a Gateway reference from an HTTPRoute parentRef entry, applying Gateway API
+// defaulting: kind defaults to Gateway (non-Gateway kinds are skipped) and namespace defaults to the route's namespace.
+func parseGatewayParentRef(ref any, routeNamespace string) (gatewayRef, bool) {
+	refMap, ok := ref.(map[string]any)
+	if !ok {
+		return gatewayRef{}, false
+	}
+	if kind, ok := refMap["kind"].(string); ok && kind != "" && kind != "Gateway" {
+		return gatewayRef{}, false
+	}
+	name, ok := refMap["name"].(string)
+	if !ok || name == "" {
+		return gatewayRef{}, false
+	}
+	namespace, _ := refMap["namespace"].(string)
+	if namespace == "" {
+		namespace = routeNamespace
+	}
+	return gatewayRef{namespace: namespace, name: name}, true
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
+
+func gcd(a int64, values ...int64) int64 {
+	for _, b := range values {
+		for b != 0 {
+			a, b = b, a%b
+		}
+	}
+	if a == 0 {
+		return 1
+	}
+	return a
+}
+
+func objectMetaFromUnstructured(obj *unstructured.Unstructured) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		Annotations: obj.GetAnnotations(),
+		Labels:      obj.GetLabels(),
+	}
+}
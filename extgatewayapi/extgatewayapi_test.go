@@ -0,0 +1,357 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extgatewayapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_withBlackholeBackend(t *testing.T) {
+	refs := []any{
+		map[string]any{"name": "a", "port": int64(8080), "weight": int64(3)},
+		map[string]any{"name": "b", "port": int64(8080)},
+	}
+
+	tests := []struct {
+		name       string
+		refs       []any
+		percentage int
+		want       []any
+		wantOk     bool
+		wantErr    string
+	}{
+		{
+			name:       "share of the traffic",
+			refs:       refs,
+			percentage: 50,
+			want: []any{
+				map[string]any{"name": "a", "port": int64(8080), "weight": int64(3)},
+				map[string]any{"name": "b", "port": int64(8080), "weight": int64(1)},
+				map[string]any{"name": "blackhole", "port": int64(80), "weight": int64(4)},
+			},
+			wantOk: true,
+		},
+		{
+			name:       "all traffic",
+			refs:       refs,
+			percentage: 100,
+			want: []any{
+				map[string]any{"name": "a", "port": int64(8080), "weight": int64(0)},
+				map[string]any{"name": "b", "port": int64(8080), "weight": int64(0)},
+				map[string]any{"name": "blackhole", "port": int64(80), "weight": int64(1)},
+			},
+			wantOk: true,
+		},
+		{
+			name:       "rule without traffic",
+			refs:       []any{map[string]any{"name": "a", "weight": int64(0)}},
+			percentage: 50,
+		},
+		{
+			name:       "rule without backends",
+			percentage: 50,
+		},
+		{
+			name:       "weights beyond the maximum",
+			refs:       []any{map[string]any{"name": "a", "weight": int64(999999)}, map[string]any{"name": "b", "weight": int64(999998)}},
+			percentage: 1,
+			wantErr:    "maximum weight",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok, err := withBlackholeBackend(tt.refs, "blackhole", tt.percentage)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOk, ok)
+			if tt.wantOk {
+				assert.Equal(t, tt.want, result)
+			}
+		})
+	}
+	assert.NotContains(t, refs[1], "weight", "the original backendRefs must not be modified")
+}
+
+func Test_withHeaderModifier(t *testing.T) {
+	result, err := withHeaderModifier(nil, requestHeaderModifier, map[string]string{"X-Test": "1"}, []string{"Authorization"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{
+			"type": requestHeaderModifier,
+			"requestHeaderModifier": map[string]any{
+				"set":    []any{map[string]any{"name": "X-Test", "value": "1"}},
+				"remove": []any{"Authorization"},
+			},
+		},
+	}, result)
+
+	existing := []any{
+		map[string]any{"type": "URLRewrite", "urlRewrite": map[string]any{"hostname": "example.com"}},
+		map[string]any{
+			"type": requestHeaderModifier,
+			"requestHeaderModifier": map[string]any{
+				"set":    []any{map[string]any{"name": "x-test", "value": "0"}, map[string]any{"name": "X-Other", "value": "1"}},
+				"remove": []any{"authorization"},
+			},
+		},
+	}
+	result, err = withHeaderModifier(existing, requestHeaderModifier, map[string]string{"X-Test": "1"}, []string{"Authorization", "Cookie"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"type": "URLRewrite", "urlRewrite": map[string]any{"hostname": "example.com"}},
+		map[string]any{
+			"type": requestHeaderModifier,
+			"requestHeaderModifier": map[string]any{
+				"set":    []any{map[string]any{"name": "X-Other", "value": "1"}, map[string]any{"name": "X-Test", "value": "1"}},
+				"remove": []any{"authorization", "Cookie"},
+			},
+		},
+	}, result)
+	assert.Len(t, existing[1].(map[string]any)["requestHeaderModifier"].(map[string]any)["set"], 2, "the original filters must not be modified")
+}
+
+func Test_parseHeaderModifierConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    ActionState
+		wantErr string
+	}{
+		{
+			name: "response headers",
+			config: map[string]any{
+				"direction":  "response",
+				"setHeaders": []any{map[string]any{"key": "X-Test", "value": "1"}},
+			},
+			want: ActionState{FilterType: responseHeaderModifier, SetHeaders: map[string]string{"X-Test": "1"}},
+		},
+		{
+			name:    "no headers",
+			config:  map[string]any{"direction": "request"},
+			wantErr: "at least one header",
+		},
+		{
+			name:    "unknown direction",
+			config:  map[string]any{"direction": "sideways", "removeHeaders": []any{"X-Test"}},
+			wantErr: "unknown direction",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := ActionState{}
+			err := parseHeaderModifierConfig(&state, tt.config)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, state)
+		})
+	}
+}
+
+func getTestClient(stopCh <-chan struct{}) (*client.Client, dynamic.Interface) {
+	extconfig.Config.DiscoveryDisabledGatewayApi = false
+	extconfig.Config.ClusterName = "test-cluster"
+
+	dynamicClient := testutil.NewFakeDynamicClient()
+	k8sClient := client.CreateClient(testclient.NewSimpleClientset(), stopCh, "", client.MockAllPermitted(), dynamicClient)
+	k8sClient.Distribution = "kubernetes"
+	return k8sClient, dynamicClient
+}
+
+func create(t *testing.T, dc dynamic.Interface, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) {
+	t.Helper()
+	_, err := dc.Resource(gvr).Namespace(obj.GetNamespace()).Create(context.Background(), obj, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func httpRoute(namespace, name string, rules ...any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1", "kind": "HTTPRoute",
+		"metadata": map[string]any{"name": name, "namespace": namespace},
+		"spec": map[string]any{
+			"hostnames":  []any{"shop.example.com"},
+			"parentRefs": []any{map[string]any{"name": "public", "namespace": "infra"}, map[string]any{"name": "internal"}},
+			"rules":      rules,
+		},
+	}}
+}
+
+func createGateways(t *testing.T, dc dynamic.Interface) {
+	create(t, dc, client.GatewayClassGVR, &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1", "kind": "GatewayClass",
+		"metadata": map[string]any{"name": "istio"},
+		"spec":     map[string]any{"controllerName": "istio.io/gateway-controller"},
+	}})
+	create(t, dc, client.GatewayGVR, &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1", "kind": "Gateway",
+		"metadata": map[string]any{"name": "public", "namespace": "infra"},
+		"spec":     map[string]any{"gatewayClassName": "istio"},
+	}})
+}
+
+func Test_httpRouteDiscovery(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+	createGateways(t, dc)
+	create(t, dc, client.HTTPRouteGVR, httpRoute("shop", "checkout",
+		map[string]any{"name": "api", "backendRefs": []any{map[string]any{"name": "checkout", "port": int64(8080)}}},
+		map[string]any{"backendRefs": []any{map[string]any{"name": "checkout-ui", "port": int64(8080)}}},
+	))
+
+	discovery := &httpRouteDiscovery{k8s: k8sClient, backends: extcommon.NewBackendResolver(k8sClient)}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		targets, err := discovery.DiscoverTargets(context.Background())
+		assert.NoError(c, err)
+		require.Len(c, targets, 1)
+
+		target := targets[0]
+		assert.Equal(c, GatewayApiHttpRouteTargetType, target.TargetType)
+		assert.Equal(c, "test-cluster/shop/checkout", target.Id)
+		assert.Equal(c, []string{"checkout"}, target.Attributes[attrHttpRoute])
+		assert.Equal(c, []string{"shop.example.com"}, target.Attributes["k8s.gateway-api.http-route.hostname"])
+		assert.Equal(c, []string{"public", "internal"}, target.Attributes["k8s.gateway-api.gateway"])
+		assert.Equal(c, []string{"infra", "shop"}, target.Attributes["k8s.gateway-api.gateway.namespace"])
+		assert.Equal(c, []string{"istio"}, target.Attributes["k8s.gateway-api.gatewayclass"])
+		assert.Equal(c, []string{"istio.io/gateway-controller"}, target.Attributes["k8s.gateway-api.controller"])
+		assert.Equal(c, []string{"api"}, target.Attributes["k8s.gateway-api.http-route.rule"])
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func newRequest(executionId uuid.UUID, config map[string]any) action_kit_api.PrepareActionRequestBody {
+	config["duration"] = float64(30000)
+	return action_kit_api.PrepareActionRequestBody{
+		ExecutionId: executionId,
+		Config:      config,
+		Target: new(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":              {"shop"},
+				"k8s.gateway-api.http-route": {"checkout"},
+			},
+		}),
+	}
+}
+
+func rules(t *testing.T, k8sClient *client.Client) []any {
+	t.Helper()
+	route, err := k8sClient.GetHTTPRoute(context.Background(), "shop", "checkout")
+	require.NoError(t, err)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	return rules
+}
+
+func Test_blackholeAction_lifecycle(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+	original := httpRoute("shop", "checkout",
+		map[string]any{"name": "api", "backendRefs": []any{map[string]any{"name": "checkout", "port": int64(8080)}}},
+		map[string]any{"name": "ui", "backendRefs": []any{map[string]any{"name": "checkout-ui", "port": int64(8080)}}},
+	)
+	create(t, dc, client.HTTPRouteGVR, original)
+
+	action := NewBlackholeAction(k8sClient).(*ruleAction)
+	executionId := uuid.New()
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, newRequest(executionId, map[string]any{"percentage": float64(25), "sectionName": "api"}))
+	require.NoError(t, err)
+	assert.Nil(t, state.OriginalValues)
+
+	_, err = action.Start(context.Background(), &state)
+	require.NoError(t, err)
+
+	route, err := k8sClient.GetHTTPRoute(context.Background(), "shop", "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, executionId.String(), route.GetAnnotations()[executionAnnotationKey])
+	attacked := rules(t, k8sClient)
+	assert.Equal(t, []any{
+		map[string]any{"name": "checkout", "port": int64(8080), "weight": int64(3)},
+		map[string]any{"name": blackholeServiceName(executionId.String()), "port": int64(80), "weight": int64(1)},
+	}, attacked[0].(map[string]any)["backendRefs"])
+	assert.Equal(t, original.Object["spec"].(map[string]any)["rules"].([]any)[1], attacked[1], "rules not selected must not be modified")
+
+	// A second attack on the same HTTPRoute is rejected
+	other := action.NewEmptyState()
+	_, err = action.Prepare(context.Background(), &other, newRequest(uuid.New(), map[string]any{"percentage": float64(50)}))
+	assert.ErrorContains(t, err, "another attack is already running")
+
+	_, err = action.Stop(context.Background(), &state)
+	require.NoError(t, err)
+
+	route, err = k8sClient.GetHTTPRoute(context.Background(), "shop", "checkout")
+	require.NoError(t, err)
+	assert.NotContains(t, route.GetAnnotations(), executionAnnotationKey)
+	assert.Equal(t, original.Object["spec"].(map[string]any)["rules"], rules(t, k8sClient))
+}
+
+func Test_headerModifierAction_lifecycle(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+	original := httpRoute("shop", "checkout",
+		map[string]any{"backendRefs": []any{map[string]any{"name": "checkout", "port": int64(8080)}}},
+	)
+	create(t, dc, client.HTTPRouteGVR, original)
+
+	action := NewHeaderModifierAction(k8sClient).(*ruleAction)
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, newRequest(uuid.New(), map[string]any{
+		"direction":     "response",
+		"removeHeaders": []any{"Cache-Control"},
+	}))
+	require.NoError(t, err)
+
+	_, err = action.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{
+			"type":                   responseHeaderModifier,
+			"responseHeaderModifier": map[string]any{"remove": []any{"Cache-Control"}},
+		},
+	}, rules(t, k8sClient)[0].(map[string]any)["filters"])
+
+	_, err = action.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, original.Object["spec"].(map[string]any)["rules"], rules(t, k8sClient))
+}
+
+func Test_action_prepare_failsOnUnknownSection(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+	create(t, dc, client.HTTPRouteGVR, httpRoute("shop", "checkout",
+		map[string]any{"name": "api", "backendRefs": []any{map[string]any{"name": "checkout", "port": int64(8080)}}},
+	))
+
+	action := NewBlackholeAction(k8sClient).(*ruleAction)
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, newRequest(uuid.New(), map[string]any{"percentage": float64(50), "sectionName": "web"}))
+	assert.ErrorContains(t, err, `has no rule named "web"`)
+
+	route, err := k8sClient.GetHTTPRoute(context.Background(), "shop", "checkout")
+	require.NoError(t, err)
+	assert.Empty(t, route.GetAnnotations(), "prepare must not modify the HTTPRoute")
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extgatewayapi
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type httpRouteDiscovery struct {
+	k8s      *client.Client
+	targets  *extcommon.ResultCache[discovery_kit_api.Target]
+	backends *extcommon.BackendResolver
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*httpRouteDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*httpRouteDiscovery)(nil)
+)
+
+func NewHttpRouteDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &httpRouteDiscovery{
+		k8s:      k8s,
+		targets:  extcommon.NewResultCache[discovery_kit_api.Target](),
+		backends: extcommon.NewBackendResolver(k8s),
+	}
+	chRefresh := extcommon.TriggerOnKubernetesSpecChange(k8s,
+		reflect.TypeFor[unstructured.Unstructured](),
+		reflect.TypeFor[corev1.Service](),
+		reflect.TypeFor[appsv1.Deployment](),
+		reflect.TypeFor[appsv1.StatefulSet](),
+	)
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, time.Duration(extconfig.Config.DiscoveryRefreshThrottle)*time.Second),
+	)
+}
+
+func (d *httpRouteDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: GatewayApiHttpRouteTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("30s"),
+		},
+	}
+}
+
+func (d *httpRouteDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       GatewayApiHttpRouteTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "HTTP Route", Other: "HTTP Routes"},
+		Category: new("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(GatewayApiIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: attrHttpRoute},
+				{Attribute: "k8s.gateway-api.http-route.hostname"},
+				{Attribute: "k8s.gateway-api.gatewayclass"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{Attribute: attrHttpRoute, Direction: "ASC"},
+			},
+		},
+	}
+}
+
+func (d *httpRouteDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	defer d.targets.EndRun()
+	defer d.backends.EndRun()
+
+	gateways := d.k8s.Gateways()
+	gatewayClasses := d.k8s.GatewayClasses()
+	gatewayToClass := map[gatewayRef]string{}
+	for _, gw := range gateways {
+		if className, found, err := unstructured.NestedString(gw.Object, "spec", "gatewayClassName"); err == nil && found {
+			gatewayToClass[gatewayRef{namespace: gw.GetNamespace(), name: gw.GetName()}] = className
+		}
+	}
+	classToController := map[string]string{}
+	for _, gc := range gatewayClasses {
+		if controllerName, found, err := unstructured.NestedString(gc.Object, "spec", "controllerName"); err == nil && found {
+			classToController[gc.GetName()] = controllerName
+		}
+	}
+	gatewaysFingerprint := extcommon.Fingerprints(extcommon.Fingerprint(gateways...), extcommon.Fingerprint(gatewayClasses...))
+
+	var targets []discovery_kit_api.Target
+	for _, route := range d.k8s.HTTPRoutes() {
+		if client.IsExcludedFromDiscovery(objectMetaFromUnstructured(route)) {
+			continue
+		}
+		backendAttributes, backendsFingerprint := d.backends.Resolve(route.GetNamespace(), extcommon.HTTPRouteBackends(route))
+		fingerprint := extcommon.Fingerprints(extcommon.Fingerprint(route), gatewaysFingerprint, backendsFingerprint, extcommon.NamespaceFingerprint(d.k8s, route.GetNamespace()))
+		targets = append(targets, d.targets.Get(string(route.GetUID()), fingerprint, func() discovery_kit_api.Target {
+			return d.toTarget(route, gatewayToClass, classToController, backendAttributes)
+		}))
+	}
+	return targets, nil
+}
+
+func (d *httpRouteDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return extcommon.GetRouteToWorkloadEnrichmentRules(GatewayApiHttpRouteTargetType)
+}
+
+func (d *httpRouteDiscovery) toTarget(route *unstructured.Unstructured, gatewayToClass map[gatewayRef]string, classToController map[string]string, backendAttributes map[string][]string) discovery_kit_api.Target {
+	namespace := route.GetNamespace()
+	name := route.GetName()
+
+	attributes := map[string][]string{
+		"k8s.namespace":    {namespace},
+		"k8s.cluster-name": {extconfig.Config.ClusterName},
+		"k8s.distribution": {d.k8s.Distribution},
+		attrHttpRoute:      {name},
+	}
+
+	if hostnames, found, err := unstructured.NestedStringSlice(route.Object, "spec", "hostnames"); err == nil && found && len(hostnames) > 0 {
+		attributes["k8s.gateway-api.http-route.hostname"] = extcommon.SortDedup(hostnames)
+	}
+
+	// Gateways which are not (yet) known are still listed, their class and controller are unknown though. The
+	// (namespace, name) pairs are sorted together to keep the gateway and gateway.namespace attributes aligned.
+	var gateways []gatewayRef
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	for _, ref := range parentRefs {
+		if gw, ok := parseGatewayParentRef(ref, namespace); ok {
+			gateways = append(gateways, gw)
+		}
+	}
+	slices.SortFunc(gateways, func(a, b gatewayRef) int {
+		return cmp.Or(strings.Compare(a.namespace, b.namespace), strings.Compare(a.name, b.name))
+	})
+	gateways = slices.Compact(gateways)
+
+	var gatewayNames, gatewayNamespaces, classes, controllers []string
+	for _, gw := range gateways {
+		gatewayNames = append(gatewayNames, gw.name)
+		gatewayNamespaces = append(gatewayNamespaces, gw.namespace)
+		if className, ok := gatewayToClass[gw]; ok {
+			classes = append(classes, className)
+			if controller, ok := classToController[className]; ok {
+				controllers = append(controllers, controller)
+			}
+		}
+	}
+	if len(gateways) > 0 {
+		attributes["k8s.gateway-api.gateway"] = gatewayNames
+		attributes["k8s.gateway-api.gateway.namespace"] = gatewayNamespaces
+	}
+	if len(classes) > 0 {
+		attributes["k8s.gateway-api.gatewayclass"] = extcommon.SortDedup(classes)
+	}
+	if len(controllers) > 0 {
+		attributes["k8s.gateway-api.controller"] = extcommon.SortDedup(controllers)
+	}
+
+	var ruleNames []string
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for _, rule := range rules {
+		if ruleMap, ok := rule.(map[string]any); ok {
+			if ruleName, ok := ruleMap["name"].(string); ok && ruleName != "" && !strings.HasPrefix(ruleName, injectedRuleNamePrefix) {
+				ruleNames = append(ruleNames, ruleName)
+			}
+		}
+	}
+	if len(ruleNames) > 0 {
+		attributes["k8s.gateway-api.http-route.rule"] = extcommon.SortDedup(ruleNames)
+	}
+
+	extcommon.MergeAttributes(attributes, backendAttributes)
+	extcommon.AddLabels(attributes, route.GetLabels(), "k8s.gateway-api.http-route.label", "k8s.label")
+	extcommon.AddNamespaceLabels(attributes, d.k8s, namespace)
+
+	target := discovery_kit_api.Target{
+		Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, namespace, name),
+		TargetType: GatewayApiHttpRouteTargetType,
+		Label:      name,
+		Attributes: attributes,
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes([]discovery_kit_api.Target{target}, extconfig.Config.DiscoveryAttributesExcludesGatewayApi)[0]
+}
@@ -4,23 +4,30 @@
 package extingress
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"strings"
 	"unicode"
 
+	"github.com/rs/zerolog/log"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 )
 
-type RequestMatcher struct {
-	PathPattern string
-	HttpMethod  string
-	HttpHeader  map[string]string
-}
+const (
+	onRevertReapply = "reapply"
+	onRevertFail    = "fail"
+)
 
-func parseRequestMatcher(config map[string]any) (RequestMatcher, error) {
-	var matcher RequestMatcher
+// parseRequestMatcher reads the conditions into the shared request matcher. Unlike extcommon.ParseRequestMatcher, the
+// path pattern is not compiled, as it is a regex of the ingress controller and not of Go, but the values are checked
+// to be safe for interpolation into a configuration snippet.
+func parseRequestMatcher(config map[string]any) (extcommon.RequestMatcher, error) {
+	var matcher extcommon.RequestMatcher
 	var err error
 
 	matcher.PathPattern = extutil.ToString(config["conditionPathPattern"])
@@ -56,6 +63,24 @@ func parseRequestMatcher(config map[string]any) (RequestMatcher, error) {
 	return matcher, nil
 }
 
+// parsePercentage reads the share of matching requests which are affected, between 1 and 100. It defaults to 100,
+// which affects all matching requests.
+func parsePercentage(config map[string]any) (int, error) {
+	if config["percentage"] == nil {
+		return 100, nil
+	}
+	percentage := extutil.ToInt(config["percentage"])
+	if percentage < 1 || percentage > 100 {
+		return 0, fmt.Errorf("traffic percentage must be between 1 and 100")
+	}
+	return percentage, nil
+}
+
+// sampled reports whether only a share of the matching requests is affected.
+func sampled(percentage int) bool {
+	return percentage > 0 && percentage < 100
+}
+
 func containsControlChar(value string) bool {
 	return strings.IndexFunc(value, unicode.IsControl) >= 0
 }
@@ -89,6 +114,90 @@ func getCommonActionDescription(targetType, id, label, description, icon string)
 				DefaultValue: new("30s"),
 				Required:     new(true),
 			},
+			{
+				Name:         "percentage",
+				Label:        "Traffic Percentage",
+				Description:  new("The percentage of matching requests the fault is applied to."),
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				DefaultValue: new("100"),
+				MinValue:     new(1),
+				MaxValue:     new(100),
+				Required:     new(true),
+			},
+			{
+				Name:         "onRevert",
+				Label:        "When Reverted",
+				Description:  new("What to do if the configuration is removed from the ingress during the attack, e.g. by a GitOps controller like Argo CD or Flux re-syncing the ingress."),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: new(onRevertReapply),
+				Required:     new(true),
+				Advanced:     new(true),
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{Label: "Re-apply the configuration", Value: onRevertReapply},
+					action_kit_api.ExplicitParameterOption{Label: "Fail the attack", Value: onRevertFail},
+				}),
+			},
 		},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+	}
+}
+
+func parseOnRevert(config map[string]any) (string, error) {
+	switch onRevert := extutil.ToString(config["onRevert"]); onRevert {
+	case "":
+		return onRevertReapply, nil
+	case onRevertReapply, onRevertFail:
+		return onRevert, nil
+	default:
+		return "", fmt.Errorf("unknown revert handling %q", onRevert)
+	}
+}
+
+// annotationBlock is the configuration an action added to an ingress annotation.
+type annotationBlock struct {
+	namespace     string
+	ingressName   string
+	annotationKey string
+	startMarker   string
+	config        string
+	onRevert      string
+}
+
+// checkAnnotationBlock verifies that the configuration is still present in the ingress annotation. GitOps
+// controllers like Argo CD or Flux revert the annotation when they re-sync the ingress, which silently ends the
+// attack. The block is then either re-applied or the attack fails, naming the field manager which reverted it.
+func checkAnnotationBlock(ctx context.Context, block annotationBlock) (*action_kit_api.StatusResult, error) {
+	if cached, err := client.K8S.IngressByNamespaceAndName(block.namespace, block.ingressName); err == nil && strings.Contains(cached.Annotations[block.annotationKey], block.startMarker) {
+		return &action_kit_api.StatusResult{}, nil
+	}
+
+	// The cache may lag behind and doesn't hold the managedFields naming the reverting controller
+	ingress, err := client.K8S.GetIngress(ctx, block.namespace, block.ingressName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ingress: %w", err)
+	}
+	if strings.Contains(ingress.Annotations[block.annotationKey], block.startMarker) {
+		return &action_kit_api.StatusResult{}, nil
+	}
+
+	reverted := fmt.Sprintf("Configuration of ingress %s/%s was reverted by controller %s.", block.namespace, block.ingressName, cmp.Or(client.AnnotationManager(ingress, block.annotationKey), "unknown"))
+	if block.onRevert == onRevertFail {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: new(action_kit_api.ActionKitError{
+				Title:  reverted,
+				Status: new(action_kit_api.Errored),
+			}),
+		}, nil
+	}
+
+	log.Info().Msgf("%s Re-applying it.", reverted)
+	if _, err = client.K8S.UpdateIngressAnnotation(ctx, block.namespace, block.ingressName, block.annotationKey, block.config); err != nil {
+		return nil, fmt.Errorf("failed to re-apply reverted configuration: %w", err)
 	}
+	return &action_kit_api.StatusResult{Messages: &[]action_kit_api.Message{
+		{Message: reverted + " Re-applied it.", Level: new(action_kit_api.Warn)},
+	}}, nil
 }
@@ -4,6 +4,8 @@
 package extingress
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,3 +58,64 @@ func TestParseRequestMatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePercentage(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]any
+		want        int
+		wantSampled bool
+		wantErr     string
+	}{
+		{
+			name:   "defaults to all requests",
+			config: map[string]any{},
+			want:   100,
+		},
+		{
+			name:        "samples a share of the requests",
+			config:      map[string]any{"percentage": 25},
+			want:        25,
+			wantSampled: true,
+		},
+		{
+			name:    "rejects a traffic percentage of 0",
+			config:  map[string]any{"percentage": 0},
+			wantErr: "traffic percentage must be between 1 and 100",
+		},
+		{
+			name:    "rejects a traffic percentage above 100",
+			config:  map[string]any{"percentage": 101},
+			wantErr: "traffic percentage must be between 1 and 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percentage, err := parsePercentage(tt.config)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, percentage)
+			assert.Equal(t, tt.wantSampled, sampled(percentage))
+		})
+	}
+}
+
+func TestRequestIdSampleRegex(t *testing.T) {
+	for _, percentage := range []int{1, 10, 25, 33, 50, 75, 99} {
+		t.Run(fmt.Sprintf("%d%%", percentage), func(t *testing.T) {
+			re := regexp.MustCompile(requestIdSampleRegex(percentage))
+
+			matched := 0
+			for prefix := range 4096 {
+				if re.MatchString(fmt.Sprintf("%03x%029x", prefix, 0)) {
+					matched++
+				}
+			}
+			assert.InDelta(t, float64(percentage), float64(matched)*100/4096, 0.05)
+		})
+	}
+}
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extingress
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The check scripts write the configuration passed as first argument to a temporary file and test it. They print the
+// exit status of the test in the last line, so a rejected configuration can be told apart from a failing exec.
+const (
+	nginxConfigCheckScript = `command -v nginx >/dev/null 2>&1 || { echo 127; exit; }
+f=$(mktemp) || { echo 126; exit; }
+{ grep -hs '^[[:space:]]*load_module' /etc/nginx/nginx.conf; printf '%s\n' "$1"; } > "$f"
+nginx -t -q -c "$f" 2>&1
+rc=$?
+rm -f "$f"
+echo "$rc"`
+	haProxyConfigCheckScript = `command -v haproxy >/dev/null 2>&1 || { echo 127; exit; }
+f=$(mktemp) || { echo 126; exit; }
+printf '%s\n' "$1" > "$f"
+haproxy -c -f "$f" 2>&1
+rc=$?
+rm -f "$f"
+echo "$rc"`
+)
+
+// configValidationTimeout limits how long the configuration check may run in the controller pods
+const configValidationTimeout = 10 * time.Second
+
+// execInPod runs a command in a controller pod. It is replaced in tests, as the fake clientset cannot exec.
+var execInPod = func(ctx context.Context, namespace, podName, containerName string, command []string) (string, error) {
+	return client.K8S.ExecInPod(ctx, namespace, podName, containerName, command)
+}
+
+// haProxyController is the controller of the IngressClasses served by the HAProxy Kubernetes Ingress Controller
+const haProxyController = "haproxy.org/ingress-controller/haproxy"
+
+// haProxyControllerLabelSelectors match the pods of the HAProxy Kubernetes Ingress Controller
+var haProxyControllerLabelSelectors = []map[string]string{
+	{"app.kubernetes.io/name": "kubernetes-ingress"}, // helm chart
+	{"run": "haproxy-ingress"},                       // plain manifests
+}
+
+// validateNginxSnippet tests the snippet with `nginx -t` in an NGINX controller pod before it is applied. The
+// controller would otherwise only reject it after the ingress was updated. If no controller pod can run the test,
+// the validation is skipped. Only the snippet of the extension is tested, as the existing snippet may use variables
+// that are defined by the controller's own configuration only, like `$proxy_upstream_name` of ingress-nginx.
+func validateNginxSnippet(ctx context.Context, targetAttributes map[string][]string, annotationKey, snippet string) error {
+	if extconfig.Config.IngressSkipConfigValidation {
+		return nil
+	}
+
+	pods, _, err := findNginxControllerPods(targetAttributes)
+	if err != nil {
+		log.Warn().Err(err).Msg("Skipping NGINX configuration validation")
+		return nil
+	}
+
+	config := renderNginxValidationConfig(annotationKey, snippet)
+	return validateConfigInPods(ctx, "NGINX", pods, nginxControllerContainerName, nginxConfigCheckScript, config)
+}
+
+// validateHAProxySnippet tests the snippet with `haproxy -c` in an HAProxy controller pod before it is applied. If no
+// controller pod can run the test, the validation is skipped.
+func validateHAProxySnippet(ctx context.Context, targetAttributes map[string][]string, snippet string) error {
+	if extconfig.Config.IngressSkipConfigValidation {
+		return nil
+	}
+
+	pods, err := findHAProxyControllerPods(targetAttributes)
+	if err != nil {
+		log.Warn().Err(err).Msg("Skipping HAProxy configuration validation")
+		return nil
+	}
+
+	config := renderHAProxyValidationConfig(snippet)
+	return validateConfigInPods(ctx, "HAProxy", pods, haProxyControllerContainerName, haProxyConfigCheckScript, config)
+}
+
+func validateConfigInPods(ctx context.Context, kind string, pods []*corev1.Pod, containerNameFn func(pod *corev1.Pod) string, script, config string) error {
+	ctx, cancel := context.WithTimeout(ctx, configValidationTimeout)
+	defer cancel()
+
+	for _, pod := range pods {
+		if ctx.Err() != nil {
+			break
+		}
+		output, err := execInPod(ctx, pod.Namespace, pod.Name, containerNameFn(pod), []string{"sh", "-c", script, "sh", config})
+		if err != nil {
+			log.Debug().Err(err).Msgf("Failed to validate %s configuration in pod %s/%s, trying next pod", kind, pod.Namespace, pod.Name)
+			continue
+		}
+
+		status, message, err := parseConfigCheckOutput(output)
+		if err != nil {
+			log.Debug().Err(err).Msgf("Failed to validate %s configuration in pod %s/%s, trying next pod", kind, pod.Namespace, pod.Name)
+			continue
+		}
+
+		switch status {
+		case 0:
+			return nil
+		case 1:
+			return fmt.Errorf("%s controller pod %s/%s rejected the configuration: %s", kind, pod.Namespace, pod.Name, message)
+		default:
+			log.Debug().Msgf("Could not validate %s configuration in pod %s/%s (exit status %d), trying next pod", kind, pod.Namespace, pod.Name, status)
+		}
+	}
+
+	log.Warn().Msgf("Skipping %s configuration validation, none of the controller pods could test the configuration", kind)
+	return nil
+}
+
+// parseConfigCheckOutput splits the output of a check script into the exit status of the test and its messages
+func parseConfigCheckOutput(output string) (int, string, error) {
+	output = strings.TrimRight(output, "\n")
+	messages, statusLine := "", output
+	if i := strings.LastIndex(output, "\n"); i >= 0 {
+		messages, statusLine = output[:i], output[i+1:]
+	}
+	status, err := strconv.Atoi(strings.TrimSpace(statusLine))
+	if err != nil {
+		return 0, "", fmt.Errorf("unexpected output of configuration check: %q", output)
+	}
+	return status, strings.TrimSpace(messages), nil
+}
+
+// renderNginxValidationConfig embeds the snippet into a minimal NGINX configuration. Configuration snippets are
+// added to a location, server snippets of the NGINX Inc. controller to a server.
+func renderNginxValidationConfig(annotationKey, snippet string) string {
+	var s strings.Builder
+	s.WriteString("error_log stderr;\n")
+	s.WriteString("events {}\n")
+	s.WriteString("http {\n")
+	s.WriteString("server {\n")
+	s.WriteString("listen 127.0.0.1:8080;\n")
+	if annotationKey == nginxEnterpriseAnnotationKey {
+		s.WriteString(snippet)
+		s.WriteString("\nlocation / {}\n")
+	} else {
+		s.WriteString("location / {\n")
+		s.WriteString(snippet)
+		s.WriteString("\n}\n")
+	}
+	s.WriteString("}\n")
+	s.WriteString("}\n")
+	return s.String()
+}
+
+// renderHAProxyValidationConfig embeds the backend config snippet into a minimal HAProxy configuration
+func renderHAProxyValidationConfig(snippet string) string {
+	var s strings.Builder
+	s.WriteString("defaults\n")
+	s.WriteString("  mode http\n")
+	s.WriteString("  timeout connect 5s\n")
+	s.WriteString("  timeout client 30s\n")
+	s.WriteString("  timeout server 30s\n")
+	s.WriteString("frontend steadybit-validation\n")
+	s.WriteString("  bind 127.0.0.1:8080\n")
+	s.WriteString("  default_backend steadybit-validation\n")
+	s.WriteString("backend steadybit-validation\n")
+	for line := range strings.Lines(snippet) {
+		s.WriteString("  " + strings.TrimRight(line, "\n") + "\n")
+	}
+	s.WriteString("  server steadybit-validation 127.0.0.1:8081\n")
+	return s.String()
+}
+
+// findHAProxyControllerPods returns the running HAProxy ingress controller pods serving the ingress class of the
+// target. The pods of a helm release are looked up in the release namespace of the IngressClass. Otherwise, only
+// pods naming the ingress class in their arguments are used.
+func findHAProxyControllerPods(targetAttributes map[string][]string) ([]*corev1.Pod, error) {
+	var ingressClassName string
+	if ingressClass, exists := targetAttributes["k8s.ingress.class"]; exists && len(ingressClass) > 0 {
+		ingressClassName = ingressClass[0]
+	}
+	if ingressClassName == "" {
+		return nil, fmt.Errorf("could not determine ingress class name to search for HAProxy controller pods")
+	}
+
+	var targetIngressClass *networkingv1.IngressClass
+	for _, ic := range client.K8S.IngressClasses() {
+		if ic.Name == ingressClassName {
+			targetIngressClass = ic
+			break
+		}
+	}
+	if targetIngressClass == nil {
+		return nil, fmt.Errorf("IngressClass %s not found", ingressClassName)
+	}
+	if targetIngressClass.Spec.Controller != haProxyController {
+		return nil, fmt.Errorf("IngressClass %s is not an HAProxy controller (controller: %s)", ingressClassName, targetIngressClass.Spec.Controller)
+	}
+
+	namespace := targetIngressClass.Annotations["meta.helm.sh/release-namespace"]
+	labelSelectors := haProxyControllerLabelSelectors
+	if releaseName := targetIngressClass.Annotations["meta.helm.sh/release-name"]; namespace != "" && releaseName != "" {
+		labelSelectors = []map[string]string{{"app.kubernetes.io/name": "kubernetes-ingress", "app.kubernetes.io/instance": releaseName}}
+	}
+
+	for _, selector := range labelSelectors {
+		var pods []*corev1.Pod
+		for _, pod := range client.K8S.PodsByLabelSelector(&metav1.LabelSelector{MatchLabels: selector}, namespace) {
+			if namespace != "" || podServesHAProxyIngressClass(pod, ingressClassName) {
+				pods = append(pods, pod)
+			}
+		}
+		if len(pods) > 0 {
+			return pods, nil
+		}
+	}
+	return nil, fmt.Errorf("no HAProxy ingress controller pods found for IngressClass %s", ingressClassName)
+}
+
+// podServesHAProxyIngressClass checks whether the ingress class is passed to the controller with --ingress.class
+func podServesHAProxyIngressClass(pod *corev1.Pod, ingressClassName string) bool {
+	for _, container := range pod.Spec.Containers {
+		for i, arg := range container.Args {
+			flag, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+			if flag != "ingress.class" {
+				continue
+			}
+			if !hasValue && i+1 < len(container.Args) {
+				value = container.Args[i+1]
+			}
+			if value == ingressClassName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// haProxyControllerContainerName returns the name of the container running HAProxy in a controller pod
+func haProxyControllerContainerName(pod *corev1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		if strings.Contains(container.Image, "haproxy") || strings.Contains(container.Name, "haproxy") {
+			return container.Name
+		}
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return ""
+	}
+	return pod.Spec.Containers[0].Name
+}
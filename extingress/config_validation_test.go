@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extingress
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestParseConfigCheckOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		wantStatus  int
+		wantMessage string
+		wantErr     bool
+	}{
+		{
+			name:       "valid configuration",
+			output:     "0\n",
+			wantStatus: 0,
+		},
+		{
+			name:        "rejected configuration",
+			output:      "nginx: [emerg] unknown directive \"sb_sleep_ms\" in /tmp/tmp.x:9\nnginx: configuration file /tmp/tmp.x test failed\n1\n",
+			wantStatus:  1,
+			wantMessage: "nginx: [emerg] unknown directive \"sb_sleep_ms\" in /tmp/tmp.x:9\nnginx: configuration file /tmp/tmp.x test failed",
+		},
+		{
+			name:       "binary not found",
+			output:     "127",
+			wantStatus: 127,
+		},
+		{
+			name:    "unexpected output",
+			output:  "sh: syntax error\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, message, err := parseConfigCheckOutput(tt.output)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
+
+func TestRenderNginxValidationConfig(t *testing.T) {
+	snippet := "return 503;\n# existing"
+
+	assert.Equal(t, "error_log stderr;\nevents {}\nhttp {\nserver {\nlisten 127.0.0.1:8080;\nlocation / {\nreturn 503;\n# existing\n}\n}\n}\n",
+		renderNginxValidationConfig(nginxAnnotationKey, snippet))
+	assert.Equal(t, "error_log stderr;\nevents {}\nhttp {\nserver {\nlisten 127.0.0.1:8080;\nreturn 503;\n# existing\nlocation / {}\n}\n}\n",
+		renderNginxValidationConfig(nginxEnterpriseAnnotationKey, snippet))
+}
+
+func TestRenderHAProxyValidationConfig(t *testing.T) {
+	config := renderHAProxyValidationConfig("acl sb_path path_reg /api\nhttp-request deny deny_status 503 if sb_path\n")
+
+	assert.Contains(t, config, "backend steadybit-validation\n  acl sb_path path_reg /api\n  http-request deny deny_status 503 if sb_path\n  server steadybit-validation 127.0.0.1:8081\n")
+}
+
+func TestValidateHAProxySnippet_SkipsIfControllerCannotBeExecuted(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	testClient, _ := newTestClient(stopCh,
+		haProxyIngressClass("haproxy", map[string]string{"meta.helm.sh/release-namespace": "haproxy-controller", "meta.helm.sh/release-name": "haproxy"}),
+		haProxyControllerPod("haproxy-controller", "haproxy-kubernetes-ingress-0", map[string]string{"app.kubernetes.io/name": "kubernetes-ingress", "app.kubernetes.io/instance": "haproxy"}),
+	)
+	client.K8S = testClient
+	attributes := map[string][]string{"k8s.ingress.class": {"haproxy"}}
+
+	require.Eventually(t, func() bool {
+		pods, err := findHAProxyControllerPods(attributes)
+		return err == nil && len(pods) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.NoError(t, validateHAProxySnippet(context.Background(), attributes, "http-request deny\n"))
+}
+
+func TestFindHAProxyControllerPods(t *testing.T) {
+	helmAnnotations := map[string]string{"meta.helm.sh/release-namespace": "haproxy-controller", "meta.helm.sh/release-name": "haproxy"}
+	tests := []struct {
+		name         string
+		ingressClass *networkingv1.IngressClass
+		attributes   map[string][]string
+		pods         []*corev1.Pod
+		wantPods     []string
+		wantErr      string
+	}{
+		{
+			name:         "pods of the helm release of the ingress class",
+			ingressClass: haProxyIngressClass("haproxy", helmAnnotations),
+			attributes:   map[string][]string{"k8s.ingress.class": {"haproxy"}},
+			pods: []*corev1.Pod{
+				haProxyControllerPod("other-team", "other-kubernetes-ingress-0", map[string]string{"app.kubernetes.io/name": "kubernetes-ingress", "app.kubernetes.io/instance": "other"}),
+				haProxyControllerPod("haproxy-controller", "haproxy-kubernetes-ingress-0", map[string]string{"app.kubernetes.io/name": "kubernetes-ingress", "app.kubernetes.io/instance": "haproxy"}),
+			},
+			wantPods: []string{"haproxy-kubernetes-ingress-0"},
+		},
+		{
+			name:         "pods naming the ingress class without helm release",
+			ingressClass: haProxyIngressClass("haproxy", nil),
+			attributes:   map[string][]string{"k8s.ingress.class": {"haproxy"}},
+			pods: []*corev1.Pod{
+				haProxyControllerPod("other-team", "haproxy-ingress-other", map[string]string{"run": "haproxy-ingress"}, "--ingress.class=other"),
+				haProxyControllerPod("haproxy-controller", "haproxy-ingress-0", map[string]string{"run": "haproxy-ingress"}, "--ingress.class", "haproxy"),
+			},
+			wantPods: []string{"haproxy-ingress-0"},
+		},
+		{
+			name:         "no pods naming the ingress class",
+			ingressClass: haProxyIngressClass("haproxy", nil),
+			attributes:   map[string][]string{"k8s.ingress.class": {"haproxy"}},
+			pods:         []*corev1.Pod{haProxyControllerPod("other-team", "haproxy-ingress-other", map[string]string{"run": "haproxy-ingress"}, "--ingress.class=other")},
+			wantErr:      "no HAProxy ingress controller pods found for IngressClass haproxy",
+		},
+		{
+			name:         "ingress class of another controller",
+			ingressClass: &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}, Spec: networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"}},
+			attributes:   map[string][]string{"k8s.ingress.class": {"nginx"}},
+			wantErr:      "IngressClass nginx is not an HAProxy controller (controller: k8s.io/ingress-nginx)",
+		},
+		{
+			name:         "missing ingress class",
+			ingressClass: haProxyIngressClass("haproxy", helmAnnotations),
+			wantErr:      "could not determine ingress class name to search for HAProxy controller pods",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			// Given
+			objects := []runtime.Object{tt.ingressClass}
+			for _, pod := range tt.pods {
+				objects = append(objects, pod)
+			}
+			testClient, _ := newTestClient(stopCh, objects...)
+			client.K8S = testClient
+			require.Eventually(t, func() bool {
+				return len(testClient.IngressClasses()) == 1 && len(testClient.Pods()) == len(tt.pods)
+			}, time.Second, 10*time.Millisecond)
+
+			// When
+			pods, err := findHAProxyControllerPods(tt.attributes)
+
+			// Then
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, tt.wantPods, names)
+		})
+	}
+}
+
+func haProxyIngressClass(name string, annotations map[string]string) *networkingv1.IngressClass {
+	return &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+		Spec:       networkingv1.IngressClassSpec{Controller: haProxyController},
+	}
+}
+
+func haProxyControllerPod(namespace, name string, labels map[string]string, args ...string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "kubernetes-ingress-controller", Image: "haproxytech/kubernetes-ingress:3.1", Args: args},
+		}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestHAProxyControllerContainerName(t *testing.T) {
+	tests := []struct {
+		name       string
+		containers []corev1.Container
+		want       string
+	}{
+		{
+			name:       "haproxy image",
+			containers: []corev1.Container{{Name: "sidecar", Image: "envoy"}, {Name: "controller", Image: "haproxytech/kubernetes-ingress:3.1"}},
+			want:       "controller",
+		},
+		{
+			name:       "first container",
+			containers: []corev1.Container{{Name: "controller", Image: "ingress:1.0"}},
+			want:       "controller",
+		},
+		{
+			name: "no containers",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, haProxyControllerContainerName(&corev1.Pod{Spec: corev1.PodSpec{Containers: tt.containers}}))
+		})
+	}
+}
+
+func TestNginxPrepare_ValidatesOnlyTheExtensionSnippet(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// Given
+	existingSnippet := "more_set_headers \"X-Upstream: $proxy_upstream_name\";\n"
+	testClient, _ := newTestClient(stopCh,
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Annotations: map[string]string{"meta.helm.sh/release-namespace": "ingress-nginx", "meta.helm.sh/release-name": "ingress-nginx"}},
+			Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress-nginx-controller-0", Namespace: "ingress-nginx", Labels: map[string]string{"app.kubernetes.io/instance": "ingress-nginx"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "controller", Args: []string{"--ingress-class=nginx"}}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		createNginxIngress("test-nginx-ingress", existingSnippet),
+	)
+	client.K8S = testClient
+	require.Eventually(t, func() bool {
+		ingress, _ := testClient.IngressByNamespaceAndName("demo", "test-nginx-ingress")
+		return ingress != nil && len(testClient.PodsByLabelSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/instance": "ingress-nginx"}}, "ingress-nginx")) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	defaultExecInPod := execInPod
+	defer func() { execInPod = defaultExecInPod }()
+	var validatedConfigs []string
+	execInPod = func(_ context.Context, _, _, _ string, command []string) (string, error) {
+		config := command[len(command)-1]
+		validatedConfigs = append(validatedConfigs, config)
+		if strings.Contains(config, "$proxy_upstream_name") {
+			return "nginx: [emerg] unknown \"proxy_upstream_name\" variable\n1\n", nil
+		}
+		return "0\n", nil
+	}
+
+	// When
+	action := NewNginxBlockTrafficAction()
+	state := action.NewEmptyState()
+	request := createNginxTestRequest("test-nginx-ingress", map[string]any{
+		"responseStatusCode":   503,
+		"conditionPathPattern": "/api/.*",
+	})
+	request.Target.Attributes["k8s.ingress.class"] = []string{"nginx"}
+	_, err := action.Prepare(context.Background(), &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, validatedConfigs, 1)
+	assert.Contains(t, validatedConfigs[0], state.AnnotationConfig)
+	assert.NotContains(t, validatedConfigs[0], existingSnippet)
+}
@@ -85,6 +85,13 @@ func buildHAProxyBlockConfig(state *HAProxyState, config map[string]any) string
 		aclRefs = append(aclRefs, aclName)
 	}
 
+	// Add percentage condition if only a share of the requests should be blocked
+	if sampled(state.Percentage) {
+		aclName := fmt.Sprintf("sb_pct_%s", aclIdPrefix)
+		aclDefinitions = append(aclDefinitions, fmt.Sprintf("acl %s rand(100) lt %d", aclName, state.Percentage))
+		aclRefs = append(aclRefs, aclName)
+	}
+
 	// Add all ACL definitions to s
 	for _, aclDef := range aclDefinitions {
 		s.WriteString(aclDef)
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -43,7 +44,7 @@ func TestHAProxyBlockTrafficAction_Prepare(t *testing.T) {
 				ExecutionId:      testUUID,
 				Namespace:        "demo",
 				IngressName:      "test-ingress",
-				Matcher:          RequestMatcher{PathPattern: "/api/*"},
+				Matcher:          extcommon.RequestMatcher{PathPattern: "/api/*"},
 				AnnotationConfig: "# BEGIN STEADYBIT - 00000000-0000-0000-0000-000000000000\nacl sb_path_00000000_0000_0000_0000_000000000000 path_reg /api/*\nhttp-request return status 503 if sb_path_00000000_0000_0000_0000_000000000000\n# END STEADYBIT - 00000000-0000-0000-0000-000000000000\n",
 			},
 		},
@@ -58,7 +59,7 @@ func TestHAProxyBlockTrafficAction_Prepare(t *testing.T) {
 				ExecutionId:      testUUID,
 				Namespace:        "demo",
 				IngressName:      "test-ingress",
-				Matcher:          RequestMatcher{HttpMethod: "POST"},
+				Matcher:          extcommon.RequestMatcher{HttpMethod: "POST"},
 				AnnotationConfig: "# BEGIN STEADYBIT - 00000000-0000-0000-0000-000000000000\nacl sb_method_00000000_0000_0000_0000_000000000000 method POST\nhttp-request return status 503 if sb_method_00000000_0000_0000_0000_000000000000\n# END STEADYBIT - 00000000-0000-0000-0000-000000000000\n",
 			},
 		},
@@ -75,7 +76,7 @@ func TestHAProxyBlockTrafficAction_Prepare(t *testing.T) {
 				ExecutionId: testUUID,
 				Namespace:   "demo",
 				IngressName: "test-ingress",
-				Matcher: RequestMatcher{HttpHeader: map[string]string{
+				Matcher: extcommon.RequestMatcher{HttpHeader: map[string]string{
 					"User-Agent": "Mozilla.*",
 				},
 				},
@@ -97,7 +98,7 @@ func TestHAProxyBlockTrafficAction_Prepare(t *testing.T) {
 				ExecutionId: testUUID,
 				Namespace:   "demo",
 				IngressName: "test-ingress",
-				Matcher: RequestMatcher{PathPattern: "/api/users",
+				Matcher: extcommon.RequestMatcher{PathPattern: "/api/users",
 					HttpMethod: "POST",
 					HttpHeader: map[string]string{
 						"Content-Type": "application/json",
@@ -106,6 +107,23 @@ func TestHAProxyBlockTrafficAction_Prepare(t *testing.T) {
 				AnnotationConfig: "# BEGIN STEADYBIT - 00000000-0000-0000-0000-000000000000\nacl sb_method_00000000_0000_0000_0000_000000000000 method POST\nacl sb_hdr_Content_Type_00000000_0000_0000_0000_000000000000 hdr(Content-Type) -m reg application/json\nacl sb_path_00000000_0000_0000_0000_000000000000 path_reg /api/users\nhttp-request return status 503 if sb_method_00000000_0000_0000_0000_000000000000 sb_hdr_Content_Type_00000000_0000_0000_0000_000000000000 sb_path_00000000_0000_0000_0000_000000000000\n# END STEADYBIT - 00000000-0000-0000-0000-000000000000\n",
 			},
 		},
+		{
+			name:        "block a percentage of the requests",
+			ingressName: "test-ingress",
+			config: map[string]any{
+				"responseStatusCode":   503,
+				"conditionPathPattern": "/api/*",
+				"percentage":           25,
+			},
+			want: HAProxyState{
+				ExecutionId:      testUUID,
+				Namespace:        "demo",
+				IngressName:      "test-ingress",
+				Matcher:          extcommon.RequestMatcher{PathPattern: "/api/*"},
+				Percentage:       25,
+				AnnotationConfig: "# BEGIN STEADYBIT - 00000000-0000-0000-0000-000000000000\nacl sb_path_00000000_0000_0000_0000_000000000000 path_reg /api/*\nacl sb_pct_00000000_0000_0000_0000_000000000000 rand(100) lt 25\nhttp-request return status 503 if sb_path_00000000_0000_0000_0000_000000000000 sb_pct_00000000_0000_0000_0000_000000000000\n# END STEADYBIT - 00000000-0000-0000-0000-000000000000\n",
+			},
+		},
 		{
 			name:        "no conditions provided",
 			ingressName: "test-ingress",
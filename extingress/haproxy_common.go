@@ -12,6 +12,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 )
 
 // Action IDs for HAProxy actions
@@ -28,8 +29,10 @@ type HAProxyState struct {
 	Namespace        string
 	IngressName      string
 	AnnotationKey    string
-	Matcher          RequestMatcher
+	Matcher          extcommon.RequestMatcher
+	Percentage       int
 	AnnotationConfig string
+	OnRevert         string
 }
 
 type haProxyAction struct {
@@ -48,7 +51,7 @@ func (a *haProxyAction) Describe() action_kit_api.ActionDescription {
 }
 
 // Prepare validates input parameters and prepares the state for execution
-func (a *haProxyAction) Prepare(_ context.Context, state *HAProxyState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+func (a *haProxyAction) Prepare(ctx context.Context, state *HAProxyState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
 	var err error
 	state.ExecutionId = request.ExecutionId
 	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
@@ -61,6 +64,11 @@ func (a *haProxyAction) Prepare(_ context.Context, state *HAProxyState, request
 		return nil, err
 	}
 
+	state.Percentage, err = parsePercentage(request.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	ingress, err := client.K8S.IngressByNamespaceAndName(state.Namespace, state.IngressName, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch ingress: %w", err)
@@ -77,7 +85,16 @@ func (a *haProxyAction) Prepare(_ context.Context, state *HAProxyState, request
 		}
 	}
 
+	state.OnRevert, err = parseOnRevert(request.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	state.AnnotationConfig = a.annotationConfigFn(state, request.Config)
+
+	if err = validateHAProxySnippet(ctx, request.Target.Attributes, state.AnnotationConfig); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
 
@@ -92,6 +109,18 @@ func (a *haProxyAction) Start(_ context.Context, state *HAProxyState) (*action_k
 	return nil, nil
 }
 
+// Status re-applies or fails the action if the HAProxy configuration was removed from the ingress
+func (a *haProxyAction) Status(ctx context.Context, state *HAProxyState) (*action_kit_api.StatusResult, error) {
+	return checkAnnotationBlock(ctx, annotationBlock{
+		namespace:     state.Namespace,
+		ingressName:   state.IngressName,
+		annotationKey: state.AnnotationKey,
+		startMarker:   getHAProxyStartMarker(state.ExecutionId),
+		config:        state.AnnotationConfig,
+		onRevert:      state.OnRevert,
+	})
+}
+
 // Stop removes the HAProxy configuration to stop blocking traffic
 func (a *haProxyAction) Stop(_ context.Context, state *HAProxyState) (*action_kit_api.StopResult, error) {
 	err := client.K8S.RemoveIngressAnnotationBlock(
@@ -178,7 +207,7 @@ func getConditionsParameters() []action_kit_api.ActionParameter {
 }
 
 func getHAProxyStartMarker(executionId uuid.UUID) string {
-	return fmt.Sprintf("# BEGIN STEADYBIT - %s\n", executionId)
+	return fmt.Sprintf("%s%s\n", client.SteadybitBlockMarker, executionId)
 }
 
 func getHAProxyEndMarker(executionId uuid.UUID) string {
@@ -186,7 +215,7 @@ func getHAProxyEndMarker(executionId uuid.UUID) string {
 }
 
 // checkHAProxyRuleConflicts checks if the new rules would conflict with existing ones
-func checkHAProxyRuleConflicts(lines []string, matcher RequestMatcher) error {
+func checkHAProxyRuleConflicts(lines []string, matcher extcommon.RequestMatcher) error {
 	if matcher.PathPattern == "" {
 		return nil
 	}
@@ -97,6 +97,16 @@ func buildHAProxyDelayConfig(state *HAProxyState, config map[string]any) string
 		invertedAclRefs = append(invertedAclRefs, fmt.Sprintf("!%s", aclName))
 	}
 
+	// Add percentage condition if only a share of the requests should be delayed. The content rules are evaluated
+	// repeatedly during the inspect delay, so the random number is drawn once per request and kept in a variable.
+	if sampled(state.Percentage) {
+		varName := fmt.Sprintf("txn.sb_pct_%s", aclIdPrefix)
+		s.WriteString(fmt.Sprintf("tcp-request content set-var(%s) rand(100) unless { var(%s) -m found }\n", varName, varName))
+		aclName := fmt.Sprintf("sb_pct_%s", aclIdPrefix)
+		aclDefinitions = append(aclDefinitions, fmt.Sprintf("acl %s var(%s) -m int lt %d", aclName, varName, state.Percentage))
+		invertedAclRefs = append(invertedAclRefs, fmt.Sprintf("!%s", aclName))
+	}
+
 	// Add all ACL definitions to the configuration
 	for _, aclDef := range aclDefinitions {
 		s.WriteString(aclDef + "\n")
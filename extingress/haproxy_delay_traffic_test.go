@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/steadybit/extension-kubernetes/v2/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -106,7 +107,7 @@ func TestHAProxyDelayTrafficAction_Prepare(t *testing.T) {
 				ExecutionId:      uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				Namespace:        "demo",
 				IngressName:      "simple-ingress",
-				Matcher:          RequestMatcher{PathPattern: "/api/*"},
+				Matcher:          extcommon.RequestMatcher{PathPattern: "/api/*"},
 				AnnotationConfig: "# BEGIN STEADYBIT - 00000000-0000-0000-0000-000000000000\ntcp-request inspect-delay 500ms\nacl sb_path_00000000_0000_0000_0000_000000000000 path_reg /api/*\ntcp-request content accept if WAIT_END || !sb_path_00000000_0000_0000_0000_000000000000\n# END STEADYBIT - 00000000-0000-0000-0000-000000000000\n",
 			},
 			wantErr: nil,
@@ -137,7 +138,7 @@ func TestHAProxyDelayTrafficAction_Prepare(t *testing.T) {
 				ExecutionId:      uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				Namespace:        "demo",
 				IngressName:      "simple-ingress",
-				Matcher:          RequestMatcher{HttpMethod: "POST"},
+				Matcher:          extcommon.RequestMatcher{HttpMethod: "POST"},
 				AnnotationConfig: "# BEGIN STEADYBIT - 00000000-0000-0000-0000-000000000000\ntcp-request inspect-delay 500ms\nacl sb_method_00000000_0000_0000_0000_000000000000 method POST\ntcp-request content accept if WAIT_END || !sb_method_00000000_0000_0000_0000_000000000000\n# END STEADYBIT - 00000000-0000-0000-0000-000000000000\n",
 			},
 			wantErr: nil,
@@ -170,7 +171,7 @@ func TestHAProxyDelayTrafficAction_Prepare(t *testing.T) {
 				ExecutionId: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				Namespace:   "demo",
 				IngressName: "simple-ingress",
-				Matcher: RequestMatcher{HttpHeader: map[string]string{
+				Matcher: extcommon.RequestMatcher{HttpHeader: map[string]string{
 					"User-Agent": "Mozilla.*",
 				},
 				},
@@ -208,7 +209,7 @@ func TestHAProxyDelayTrafficAction_Prepare(t *testing.T) {
 				ExecutionId: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				Namespace:   "demo",
 				IngressName: "simple-ingress",
-				Matcher: RequestMatcher{PathPattern: "/api/users",
+				Matcher: extcommon.RequestMatcher{PathPattern: "/api/users",
 					HttpMethod: "POST",
 					HttpHeader: map[string]string{
 						"Content-Type": "application/json",
@@ -18,25 +18,35 @@ import (
 	"github.com/steadybit/extension-kubernetes/v2/client"
 	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 )
 
 type ingressDiscovery struct {
-	k8s *client.Client
+	k8s      *client.Client
+	targets  *extcommon.ResultCache[discovery_kit_api.Target]
+	backends *extcommon.BackendResolver
 }
 
 var (
-	_ discovery_kit_sdk.TargetDescriber = (*ingressDiscovery)(nil)
+	_ discovery_kit_sdk.TargetDescriber          = (*ingressDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*ingressDiscovery)(nil)
 )
 
 func NewIngressDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
 	discovery := &ingressDiscovery{
-		k8s: k8s,
+		k8s:      k8s,
+		targets:  extcommon.NewResultCache[discovery_kit_api.Target](),
+		backends: extcommon.NewBackendResolver(k8s),
 	}
 
-	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+	chRefresh := extcommon.TriggerOnKubernetesSpecChange(k8s,
 		reflect.TypeFor[networkingv1.Ingress](),
 		reflect.TypeFor[networkingv1.IngressClass](),
+		reflect.TypeFor[corev1.Service](),
+		reflect.TypeFor[appsv1.Deployment](),
+		reflect.TypeFor[appsv1.StatefulSet](),
 	)
 
 	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
@@ -94,45 +104,62 @@ func (d *ingressDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_a
 		}
 	}
 
+	defer d.targets.EndRun()
+	defer d.backends.EndRun()
+
+	ingressClassesFingerprint := extcommon.Fingerprint(d.k8s.IngressClasses()...)
 	targets := make([]discovery_kit_api.Target, len(filteredIngresses))
 	for i, ingress := range filteredIngresses {
-		attributes := map[string][]string{
-			"k8s.namespace":    {ingress.Namespace},
-			"k8s.ingress":      {ingress.Name},
-			"k8s.cluster-name": {extconfig.Config.ClusterName},
-			"k8s.distribution": {d.k8s.Distribution},
-		}
+		backendAttributes, backendsFingerprint := d.backends.Resolve(ingress.Namespace, extcommon.IngressBackends(ingress))
+		fingerprint := extcommon.Fingerprints(extcommon.Fingerprint(ingress), ingressClassesFingerprint, backendsFingerprint, extcommon.NamespaceFingerprint(d.k8s, ingress.Namespace))
+		targets[i] = d.targets.Get(string(ingress.UID), fingerprint, func() discovery_kit_api.Target {
+			return d.toTarget(ingress, backendAttributes)
+		})
+	}
+	return targets, nil
+}
 
-		if ingressClassName := d.getIngressClassName(ingress); ingressClassName != "" {
-			attributes["k8s.ingress.class"] = []string{ingressClassName}
-			controller := d.k8s.GetIngressControllerByClassName(ingressClassName)
-			if controller != "" {
-				attributes["k8s.ingress.controller"] = []string{controller}
-			}
-		}
+func (d *ingressDiscovery) toTarget(ingress *networkingv1.Ingress, backendAttributes map[string][]string) discovery_kit_api.Target {
+	attributes := map[string][]string{
+		"k8s.namespace":    {ingress.Namespace},
+		"k8s.ingress":      {ingress.Name},
+		"k8s.cluster-name": {extconfig.Config.ClusterName},
+		"k8s.distribution": {d.k8s.Distribution},
+	}
 
-		hosts := make([]string, 0)
-		for _, rule := range ingress.Spec.Rules {
-			if rule.Host != "" {
-				hosts = append(hosts, rule.Host)
-			}
+	if ingressClassName := d.getIngressClassName(ingress); ingressClassName != "" {
+		attributes["k8s.ingress.class"] = []string{ingressClassName}
+		controller := d.k8s.GetIngressControllerByClassName(ingressClassName)
+		if controller != "" {
+			attributes["k8s.ingress.controller"] = []string{controller}
 		}
-		if len(hosts) > 0 {
-			attributes["k8s.ingress.hosts"] = hosts
+	}
+
+	hosts := make([]string, 0)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
 		}
+	}
+	if len(hosts) > 0 {
+		attributes["k8s.ingress.hosts"] = hosts
+	}
 
-		extcommon.AddLabels(attributes, ingress.ObjectMeta.Labels, "k8s.ingress.label", "k8s.label")
-		extcommon.AddNamespaceLabels(attributes, d.k8s, ingress.Namespace)
+	extcommon.MergeAttributes(attributes, backendAttributes)
+	extcommon.AddLabels(attributes, ingress.ObjectMeta.Labels, "k8s.ingress.label", "k8s.label")
+	extcommon.AddNamespaceLabels(attributes, d.k8s, ingress.Namespace)
 
-		targets[i] = discovery_kit_api.Target{
-			Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, ingress.Namespace, ingress.Name),
-			TargetType: HAProxyIngressTargetType,
-			Label:      ingress.Name,
-			Attributes: attributes,
-		}
+	target := discovery_kit_api.Target{
+		Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, ingress.Namespace, ingress.Name),
+		TargetType: HAProxyIngressTargetType,
+		Label:      ingress.Name,
+		Attributes: attributes,
 	}
+	return discovery_kit_commons.ApplyAttributeExcludes([]discovery_kit_api.Target{target}, extconfig.Config.DiscoveryAttributesExcludesIngress)[0]
+}
 
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesIngress), nil
+func (d *ingressDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return extcommon.GetRouteToWorkloadEnrichmentRules(HAProxyIngressTargetType)
 }
 
 func (d *ingressDiscovery) getIngressClassName(ingress *networkingv1.Ingress) string {
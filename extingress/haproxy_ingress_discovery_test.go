@@ -15,6 +15,7 @@ import (
 	testclient "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/steadybit/extension-kubernetes/v2/extconfig"
 	"github.com/steadybit/extension-kubernetes/v2/testutil"
 )
@@ -78,7 +79,7 @@ func Test_ingressDiscovery_Basic(t *testing.T) {
 	_, err = cs.NetworkingV1().Ingresses("default").Create(context.Background(), ing2, metav1.CreateOptions{})
 	require.NoError(t, err)
 
-	d := &ingressDiscovery{k8s: cli}
+	d := &ingressDiscovery{k8s: cli, backends: extcommon.NewBackendResolver(cli)}
 
 	// Ensure only ingresses with HAProxy classes are discovered
 	assert.Eventually(t, func() bool {
@@ -121,7 +122,7 @@ func Test_ingressDiscovery_ExcludeDisabled(t *testing.T) {
 	_, err = cs.NetworkingV1().Ingresses("default").Create(context.Background(), ing, metav1.CreateOptions{})
 	require.NoError(t, err)
 
-	d := &ingressDiscovery{k8s: cli}
+	d := &ingressDiscovery{k8s: cli, backends: extcommon.NewBackendResolver(cli)}
 
 	assert.Eventually(t, func() bool {
 		res, _ := d.DiscoverTargets(context.Background())
@@ -148,7 +149,7 @@ func Test_ingressDiscovery_IncludeDisabledIfDisableDiscoveryExcludes(t *testing.
 	_, err = cs.NetworkingV1().Ingresses("default").Create(context.Background(), ing, metav1.CreateOptions{})
 	require.NoError(t, err)
 
-	d := &ingressDiscovery{k8s: cli}
+	d := &ingressDiscovery{k8s: cli, backends: extcommon.NewBackendResolver(cli)}
 
 	assert.Eventually(t, func() bool {
 		res, _ := d.DiscoverTargets(context.Background())
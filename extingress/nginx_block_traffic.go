@@ -66,7 +66,7 @@ func buildNginxBlockConfig(state *NginxState, config map[string]any) string {
 	var s strings.Builder
 	s.WriteString(getNginxStartMarker(state.ExecutionId, nginxActionSubTypeBlock))
 
-	s.WriteString(buildConfigForMatcher(state.Matcher, shouldBlockVar))
+	s.WriteString(buildConfigForMatcher(state.Matcher, state.Percentage, shouldBlockVar))
 	s.WriteString(fmt.Sprintf("if (%s = 1) { return %d; }\n", shouldBlockVar, responseStatusCode))
 
 	s.WriteString(getNginxEndMarker(state.ExecutionId, nginxActionSubTypeBlock))
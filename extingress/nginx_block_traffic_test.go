@@ -15,10 +15,12 @@ import (
 
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
 )
 
 // TestNginxBlockTrafficAction_Prepare tests the Prepare method of NginxBlockTrafficAction
@@ -46,7 +48,7 @@ func TestNginxBlockTrafficAction_Prepare(t *testing.T) {
 				ExecutionId:      testUUIDBlock,
 				Namespace:        "demo",
 				IngressName:      "test-nginx-ingress",
-				Matcher:          RequestMatcher{PathPattern: "/api/.*"},
+				Matcher:          extcommon.RequestMatcher{PathPattern: "/api/.*"},
 				AnnotationKey:    nginxAnnotationKey,
 				AnnotationConfig: "# BEGIN STEADYBIT - Block - 00000000-0000-0000-0000-000000000000\nset $sb_should_block_00000000000000000000000000000000 1;\nif ($request_uri !~* /api/.*) { set $sb_should_block_00000000000000000000000000000000 0; }\nif ($sb_should_block_00000000000000000000000000000000 = 1) { return 503; }\n# END STEADYBIT - Block - 00000000-0000-0000-0000-000000000000\n",
 			},
@@ -62,7 +64,7 @@ func TestNginxBlockTrafficAction_Prepare(t *testing.T) {
 				ExecutionId:      testUUIDBlock,
 				Namespace:        "demo",
 				IngressName:      "test-nginx-ingress",
-				Matcher:          RequestMatcher{HttpMethod: "POST"},
+				Matcher:          extcommon.RequestMatcher{HttpMethod: "POST"},
 				AnnotationKey:    nginxAnnotationKey,
 				AnnotationConfig: "# BEGIN STEADYBIT - Block - 00000000-0000-0000-0000-000000000000\nset $sb_should_block_00000000000000000000000000000000 1;\nif ($request_method != POST) { set $sb_should_block_00000000000000000000000000000000 0; }\nif ($sb_should_block_00000000000000000000000000000000 = 1) { return 503; }\n# END STEADYBIT - Block - 00000000-0000-0000-0000-000000000000\n",
 			},
@@ -80,7 +82,7 @@ func TestNginxBlockTrafficAction_Prepare(t *testing.T) {
 				ExecutionId: testUUIDBlock,
 				Namespace:   "demo",
 				IngressName: "test-nginx-ingress",
-				Matcher: RequestMatcher{HttpHeader: map[string]string{
+				Matcher: extcommon.RequestMatcher{HttpHeader: map[string]string{
 					"User-Agent": "Mozilla.*",
 				}},
 				AnnotationKey:    nginxAnnotationKey,
@@ -102,7 +104,7 @@ func TestNginxBlockTrafficAction_Prepare(t *testing.T) {
 				ExecutionId: testUUIDBlock,
 				Namespace:   "demo",
 				IngressName: "test-nginx-ingress",
-				Matcher: RequestMatcher{PathPattern: "/api/users",
+				Matcher: extcommon.RequestMatcher{PathPattern: "/api/users",
 					HttpMethod: "POST",
 					HttpHeader: map[string]string{
 						"Content-Type": "application/json",
@@ -112,6 +114,24 @@ func TestNginxBlockTrafficAction_Prepare(t *testing.T) {
 				AnnotationConfig: "# BEGIN STEADYBIT - Block - 00000000-0000-0000-0000-000000000000\nset $sb_should_block_00000000000000000000000000000000 1;\nif ($request_uri !~* /api/users) { set $sb_should_block_00000000000000000000000000000000 0; }\nif ($request_method != POST) { set $sb_should_block_00000000000000000000000000000000 0; }\nif ($http_content_type !~* application/json) { set $sb_should_block_00000000000000000000000000000000 0; }\nif ($sb_should_block_00000000000000000000000000000000 = 1) { return 503; }\n# END STEADYBIT - Block - 00000000-0000-0000-0000-000000000000\n",
 			},
 		},
+		{
+			name:        "block a percentage of the requests",
+			ingressName: "test-nginx-ingress",
+			config: map[string]any{
+				"responseStatusCode":   503,
+				"conditionPathPattern": "/api/.*",
+				"percentage":           25,
+			},
+			want: NginxState{
+				ExecutionId:      testUUIDBlock,
+				Namespace:        "demo",
+				IngressName:      "test-nginx-ingress",
+				Matcher:          extcommon.RequestMatcher{PathPattern: "/api/.*"},
+				Percentage:       25,
+				AnnotationKey:    nginxAnnotationKey,
+				AnnotationConfig: "# BEGIN STEADYBIT - Block - 00000000-0000-0000-0000-000000000000\nset $sb_should_block_00000000000000000000000000000000 1;\nif ($request_uri !~* /api/.*) { set $sb_should_block_00000000000000000000000000000000 0; }\nif ($request_id !~ ^([0-3])) { set $sb_should_block_00000000000000000000000000000000 0; }\nif ($sb_should_block_00000000000000000000000000000000 = 1) { return 503; }\n# END STEADYBIT - Block - 00000000-0000-0000-0000-000000000000\n",
+			},
+		},
 		{
 			name:        "no conditions provided",
 			ingressName: "test-nginx-ingress",
@@ -154,6 +174,106 @@ func TestNginxBlockTrafficAction_Prepare(t *testing.T) {
 	}
 }
 
+func TestNginxBlockTrafficAction_StatusOnRevert(t *testing.T) {
+	tests := []struct {
+		name        string
+		onRevert    string
+		wantError   string
+		wantMessage string
+	}{
+		{
+			name:        "re-applies reverted configuration",
+			onRevert:    onRevertReapply,
+			wantMessage: "Configuration of ingress demo/test-nginx-ingress was reverted by controller argocd-controller. Re-applied it.",
+		},
+		{
+			name:      "fails on reverted configuration",
+			onRevert:  onRevertFail,
+			wantError: "Configuration of ingress demo/test-nginx-ingress was reverted by controller argocd-controller.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			ingress := createNginxIngress("test-nginx-ingress", "# Some config\n")
+			ingress.ManagedFields = []metav1.ManagedFieldsEntry{{
+				Manager:  "argocd-controller",
+				Time:     new(metav1.Now()),
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:` + nginxAnnotationKey + `":{}}}}`)},
+			}}
+			testClient, _ := newTestClient(stopCh, ingress)
+			client.K8S = testClient
+
+			action := NewNginxBlockTrafficAction()
+			state := NginxState{
+				ExecutionId:   testUUIDBlock,
+				Namespace:     "demo",
+				IngressName:   "test-nginx-ingress",
+				AnnotationKey: nginxAnnotationKey,
+				Matcher:       extcommon.RequestMatcher{PathPattern: "/api"},
+				OnRevert:      tt.onRevert,
+			}
+			state.AnnotationConfig = buildNginxBlockConfig(&state, map[string]any{"responseStatusCode": 503})
+
+			result, err := action.(*nginxAction).Status(context.Background(), &state)
+			require.NoError(t, err)
+
+			current, err := testClient.IngressByNamespaceAndName("demo", "test-nginx-ingress", true)
+			require.NoError(t, err)
+			if tt.wantError != "" {
+				require.NotNil(t, result.Error)
+				assert.Equal(t, tt.wantError, result.Error.Title)
+				assert.True(t, result.Completed)
+				assert.NotContains(t, current.Annotations[nginxAnnotationKey], "BEGIN STEADYBIT")
+				return
+			}
+			require.NotNil(t, result.Messages)
+			assert.Equal(t, tt.wantMessage, (*result.Messages)[0].Message)
+			assert.True(t, strings.HasPrefix(current.Annotations[nginxAnnotationKey], state.AnnotationConfig))
+
+			result, err = action.(*nginxAction).Status(context.Background(), &state)
+			require.NoError(t, err)
+			assert.Nil(t, result.Messages)
+		})
+	}
+}
+
+func TestNginxBlockTrafficAction_StatusChecksIntactConfigurationInCache(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	state := NginxState{
+		ExecutionId:   testUUIDBlock,
+		Namespace:     "demo",
+		IngressName:   "test-nginx-ingress",
+		AnnotationKey: nginxAnnotationKey,
+		Matcher:       extcommon.RequestMatcher{PathPattern: "/api"},
+		OnRevert:      onRevertFail,
+	}
+	state.AnnotationConfig = buildNginxBlockConfig(&state, map[string]any{"responseStatusCode": 503})
+	testClient, cs := newTestClient(stopCh, createNginxIngress("test-nginx-ingress", state.AnnotationConfig))
+	client.K8S = testClient
+	require.Eventually(t, func() bool {
+		cached, err := testClient.IngressByNamespaceAndName("demo", "test-nginx-ingress")
+		return err == nil && strings.Contains(cached.Annotations[nginxAnnotationKey], getNginxStartMarker(testUUIDBlock, nginxActionSubTypeBlock))
+	}, time.Second, 10*time.Millisecond)
+	fake := cs.(*testclient.Clientset)
+	fake.ClearActions()
+
+	// When
+	result, err := NewNginxBlockTrafficAction().(*nginxAction).Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Nil(t, result.Error)
+	for _, action := range fake.Actions() {
+		assert.NotEqual(t, "get", action.GetVerb(), "unexpected %s of %s", action.GetVerb(), action.GetResource().Resource)
+	}
+}
+
 // Fixed test UUID for predictable test results
 var testUUIDBlock = uuid.MustParse("00000000-0000-0000-0000-000000000000")
 
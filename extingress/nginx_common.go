@@ -7,6 +7,7 @@ package extingress
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
@@ -14,6 +15,7 @@ import (
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 )
 
 // Action IDs and constants for NGINX actions
@@ -31,9 +33,11 @@ type NginxState struct {
 	ExecutionId      uuid.UUID
 	Namespace        string
 	IngressName      string
-	Matcher          RequestMatcher
+	Matcher          extcommon.RequestMatcher
+	Percentage       int
 	AnnotationKey    string
 	AnnotationConfig string
+	OnRevert         string
 }
 
 type nginxAction struct {
@@ -54,7 +58,7 @@ func (a *nginxAction) Describe() action_kit_api.ActionDescription {
 }
 
 // Prepare validates input parameters and prepares the state for execution
-func (a *nginxAction) Prepare(_ context.Context, state *NginxState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+func (a *nginxAction) Prepare(ctx context.Context, state *NginxState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
 	if a.requiresSteadybitModule {
 		if err := validateNginxSteadybitModule(request.Target.Attributes); err != nil {
 			return nil, fmt.Errorf("NGINX steadybit sleep module validation failed: %w", err)
@@ -77,6 +81,11 @@ func (a *nginxAction) Prepare(_ context.Context, state *NginxState, request acti
 		return nil, err
 	}
 
+	state.Percentage, err = parsePercentage(request.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	existingSnippet := strings.Split(ingress.Annotations[state.AnnotationKey], "\n")
 	if err = checkNginxRuleConflicts(existingSnippet, state.Matcher); err != nil {
 		return nil, err
@@ -88,13 +97,22 @@ func (a *nginxAction) Prepare(_ context.Context, state *NginxState, request acti
 		}
 	}
 
+	state.OnRevert, err = parseOnRevert(request.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	state.AnnotationConfig = a.annotationConfigFn(state, request.Config)
 
+	if err = validateNginxSnippet(ctx, request.Target.Attributes, state.AnnotationKey, state.AnnotationConfig); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
 // checkNginxRuleConflicts checks if the new rules would conflict with existing ones
-func checkNginxRuleConflicts(existingLines []string, matcher RequestMatcher) error {
+func checkNginxRuleConflicts(existingLines []string, matcher extcommon.RequestMatcher) error {
 	if matcher.PathPattern == "" {
 		return nil
 	}
@@ -142,6 +160,18 @@ func (a *nginxAction) Start(_ context.Context, state *NginxState) (*action_kit_a
 	return nil, nil
 }
 
+// Status re-applies or fails the action if the NGINX configuration was removed from the ingress
+func (a *nginxAction) Status(ctx context.Context, state *NginxState) (*action_kit_api.StatusResult, error) {
+	return checkAnnotationBlock(ctx, annotationBlock{
+		namespace:     state.Namespace,
+		ingressName:   state.IngressName,
+		annotationKey: state.AnnotationKey,
+		startMarker:   getNginxStartMarker(state.ExecutionId, a.subtype),
+		config:        state.AnnotationConfig,
+		onRevert:      state.OnRevert,
+	})
+}
+
 // Stop removes the NGINX configuration to stop blocking traffic
 func (a *nginxAction) Stop(_ context.Context, state *NginxState) (*action_kit_api.StopResult, error) {
 	err := client.K8S.RemoveIngressAnnotationBlock(
@@ -162,7 +192,7 @@ func (a *nginxAction) Stop(_ context.Context, state *NginxState) (*action_kit_ap
 
 // getNginxStartMarker Helper functions similar to HAProxy implementation
 func getNginxStartMarker(executionId uuid.UUID, subtype string) string {
-	return fmt.Sprintf("# BEGIN STEADYBIT - %s - %s\n", subtype, executionId)
+	return fmt.Sprintf("%s%s - %s\n", client.SteadybitBlockMarker, subtype, executionId)
 }
 
 func getNginxEndMarker(executionId uuid.UUID, subtype string) string {
@@ -199,7 +229,7 @@ func getNginxAnnotationKey(request action_kit_api.PrepareActionRequestBody) stri
 	return annotationKey
 }
 
-func buildConfigForMatcher(matcher RequestMatcher, varName string) string {
+func buildConfigForMatcher(matcher extcommon.RequestMatcher, percentage int, varName string) string {
 	var config strings.Builder
 
 	config.WriteString(fmt.Sprintf("set %s 1;\n", varName))
@@ -217,5 +247,42 @@ func buildConfigForMatcher(matcher RequestMatcher, varName string) string {
 		config.WriteString(fmt.Sprintf("if ($http_%s !~* %s) { set %s 0; }\n", normalizedHeaderName, headerValue, varName))
 	}
 
+	if sampled(percentage) {
+		config.WriteString(fmt.Sprintf("if ($request_id !~ %s) { set %s 0; }\n", requestIdSampleRegex(percentage), varName))
+	}
+
 	return config.String()
 }
+
+// requestIdSampleRegex returns a regex matching the given percentage of request ids. Configuration snippets can't
+// declare split_clients, which is only allowed in the http context, so the random hex-encoded $request_id is used
+// instead: the regex matches the ids whose first three hex digits are below percentage * 4096 / 100.
+func requestIdSampleRegex(percentage int) string {
+	const width = 3
+	threshold := (percentage*4096 + 50) / 100
+	digits := fmt.Sprintf("%0*x", width, threshold)
+
+	var alternatives []string
+	for i := range width {
+		digit, _ := strconv.ParseInt(digits[i:i+1], 16, 0)
+		if digit == 0 {
+			continue
+		}
+		alternatives = append(alternatives, digits[:i]+hexDigitsBelow(int(digit)))
+	}
+	return "^(" + strings.Join(alternatives, "|") + ")"
+}
+
+// hexDigitsBelow returns a character class matching the hex digits below the given one.
+func hexDigitsBelow(digit int) string {
+	switch {
+	case digit == 1:
+		return "0"
+	case digit <= 10:
+		return fmt.Sprintf("[0-%d]", digit-1)
+	case digit == 11:
+		return "[0-9a]"
+	default:
+		return fmt.Sprintf("[0-9a-%x]", digit-1)
+	}
+}
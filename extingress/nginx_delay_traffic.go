@@ -76,7 +76,7 @@ func buildNginxDelayConfig(state *NginxState, config map[string]any) string {
 	var s strings.Builder
 	s.WriteString(getNginxStartMarker(state.ExecutionId, nginxActionSubTypeDelay))
 
-	s.WriteString(buildConfigForMatcher(state.Matcher, shouldDelayVar))
+	s.WriteString(buildConfigForMatcher(state.Matcher, state.Percentage, shouldDelayVar))
 
 	sleepDurationVar := getNginxUniqueVariableName(state.ExecutionId, "sleep_ms_duration")
 	s.WriteString(fmt.Sprintf("set %s 0;\n", sleepDurationVar))
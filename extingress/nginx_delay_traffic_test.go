@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/steadybit/extension-kubernetes/v2/extconfig"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -46,7 +47,7 @@ func TestNginxDelayTrafficAction_Prepare(t *testing.T) {
 				ExecutionId:      myTestUUID,
 				Namespace:        "demo",
 				IngressName:      "test-nginx-ingress",
-				Matcher:          RequestMatcher{PathPattern: "/api/.*"},
+				Matcher:          extcommon.RequestMatcher{PathPattern: "/api/.*"},
 				AnnotationKey:    nginxAnnotationKey,
 				AnnotationConfig: "# BEGIN STEADYBIT - Delay - 00000000-0000-0000-0000-000000000000\nset $sb_should_delay_00000000000000000000000000000000 1;\nif ($request_uri !~* /api/.*) { set $sb_should_delay_00000000000000000000000000000000 0; }\nset $sb_sleep_ms_duration_00000000000000000000000000000000 0;\nif ($sb_should_delay_00000000000000000000000000000000 = 1) { set $sb_sleep_ms_duration_00000000000000000000000000000000 500; }\nsb_sleep_ms $sb_sleep_ms_duration_00000000000000000000000000000000;\n# END STEADYBIT - Delay - 00000000-0000-0000-0000-000000000000\n",
 			},
@@ -63,7 +64,7 @@ func TestNginxDelayTrafficAction_Prepare(t *testing.T) {
 				ExecutionId:      myTestUUID,
 				Namespace:        "demo",
 				IngressName:      "test-nginx-ingress",
-				Matcher:          RequestMatcher{PathPattern: "/api/.*"},
+				Matcher:          extcommon.RequestMatcher{PathPattern: "/api/.*"},
 				AnnotationKey:    nginxEnterpriseAnnotationKey,
 				AnnotationConfig: "# BEGIN STEADYBIT - Delay - 00000000-0000-0000-0000-000000000000\nset $sb_should_delay_00000000000000000000000000000000 1;\nif ($request_uri !~* /api/.*) { set $sb_should_delay_00000000000000000000000000000000 0; }\nset $sb_sleep_ms_duration_00000000000000000000000000000000 0;\nif ($sb_should_delay_00000000000000000000000000000000 = 1) { set $sb_sleep_ms_duration_00000000000000000000000000000000 500; }\nsb_sleep_ms $sb_sleep_ms_duration_00000000000000000000000000000000;\n# END STEADYBIT - Delay - 00000000-0000-0000-0000-000000000000\n",
 			},
@@ -79,7 +80,7 @@ func TestNginxDelayTrafficAction_Prepare(t *testing.T) {
 				ExecutionId:      myTestUUID,
 				Namespace:        "demo",
 				IngressName:      "test-nginx-ingress",
-				Matcher:          RequestMatcher{HttpMethod: "POST"},
+				Matcher:          extcommon.RequestMatcher{HttpMethod: "POST"},
 				AnnotationKey:    nginxAnnotationKey,
 				AnnotationConfig: "# BEGIN STEADYBIT - Delay - 00000000-0000-0000-0000-000000000000\nset $sb_should_delay_00000000000000000000000000000000 1;\nif ($request_method != POST) { set $sb_should_delay_00000000000000000000000000000000 0; }\nset $sb_sleep_ms_duration_00000000000000000000000000000000 0;\nif ($sb_should_delay_00000000000000000000000000000000 = 1) { set $sb_sleep_ms_duration_00000000000000000000000000000000 500; }\nsb_sleep_ms $sb_sleep_ms_duration_00000000000000000000000000000000;\n# END STEADYBIT - Delay - 00000000-0000-0000-0000-000000000000\n",
 			},
@@ -97,7 +98,7 @@ func TestNginxDelayTrafficAction_Prepare(t *testing.T) {
 				ExecutionId: myTestUUID,
 				Namespace:   "demo",
 				IngressName: "test-nginx-ingress",
-				Matcher: RequestMatcher{HttpHeader: map[string]string{
+				Matcher: extcommon.RequestMatcher{HttpHeader: map[string]string{
 					"User-Agent": "Mozilla.*",
 				},
 				},
@@ -120,7 +121,7 @@ func TestNginxDelayTrafficAction_Prepare(t *testing.T) {
 				ExecutionId: myTestUUID,
 				Namespace:   "demo",
 				IngressName: "test-nginx-ingress",
-				Matcher: RequestMatcher{PathPattern: "/api/users",
+				Matcher: extcommon.RequestMatcher{PathPattern: "/api/users",
 					HttpMethod: "POST",
 					HttpHeader: map[string]string{
 						"Content-Type": "application/json",
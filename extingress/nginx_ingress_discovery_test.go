@@ -12,6 +12,7 @@ import (
 
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
 
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/steadybit/extension-kubernetes/v2/extconfig"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -58,7 +59,10 @@ func Test_nginxIngressDiscovery_Basic(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: "ing1", Namespace: "default"},
 		Spec: networkingv1.IngressSpec{
 			IngressClassName: new("nginxClass"),
-			Rules:            []networkingv1.IngressRule{{Host: "host1.example.com"}},
+			DefaultBackend: &networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{Name: "shop", Port: networkingv1.ServiceBackendPort{Number: 80}},
+			},
+			Rules: []networkingv1.IngressRule{{Host: "host1.example.com"}},
 		},
 	}
 	_, err = cs.NetworkingV1().Ingresses("default").Create(context.Background(), ing1, metav1.CreateOptions{})
@@ -99,7 +103,7 @@ func Test_nginxIngressDiscovery_Basic(t *testing.T) {
 	_, err = cs.NetworkingV1().Ingresses("default").Create(context.Background(), ing4, metav1.CreateOptions{})
 	require.NoError(t, err)
 
-	d := &nginxIngressDiscovery{k8s: cli}
+	d := &nginxIngressDiscovery{k8s: cli, backends: extcommon.NewBackendResolver(cli)}
 
 	// Ensure only ingresses with NGINX classes are discovered
 	assert.Eventually(t, func() bool {
@@ -123,6 +127,8 @@ func Test_nginxIngressDiscovery_Basic(t *testing.T) {
 		assert.Equal(t, []string{"nginxClass"}, target.Attributes["k8s.ingress.class"])
 		assert.Equal(t, []string{"k8s.io/ingress-nginx"}, target.Attributes["k8s.ingress.controller"])
 		assert.Equal(t, []string{"host1.example.com"}, target.Attributes["k8s.ingress.hosts"])
+		assert.Equal(t, []string{"shop"}, target.Attributes["k8s.backend.service"])
+		assert.Equal(t, []string{"shop:80"}, target.Attributes["k8s.backend.service.port"])
 	} else {
 		t.Error("ing1 not found in discovered targets")
 	}
@@ -176,7 +182,7 @@ func Test_nginxIngressDiscovery_ExcludeDisabled(t *testing.T) {
 	_, err = cs.NetworkingV1().Ingresses("default").Create(context.Background(), ing, metav1.CreateOptions{})
 	require.NoError(t, err)
 
-	d := &nginxIngressDiscovery{k8s: cli}
+	d := &nginxIngressDiscovery{k8s: cli, backends: extcommon.NewBackendResolver(cli)}
 
 	assert.Eventually(t, func() bool {
 		res, _ := d.DiscoverTargets(context.Background())
@@ -211,7 +217,7 @@ func Test_nginxIngressDiscovery_IncludeDisabledIfDisableDiscoveryExcludes(t *tes
 	_, err = cs.NetworkingV1().Ingresses("default").Create(context.Background(), ing, metav1.CreateOptions{})
 	require.NoError(t, err)
 
-	d := &nginxIngressDiscovery{k8s: cli}
+	d := &nginxIngressDiscovery{k8s: cli, backends: extcommon.NewBackendResolver(cli)}
 
 	assert.Eventually(t, func() bool {
 		res, _ := d.DiscoverTargets(context.Background())
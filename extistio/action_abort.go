@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extistio
+
+import (
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+)
+
+func NewAbortAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &faultRouteAction{
+		k8s:          k8s,
+		description:  getAbortDescription(),
+		subtype:      "abort",
+		buildFaultFn: buildAbortFault,
+	}
+}
+
+func getAbortDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		AbortActionId,
+		"Istio Abort Traffic",
+		"Abort a percentage of the traffic routed by an Istio VirtualService with a given HTTP status code.",
+	)
+	desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
+		Name:         "statusCode",
+		Label:        "HTTP Status Code",
+		Description:  new("The HTTP status code returned for aborted requests."),
+		Type:         action_kit_api.ActionParameterTypeInteger,
+		DefaultValue: new("503"),
+		Required:     new(true),
+		MinValue:     new(400),
+		MaxValue:     new(599),
+	})
+	return extcommon.WithConditionParameters(desc, conditionPathPatternDescription, false)
+}
+
+func buildAbortFault(config map[string]any) (map[string]any, error) {
+	statusCode := extutil.ToInt64(config["statusCode"])
+	if statusCode < 400 || statusCode > 599 {
+		return nil, fmt.Errorf("statusCode must be between 400 and 599")
+	}
+	percentage, err := extcommon.PercentageFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"abort": map[string]any{
+			"httpStatus": statusCode,
+			"percentage": map[string]any{"value": percentage},
+		},
+	}, nil
+}
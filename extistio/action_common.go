@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extistio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ActionState is shared by all Istio VirtualService attacks.
+type ActionState struct {
+	Namespace          string                   `json:"namespace"`
+	VirtualServiceName string                   `json:"virtualServiceName"`
+	RouteNamePrefix    string                   `json:"routeNamePrefix"`
+	ExecutionId        string                   `json:"executionId"`
+	Fault              map[string]any           `json:"fault"`
+	Matcher            extcommon.RequestMatcher `json:"matcher"`
+}
+
+// faultRouteAction is the common attack implementation. It prepends fault-injected copies of the HTTP routes to the
+// VirtualService for the duration of the attack. Each attack supplies a description and a buildFaultFn producing the
+// HTTPFaultInjection of the routes.
+type faultRouteAction struct {
+	k8s          *client.Client
+	description  action_kit_api.ActionDescription
+	subtype      string
+	buildFaultFn func(config map[string]any) (map[string]any, error)
+}
+
+func (a *faultRouteAction) NewEmptyState() ActionState {
+	return ActionState{}
+}
+
+func (a *faultRouteAction) Describe() action_kit_api.ActionDescription {
+	return a.description
+}
+
+func (a *faultRouteAction) Prepare(ctx context.Context, state *ActionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	namespace := request.Target.Attributes["k8s.namespace"]
+	virtualServiceName := request.Target.Attributes[attrVirtualService]
+	if len(namespace) == 0 || len(virtualServiceName) == 0 {
+		return nil, extension_kit.ToError("Missing required target attributes k8s.namespace and/or k8s.istio.virtual-service.", nil)
+	}
+
+	state.Namespace = namespace[0]
+	state.VirtualServiceName = virtualServiceName[0]
+	state.ExecutionId = request.ExecutionId.String()
+	state.RouteNamePrefix = fmt.Sprintf("%s%s-%s-", faultRouteNamePrefix, a.subtype, request.ExecutionId.String())
+
+	fault, err := a.buildFaultFn(request.Config)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to build fault configuration: %v", err), err)
+	}
+	state.Fault = fault
+
+	matcher, err := extcommon.ParseRequestMatcher(request.Config)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to parse request conditions: %v", err), err)
+	}
+	state.Matcher = matcher
+
+	virtualService, err := a.k8s.GetIstioVirtualService(ctx, state.Namespace, state.VirtualServiceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to fetch VirtualService %s/%s: %v", state.Namespace, state.VirtualServiceName, err), err)
+	}
+	// Apply the attack to the fetched copy only, so invalid conditions fail the preparation instead of the start.
+	if err := a.inject(virtualService, state); err != nil {
+		return nil, extension_kit.ToError(err.Error(), err)
+	}
+	return nil, nil
+}
+
+func (a *faultRouteAction) Start(ctx context.Context, state *ActionState) (*action_kit_api.StartResult, error) {
+	err := a.k8s.UpdateIstioVirtualService(ctx, state.Namespace, state.VirtualServiceName, func(virtualService *unstructured.Unstructured) (bool, error) {
+		// Re-check on the latest version, a concurrent attack on the same VirtualService may have started since Prepare.
+		return true, a.inject(virtualService, state)
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to inject fault into VirtualService %s/%s: %v", state.Namespace, state.VirtualServiceName, err), err)
+	}
+
+	log.Info().Msgf("Injected %s fault into VirtualService %s/%s", a.subtype, state.Namespace, state.VirtualServiceName)
+	return &action_kit_api.StartResult{
+		Messages: new([]action_kit_api.Message{
+			{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("Injected %s fault into VirtualService %s/%s", a.subtype, state.Namespace, state.VirtualServiceName),
+			},
+		}),
+	}, nil
+}
+
+func (a *faultRouteAction) Stop(ctx context.Context, state *ActionState) (*action_kit_api.StopResult, error) {
+	err := a.k8s.UpdateIstioVirtualService(ctx, state.Namespace, state.VirtualServiceName, func(virtualService *unstructured.Unstructured) (bool, error) {
+		return removeFaultRoutes(virtualService, state.RouteNamePrefix), nil
+	})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to remove fault from VirtualService %s/%s: %v", state.Namespace, state.VirtualServiceName, err), err)
+	}
+
+	log.Info().Msgf("Removed %s fault from VirtualService %s/%s", a.subtype, state.Namespace, state.VirtualServiceName)
+	return nil, nil
+}
+
+// inject fails if another attack already injected its routes into the VirtualService, as Istio would only apply the
+// fault of the first matching route. Otherwise, it injects the fault routes of this attack.
+func (a *faultRouteAction) inject(virtualService *unstructured.Unstructured, state *ActionState) error {
+	if conflict := findConflictingRoute(virtualService, state.RouteNamePrefix); conflict != "" {
+		return fmt.Errorf("another attack is already running on VirtualService %s/%s (route %s). Wait for it to finish or target a different VirtualService",
+			state.Namespace, state.VirtualServiceName, conflict)
+	}
+	_, err := injectFaultRoutes(virtualService, state.RouteNamePrefix, state.Fault, state.Matcher)
+	return err
+}
+
+// conditionPathPatternDescription describes the path condition, which replaces the URI match of the routes.
+const conditionPathPatternDescription = "Optional: only affect requests whose path matches this regular expression (RE2, matching the whole path). Not supported for VirtualServices whose routes already match on the URI."
+
+// getCommonActionDescription returns the base action description with the duration and percentage parameters and
+// the VirtualService target selection. Call extcommon.WithConditionParameters after adding the attack's own parameters.
+func getCommonActionDescription(id, label, description string) action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          id,
+		Label:       label,
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Description: description,
+		Technology:  new("Kubernetes"),
+		Icon:        new(IstioIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType: IstioVirtualServiceTargetType,
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "virtual service",
+					Description: new("Find VirtualService by cluster, namespace and name"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.istio.virtual-service=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new("The duration of the attack. The VirtualService will be affected for the specified duration."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Required:     new(true),
+			},
+			{
+				Name:         "percentage",
+				Label:        "Traffic Percentage",
+				Description:  new("The percentage of matching requests the fault is applied to."),
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				DefaultValue: new("100"),
+				MinValue:     new(1),
+				MaxValue:     new(100),
+				Required:     new(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extistio
+
+import (
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+)
+
+func NewDelayAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &faultRouteAction{
+		k8s:          k8s,
+		description:  getDelayDescription(),
+		subtype:      "delay",
+		buildFaultFn: buildDelayFault,
+	}
+}
+
+func getDelayDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		DelayActionId,
+		"Istio Delay Traffic",
+		"Inject a fixed delay into a percentage of the traffic routed by an Istio VirtualService.",
+	)
+	desc.Parameters = append(desc.Parameters, action_kit_api.ActionParameter{
+		Name:         "delay",
+		Label:        "Delay",
+		Description:  new("The fixed delay to inject into matching requests."),
+		Type:         action_kit_api.ActionParameterTypeDuration,
+		DefaultValue: new("500ms"),
+		Required:     new(true),
+	})
+	return extcommon.WithConditionParameters(desc, conditionPathPatternDescription, false)
+}
+
+func buildDelayFault(config map[string]any) (map[string]any, error) {
+	delayMs := extutil.ToInt64(config["delay"])
+	if delayMs <= 0 {
+		return nil, fmt.Errorf("delay must be greater than zero")
+	}
+	percentage, err := extcommon.PercentageFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"delay": map[string]any{
+			"fixedDelay": fmt.Sprintf("%dms", delayMs),
+			"percentage": map[string]any{"value": percentage},
+		},
+	}, nil
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extistio
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// IstioVirtualServiceTargetType is the discovery target type for Istio VirtualServices.
+	IstioVirtualServiceTargetType = "com.steadybit.extension_kubernetes.istio-virtual-service"
+
+	// attrVirtualService is the discovery attribute holding the VirtualService name.
+	attrVirtualService = "k8s.istio.virtual-service"
+
+	DelayActionId = "com.steadybit.extension_kubernetes.istio-virtual-service-delay"
+	AbortActionId = "com.steadybit.extension_kubernetes.istio-virtual-service-abort"
+
+	// faultRouteNamePrefix marks the HTTP routes injected by the attacks. A VirtualService containing such a route
+	// is already under attack.
+	faultRouteNamePrefix = "steadybit-"
+
+	// IstioIcon is a monochrome sail icon (currentColor).
+	IstioIcon = "data:image/svg+xml,%3Csvg%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20d%3D%22M10.5%201.5L4.5%2017.25H10.5V1.5Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3Cpath%20d%3D%22M12%206V17.25H17.25L12%206Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3Cpath%20d%3D%22M3.75%2018.75H20.25L17.25%2022.5H6.75L3.75%2018.75Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3C%2Fsvg%3E"
+)
+
+// restrictMatches combines the matcher with the match conditions of a route. Istio ORs the entries of a route's match list
+// and ANDs the conditions within an entry, so the matcher's conditions are added to every entry. A condition on the
+// URI or on a header the entry already matches on cannot be combined and is rejected.
+func restrictMatches(m extcommon.RequestMatcher, matches []any) ([]any, error) {
+	if m.PathPattern == "" && len(m.HttpHeader) == 0 {
+		return matches, nil
+	}
+	if len(matches) == 0 {
+		matches = []any{map[string]any{}}
+	}
+
+	result := make([]any, 0, len(matches))
+	for _, match := range matches {
+		entry, ok := match.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected match entry %v", match)
+		}
+		if m.PathPattern != "" {
+			if _, found := entry["uri"]; found {
+				return nil, fmt.Errorf("the route already matches on the URI and cannot be combined with a path condition")
+			}
+			entry["uri"] = map[string]any{"regex": m.PathPattern}
+		}
+		if len(m.HttpHeader) > 0 {
+			headers, _ := entry["headers"].(map[string]any)
+			if headers == nil {
+				headers = map[string]any{}
+			}
+			for _, name := range slices.Sorted(maps.Keys(m.HttpHeader)) {
+				key := strings.ToLower(name)
+				if _, found := headers[key]; found {
+					return nil, fmt.Errorf("the route already matches on header %q and cannot be combined with a header condition on it", key)
+				}
+				headers[key] = map[string]any{"exact": m.HttpHeader[name]}
+			}
+			entry["headers"] = headers
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// httpRoutes returns the HTTP routes (spec.http) of the VirtualService.
+func httpRoutes(virtualService *unstructured.Unstructured) []any {
+	routes, _, _ := unstructured.NestedSlice(virtualService.Object, "spec", "http")
+	return routes
+}
+
+// injectFaultRoutes prepends a fault-injected copy of every HTTP route of the VirtualService. Istio uses the first
+// route matching a request, so the copies receive the requests matched by the matcher and forward them to the same
+// destinations as the original routes. The copies are named namePrefix followed by their index.
+func injectFaultRoutes(virtualService *unstructured.Unstructured, namePrefix string, fault map[string]any, matcher extcommon.RequestMatcher) (bool, error) {
+	routes := httpRoutes(virtualService)
+	if len(routes) == 0 {
+		return false, fmt.Errorf("VirtualService %s/%s has no HTTP routes", virtualService.GetNamespace(), virtualService.GetName())
+	}
+	if slices.ContainsFunc(routes, func(route any) bool { return strings.HasPrefix(routeName(route), namePrefix) }) {
+		return false, nil
+	}
+
+	faultRoutes := make([]any, 0, len(routes))
+	for i, route := range routes {
+		faultRoute, ok := runtime.DeepCopyJSONValue(route).(map[string]any)
+		if !ok {
+			return false, fmt.Errorf("unexpected HTTP route %v", route)
+		}
+		matches, _ := faultRoute["match"].([]any)
+		matches, err := restrictMatches(matcher, matches)
+		if err != nil {
+			return false, fmt.Errorf("HTTP route %d: %w", i, err)
+		}
+		if len(matches) > 0 {
+			faultRoute["match"] = matches
+		}
+		faultRoute["name"] = fmt.Sprintf("%s%d", namePrefix, i)
+		faultRoute["fault"] = runtime.DeepCopyJSONValue(fault)
+		faultRoutes = append(faultRoutes, faultRoute)
+	}
+
+	if err := unstructured.SetNestedSlice(virtualService.Object, append(faultRoutes, routes...), "spec", "http"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeFaultRoutes removes the routes injected by injectFaultRoutes, restoring the original HTTP routes.
+func removeFaultRoutes(virtualService *unstructured.Unstructured, namePrefix string) bool {
+	routes := httpRoutes(virtualService)
+	remaining := slices.DeleteFunc(slices.Clone(routes), func(route any) bool {
+		return strings.HasPrefix(routeName(route), namePrefix)
+	})
+	if len(remaining) == len(routes) {
+		return false
+	}
+	_ = unstructured.SetNestedSlice(virtualService.Object, remaining, "spec", "http")
+	return true
+}
+
+// findConflictingRoute returns the name of a route injected by another attack, if any. ownPrefix is excluded from
+// the check, so re-running Start for the same execution is a no-op.
+func findConflictingRoute(virtualService *unstructured.Unstructured, ownPrefix string) string {
+	for _, route := range httpRoutes(virtualService) {
+		name := routeName(route)
+		if strings.HasPrefix(name, faultRouteNamePrefix) && !strings.HasPrefix(name, ownPrefix) {
+			return name
+		}
+	}
+	return ""
+}
+
+func routeName(route any) string {
+	if routeMap, ok := route.(map[string]any); ok {
+		name, _ := routeMap["name"].(string)
+		return name
+	}
+	return ""
+}
+
+func objectMetaFromUnstructured(obj *unstructured.Unstructured) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		Annotations: obj.GetAnnotations(),
+		Labels:      obj.GetLabels(),
+	}
+}
@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extistio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_buildFault(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(config map[string]any) (map[string]any, error)
+		config  map[string]any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:   "delay",
+			build:  buildDelayFault,
+			config: map[string]any{"delay": float64(500), "percentage": float64(25)},
+			want: map[string]any{
+				"delay": map[string]any{"fixedDelay": "500ms", "percentage": map[string]any{"value": float64(25)}},
+			},
+		},
+		{
+			name:    "delay without duration",
+			build:   buildDelayFault,
+			config:  map[string]any{"delay": float64(0)},
+			wantErr: true,
+		},
+		{
+			name:   "abort of all requests",
+			build:  buildAbortFault,
+			config: map[string]any{"statusCode": float64(503)},
+			want: map[string]any{
+				"abort": map[string]any{"httpStatus": int64(503), "percentage": map[string]any{"value": float64(100)}},
+			},
+		},
+		{
+			name:    "abort with a success status",
+			build:   buildAbortFault,
+			config:  map[string]any{"statusCode": float64(200)},
+			wantErr: true,
+		},
+		{
+			name:    "abort of no requests",
+			build:   buildAbortFault,
+			config:  map[string]any{"statusCode": float64(503), "percentage": float64(0)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fault, err := tt.build(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, fault)
+		})
+	}
+}
+
+func virtualService(namespace, name string, routes ...any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "networking.istio.io/v1beta1", "kind": "VirtualService",
+		"metadata": map[string]any{"name": name, "namespace": namespace},
+		"spec": map[string]any{
+			"hosts":    []any{"reviews.default.svc.cluster.local", "reviews"},
+			"gateways": []any{"mesh"},
+			"http":     routes,
+		},
+	}}
+}
+
+func destinationRoute(name, host string, match ...any) map[string]any {
+	route := map[string]any{
+		"route": []any{map[string]any{"destination": map[string]any{"host": host}}},
+	}
+	if name != "" {
+		route["name"] = name
+	}
+	if len(match) > 0 {
+		route["match"] = match
+	}
+	return route
+}
+
+var delayFault = map[string]any{"delay": map[string]any{"fixedDelay": "500ms", "percentage": map[string]any{"value": float64(100)}}}
+
+func Test_injectFaultRoutes(t *testing.T) {
+	vs := virtualService("default", "reviews",
+		destinationRoute("v2", "reviews-v2", map[string]any{"headers": map[string]any{"end-user": map[string]any{"exact": "jason"}}}),
+		destinationRoute("", "reviews-v1"),
+	)
+
+	changed, err := injectFaultRoutes(vs, "steadybit-delay-1-", delayFault, extcommon.RequestMatcher{HttpHeader: map[string]string{"X-Chaos": "on"}})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	routes := httpRoutes(vs)
+	require.Len(t, routes, 4)
+	assert.Equal(t, map[string]any{
+		"name":  "steadybit-delay-1-0",
+		"fault": delayFault,
+		"route": []any{map[string]any{"destination": map[string]any{"host": "reviews-v2"}}},
+		"match": []any{map[string]any{"headers": map[string]any{
+			"end-user": map[string]any{"exact": "jason"},
+			"x-chaos":  map[string]any{"exact": "on"},
+		}}},
+	}, routes[0])
+	assert.Equal(t, map[string]any{
+		"name":  "steadybit-delay-1-1",
+		"fault": delayFault,
+		"route": []any{map[string]any{"destination": map[string]any{"host": "reviews-v1"}}},
+		"match": []any{map[string]any{"headers": map[string]any{"x-chaos": map[string]any{"exact": "on"}}}},
+	}, routes[1])
+	assert.Equal(t, "v2", routeName(routes[2]))
+	assert.NotContains(t, routes[2].(map[string]any)["match"].([]any)[0].(map[string]any)["headers"], "x-chaos", "original route must not be modified")
+
+	changed, err = injectFaultRoutes(vs, "steadybit-delay-1-", delayFault, extcommon.RequestMatcher{})
+	require.NoError(t, err)
+	assert.False(t, changed, "injecting twice should be a no-op")
+
+	assert.True(t, removeFaultRoutes(vs, "steadybit-delay-1-"))
+	assert.Equal(t, []any{
+		destinationRoute("v2", "reviews-v2", map[string]any{"headers": map[string]any{"end-user": map[string]any{"exact": "jason"}}}),
+		destinationRoute("", "reviews-v1"),
+	}, httpRoutes(vs))
+	assert.False(t, removeFaultRoutes(vs, "steadybit-delay-1-"))
+}
+
+func Test_injectFaultRoutes_rejectsUncombinableConditions(t *testing.T) {
+	tests := []struct {
+		name           string
+		virtualService *unstructured.Unstructured
+		matcher        extcommon.RequestMatcher
+		wantErr        string
+	}{
+		{
+			name:           "path condition on a route matching on the URI",
+			virtualService: virtualService("default", "reviews", destinationRoute("api", "reviews-v1", map[string]any{"uri": map[string]any{"prefix": "/api"}})),
+			matcher:        extcommon.RequestMatcher{PathPattern: "/api/.*"},
+			wantErr:        "already matches on the URI",
+		},
+		{
+			name:           "header condition on a header the route matches on",
+			virtualService: virtualService("default", "reviews", destinationRoute("v2", "reviews-v2", map[string]any{"headers": map[string]any{"end-user": map[string]any{"exact": "jason"}}})),
+			matcher:        extcommon.RequestMatcher{HttpHeader: map[string]string{"End-User": "bob"}},
+			wantErr:        "already matches on header \"end-user\"",
+		},
+		{
+			name:           "VirtualService without HTTP routes",
+			virtualService: virtualService("default", "empty"),
+			wantErr:        "has no HTTP routes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := injectFaultRoutes(tt.virtualService, "steadybit-delay-1-", delayFault, tt.matcher)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_findConflictingRoute(t *testing.T) {
+	vs := virtualService("default", "reviews", destinationRoute("steadybit-abort-2-0", "reviews-v1"), destinationRoute("", "reviews-v1"))
+
+	tests := []struct {
+		name       string
+		namePrefix string
+		want       string
+	}{
+		{name: "route of another attack", namePrefix: "steadybit-delay-1-", want: "steadybit-abort-2-0"},
+		{name: "own route", namePrefix: "steadybit-abort-2-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, findConflictingRoute(vs, tt.namePrefix))
+		})
+	}
+}
+
+func getTestClient(stopCh <-chan struct{}) (*client.Client, dynamic.Interface) {
+	extconfig.Config.DiscoveryDisabledIstio = false
+	extconfig.Config.ClusterName = "test-cluster"
+
+	dynamicClient := testutil.NewFakeDynamicClient()
+	k8sClient := client.CreateClient(testclient.NewSimpleClientset(), stopCh, "", client.MockAllPermitted(), dynamicClient)
+	k8sClient.Distribution = "kubernetes"
+	return k8sClient, dynamicClient
+}
+
+func create(t *testing.T, dc dynamic.Interface, obj *unstructured.Unstructured) {
+	t.Helper()
+	_, err := dc.Resource(client.IstioVirtualServiceGVR).Namespace(obj.GetNamespace()).Create(context.Background(), obj, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func Test_virtualServiceDiscovery(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+	create(t, dc, virtualService("default", "reviews",
+		destinationRoute("v2", "reviews-v2", map[string]any{"headers": map[string]any{"end-user": map[string]any{"exact": "jason"}}}),
+		destinationRoute("", "reviews-v1"),
+	))
+	create(t, dc, virtualService("default", "tcp-only"))
+
+	discovery := &virtualServiceDiscovery{k8s: k8sClient}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		targets, err := discovery.DiscoverTargets(context.Background())
+		assert.NoError(c, err)
+		require.Len(c, targets, 1)
+
+		target := targets[0]
+		assert.Equal(c, IstioVirtualServiceTargetType, target.TargetType)
+		assert.Equal(c, "test-cluster/default/reviews", target.Id)
+		assert.Equal(c, []string{"reviews"}, target.Attributes[attrVirtualService])
+		assert.Equal(c, []string{"reviews", "reviews.default.svc.cluster.local"}, target.Attributes["k8s.istio.virtual-service.host"])
+		assert.Equal(c, []string{"mesh"}, target.Attributes["k8s.istio.gateway"])
+		assert.Equal(c, []string{"v2"}, target.Attributes["k8s.istio.virtual-service.route"])
+		assert.Equal(c, []string{"reviews-v1", "reviews-v2"}, target.Attributes["k8s.istio.destination-service"])
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func newAbortRequest(executionId uuid.UUID, config map[string]any) action_kit_api.PrepareActionRequestBody {
+	config["duration"] = float64(30000)
+	config["statusCode"] = float64(503)
+	return action_kit_api.PrepareActionRequestBody{
+		ExecutionId: executionId,
+		Config:      config,
+		Target: new(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":             {"default"},
+				"k8s.istio.virtual-service": {"reviews"},
+			},
+		}),
+	}
+}
+
+func Test_action_lifecycle(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+	original := virtualService("default", "reviews", destinationRoute("", "reviews-v1"))
+	create(t, dc, original)
+
+	action := NewAbortAction(k8sClient).(*faultRouteAction)
+	executionId := uuid.New()
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, newAbortRequest(executionId, map[string]any{"conditionPathPattern": "/ratings/.*"}))
+	require.NoError(t, err)
+	assert.Equal(t, "steadybit-abort-"+executionId.String()+"-", state.RouteNamePrefix)
+
+	_, err = action.Start(context.Background(), &state)
+	require.NoError(t, err)
+
+	vs, err := k8sClient.GetIstioVirtualService(context.Background(), "default", "reviews")
+	require.NoError(t, err)
+	routes := httpRoutes(vs)
+	require.Len(t, routes, 2)
+	assert.Equal(t, state.RouteNamePrefix+"0", routeName(routes[0]))
+	assert.Equal(t, []any{map[string]any{"uri": map[string]any{"regex": "/ratings/.*"}}}, routes[0].(map[string]any)["match"])
+	assert.Equal(t, int64(503), routes[0].(map[string]any)["fault"].(map[string]any)["abort"].(map[string]any)["httpStatus"])
+
+	// A second attack on the same VirtualService is rejected
+	other := action.NewEmptyState()
+	_, err = action.Prepare(context.Background(), &other, newAbortRequest(uuid.New(), map[string]any{}))
+	assert.ErrorContains(t, err, "another attack is already running")
+
+	_, err = action.Stop(context.Background(), &state)
+	require.NoError(t, err)
+
+	vs, err = k8sClient.GetIstioVirtualService(context.Background(), "default", "reviews")
+	require.NoError(t, err)
+	assert.Equal(t, httpRoutes(original), httpRoutes(vs))
+}
+
+func Test_action_prepare_failsOnInvalidConditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{
+			name:    "path condition on a route matching on the URI",
+			config:  map[string]any{"conditionPathPattern": "/api/.*"},
+			wantErr: "already matches on the URI",
+		},
+		{
+			name:    "invalid path pattern",
+			config:  map[string]any{"conditionPathPattern": "/api/("},
+			wantErr: "invalid path pattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			k8sClient, dc := getTestClient(stopCh)
+			create(t, dc, virtualService("default", "reviews", destinationRoute("api", "reviews-v1", map[string]any{"uri": map[string]any{"prefix": "/api"}})))
+
+			action := NewAbortAction(k8sClient).(*faultRouteAction)
+			state := action.NewEmptyState()
+			_, err := action.Prepare(context.Background(), &state, newAbortRequest(uuid.New(), tt.config))
+			assert.ErrorContains(t, err, tt.wantErr)
+
+			vs, err := k8sClient.GetIstioVirtualService(context.Background(), "default", "reviews")
+			require.NoError(t, err)
+			assert.Len(t, httpRoutes(vs), 1, "prepare must not modify the VirtualService")
+		})
+	}
+}
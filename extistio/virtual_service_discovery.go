@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extistio
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type virtualServiceDiscovery struct {
+	k8s     *client.Client
+	targets *extcommon.ResultCache[discovery_kit_api.Target]
+}
+
+var _ discovery_kit_sdk.TargetDescriber = (*virtualServiceDiscovery)(nil)
+
+func NewVirtualServiceDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &virtualServiceDiscovery{
+		k8s:     k8s,
+		targets: extcommon.NewResultCache[discovery_kit_api.Target](),
+	}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+		reflect.TypeFor[unstructured.Unstructured](),
+	)
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, time.Duration(extconfig.Config.DiscoveryRefreshThrottle)*time.Second),
+	)
+}
+
+func (d *virtualServiceDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: IstioVirtualServiceTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("30s"),
+		},
+	}
+}
+
+func (d *virtualServiceDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       IstioVirtualServiceTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Istio Virtual Service", Other: "Istio Virtual Services"},
+		Category: new("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(IstioIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: attrVirtualService},
+				{Attribute: "k8s.istio.virtual-service.host"},
+				{Attribute: "k8s.istio.destination-service"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{Attribute: attrVirtualService, Direction: "ASC"},
+			},
+		},
+	}
+}
+
+func (d *virtualServiceDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	defer d.targets.EndRun()
+
+	var targets []discovery_kit_api.Target
+	for _, virtualService := range d.k8s.IstioVirtualServices() {
+		// Only HTTP routes support fault injection, TCP and TLS-only VirtualServices cannot be attacked.
+		if client.IsExcludedFromDiscovery(objectMetaFromUnstructured(virtualService)) || len(httpRoutes(virtualService)) == 0 {
+			continue
+		}
+		fingerprint := extcommon.Fingerprints(extcommon.Fingerprint(virtualService), extcommon.NamespaceFingerprint(d.k8s, virtualService.GetNamespace()))
+		targets = append(targets, d.targets.Get(string(virtualService.GetUID()), fingerprint, func() discovery_kit_api.Target {
+			return d.toTarget(virtualService)
+		}))
+	}
+	return targets, nil
+}
+
+func (d *virtualServiceDiscovery) toTarget(virtualService *unstructured.Unstructured) discovery_kit_api.Target {
+	namespace := virtualService.GetNamespace()
+	name := virtualService.GetName()
+
+	attributes := map[string][]string{
+		"k8s.namespace":    {namespace},
+		"k8s.cluster-name": {extconfig.Config.ClusterName},
+		"k8s.distribution": {d.k8s.Distribution},
+		attrVirtualService: {name},
+	}
+
+	if hosts, found, err := unstructured.NestedStringSlice(virtualService.Object, "spec", "hosts"); err == nil && found && len(hosts) > 0 {
+		attributes["k8s.istio.virtual-service.host"] = extcommon.SortDedup(hosts)
+	}
+	if gateways, found, err := unstructured.NestedStringSlice(virtualService.Object, "spec", "gateways"); err == nil && found && len(gateways) > 0 {
+		attributes["k8s.istio.gateway"] = extcommon.SortDedup(gateways)
+	}
+
+	var routeNames, destinations []string
+	for _, route := range httpRoutes(virtualService) {
+		// Skip the routes injected by a running attack, so the attributes do not change during the attack.
+		name := routeName(route)
+		if strings.HasPrefix(name, faultRouteNamePrefix) {
+			continue
+		}
+		if name != "" {
+			routeNames = append(routeNames, name)
+		}
+		destinations = append(destinations, destinationHosts(route)...)
+	}
+	if len(routeNames) > 0 {
+		attributes["k8s.istio.virtual-service.route"] = extcommon.SortDedup(routeNames)
+	}
+	if len(destinations) > 0 {
+		attributes["k8s.istio.destination-service"] = extcommon.SortDedup(destinations)
+	}
+
+	extcommon.AddLabels(attributes, virtualService.GetLabels(), "k8s.istio.virtual-service.label", "k8s.label")
+	extcommon.AddNamespaceLabels(attributes, d.k8s, namespace)
+
+	target := discovery_kit_api.Target{
+		Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, namespace, name),
+		TargetType: IstioVirtualServiceTargetType,
+		Label:      name,
+		Attributes: attributes,
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes([]discovery_kit_api.Target{target}, extconfig.Config.DiscoveryAttributesExcludesIstio)[0]
+}
+
+// destinationHosts returns the destination hosts (route[].destination.host) of an HTTP route.
+func destinationHosts(route any) []string {
+	routeMap, ok := route.(map[string]any)
+	if !ok {
+		return nil
+	}
+	weighted, _ := routeMap["route"].([]any)
+	var hosts []string
+	for _, destination := range weighted {
+		destinationMap, ok := destination.(map[string]any)
+		if !ok {
+			continue
+		}
+		if host, found, err := unstructured.NestedString(destinationMap, "destination", "host"); err == nil && found && host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcluster"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const ClusterResourceUsageMetricsActionId = "com.steadybit.extension_kubernetes.resource_usage_metrics"
+
+type ResourceUsageMetricsAction struct {
+	actionId           string
+	targetType         string
+	targetTypeLabel    string
+	selectionTemplates *action_kit_api.TargetSelectionTemplates
+	// workload resolves the workload whose pods are reported. The cluster-wide action reports all pods and nodes.
+	workload func(request action_kit_api.PrepareActionRequestBody) *Workload
+}
+
+type ResourceUsageMetricsState struct {
+	End       time.Time
+	Namespace string
+	Workload  *Workload
+	// LastTimestamps holds the sample timestamp of the last reported metrics per pod/node, metrics-server only
+	// scrapes every 15s by default, unchanged samples are not reported again.
+	LastTimestamps map[string]time.Time
+	// UnavailableReported is set once the user was told that metrics-server is missing.
+	UnavailableReported bool
+}
+
+type ResourceUsageMetricsConfig struct {
+	Duration  int
+	Namespace string
+}
+
+func NewClusterResourceUsageMetricsAction() action_kit_sdk.Action[ResourceUsageMetricsState] {
+	return ResourceUsageMetricsAction{
+		actionId:        ClusterResourceUsageMetricsActionId,
+		targetType:      extcluster.ClusterTargetType,
+		targetTypeLabel: "Cluster",
+		selectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+			{
+				Label:       "cluster name",
+				Description: new("Find cluster by name"),
+				Query:       "k8s.cluster-name=\"\"",
+			},
+		}),
+	}
+}
+
+var _ action_kit_sdk.Action[ResourceUsageMetricsState] = (*ResourceUsageMetricsAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[ResourceUsageMetricsState] = (*ResourceUsageMetricsAction)(nil)
+
+func (f ResourceUsageMetricsAction) NewEmptyState() ResourceUsageMetricsState {
+	return ResourceUsageMetricsState{}
+}
+
+func (f ResourceUsageMetricsAction) Describe() action_kit_api.ActionDescription {
+	parameters := []action_kit_api.ActionParameter{
+		{
+			Name:         "duration",
+			Label:        "Duration",
+			Description:  new(""),
+			Type:         action_kit_api.ActionParameterTypeDuration,
+			DefaultValue: new("60s"),
+			Order:        new(1),
+			Required:     new(true),
+		},
+	}
+	if f.workload == nil {
+		parameters = append(parameters, action_kit_api.ActionParameter{
+			Name:        "namespace",
+			Label:       "Namespace",
+			Description: new("Only collect metrics of pods in this namespace. Collects metrics of all namespaces if empty."),
+			Type:        action_kit_api.ActionParameterTypeString,
+			Order:       new(2),
+			Required:    new(false),
+			Advanced:    new(true),
+		})
+	}
+
+	return action_kit_api.ActionDescription{
+		Id:          f.actionId,
+		Label:       f.targetTypeLabel + " Resource Usage Metrics",
+		Description: "Collects the CPU and memory usage of pods and nodes as reported by the metrics.k8s.io API (metrics-server).",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new("data:image/svg+xml;base64,PHN2ZyB3aWR0aD0iMjQiIGhlaWdodD0iMjQiIHZpZXdCb3g9IjAgMCAyNCAyNCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KPHBhdGggZmlsbC1ydWxlPSJldmVub2RkIiBjbGlwLXJ1bGU9ImV2ZW5vZGQiIGQ9Ik0xMC40NSAyLjMyTDQuNjYgNS4yMlY1LjIxQzQuNjMyMTYgNS4yMjU5MSA0LjYwNDMzIDUuMjQwMjMgNC41NzcxMiA1LjI1NDIzQzQuNTM1OTEgNS4yNzU0NCA0LjQ5NjE0IDUuMjk1OTEgNC40NiA1LjMyTDcuOTMgNy4yNUM5IDYuMjYgMTAuNDMgNS42NiAxMiA1LjY2QzEzLjU3IDUuNjYgMTUgNi4yNiAxNi4wNyA3LjI1TDE5LjUgNS4zNUMxOS40MyA1LjMxIDE5LjM2IDUuMjcgMTkuMjkgNS4yNEwxMy4wOCAyLjI5QzEyLjI1IDEuOSAxMS4yOCAxLjkxIDEwLjQ1IDIuMzJaTTYuNjg4MTggOC44NTM0NEw2LjcgOC44Nkw2LjY5IDguODVDNi42ODkzOSA4Ljg1MTE1IDYuNjg4NzkgOC44NTIyOSA2LjY4ODE4IDguODUzNDRaTTYuNjg4MTggOC44NTM0NEwzLjE3IDYuOUMzLjA2IDcuMjIgMyA3LjU2IDMgNy45VjE1LjQyQzMgMTYuNTYgMy42NCAxNy41OSA0LjY2IDE4LjFMMTAuNDUgMjFDMTAuNjMgMjEuMDkgMTAuODEgMjEuMTYgMTEgMjEuMjFWMTcuNTdDOC4xNiAxNy4wOSA2IDE0LjYzIDYgMTEuNjVDNiAxMC42NDE0IDYuMjQ5MzEgOS42ODI2NSA2LjY4ODE4IDguODUzNDRaTTEzLjAxIDIxLjA3VjE3LjU4TDEzIDE3LjU3QzE1Ljg0IDE3LjA5IDE4IDE0LjYyIDE4IDExLjY1QzE4IDEwLjY0IDE3Ljc1IDkuNjkgMTcuMzEgOC44NUwyMC44MiA2LjlDMjAuOTUgNy4yMyAyMS4wMSA3LjU4IDIxLjAxIDcuOTRWMTUuMzdDMjEuMDEgMTYuNTMgMjAuMzUgMTcuNTggMTkuMyAxOC4wOEwxMy4wOSAyMS4wM0MxMy4wNiAyMS4wNSAxMy4wMSAyMS4wNyAxMy4wMSAyMS4wN1pNMTQuMTIgMTIuMDRIMTUuNjRMMTUuNjUgMTIuMDNDMTUuOTYgMTIuMDMgMTYuMjEgMTIuMjUgMTYuMjEgMTIuNTNDMTYuMjEgMTIuODEgMTUuOTYgMTMuMDMgMTUuNjUgMTMuMDNIMTQuNDVMMTMuNDMgMTQuNkMxMy4zMyAxNC43NiAxMy4xNCAxNC44NSAxMi45NCAxNC44NUgxMi45QzEyLjY4IDE0Ljg0IDEyLjQ5IDE0LjcxIDEyLjQxIDE0LjUzTDExLjA2IDExLjMzTDEwLjQyIDEyLjczQzEwLjM0IDEyLjkyIDEwLjEzIDEzLjA0IDkuOTAwMDEgMTMuMDRIOC4zODAwMUM4LjA3MDAxIDEzLjA0IDcuODIwMDEgMTIuODEgNy44MjAwMSAxMi41NEM3LjgyMDAxIDEyLjI3IDguMDcwMDEgMTIuMDQgOC4zODAwMSAxMi4wNEg5LjUyMDAxTDEwLjU2IDkuNzdDMTAuNjUgOS41OCAxMC44NSA5LjQ2IDExLjA4IDkuNDZDMTEuMzEgOS40NiAxMS41MiA5LjU5IDExLjYgOS43OEwxMy4wNCAxMy4xOUwxMy42MyAxMi4yOUMxMy43MyAxMi4xNCAxMy45MiAxMi4wNCAxNC4xMiAxMi4wNFoiIGZpbGw9IiMxRDI2MzIiLz4KPC9zdmc+Cg=="),
+		Technology:  new("Kubernetes"),
+
+		Kind:        action_kit_api.Other,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType:          f.targetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.QuantityRestrictionExactlyOne),
+			SelectionTemplates:  f.selectionTemplates,
+		}),
+		Parameters: parameters,
+		Widgets: new([]action_kit_api.Widget{
+			resourceUsageWidget("Pod CPU Usage (millicores)", "pod_cpu_usage_millicores", "k8s.pod.name"),
+			resourceUsageWidget("Pod Memory Usage (bytes)", "pod_memory_usage_bytes", "k8s.pod.name"),
+			resourceUsageWidget("Node CPU Usage (millicores)", "node_cpu_usage_millicores", "k8s.node.name"),
+			resourceUsageWidget("Node Memory Usage (bytes)", "node_memory_usage_bytes", "k8s.node.name"),
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+	}
+}
+
+// resourceUsageWidget charts one of the usage metrics, a line per pod or node identified by the given label.
+func resourceUsageWidget(title, metricName, identity string) action_kit_api.LineChartWidget {
+	return action_kit_api.LineChartWidget{
+		Type:  action_kit_api.ComSteadybitWidgetLineChart,
+		Title: title,
+		Identity: action_kit_api.LineChartWidgetIdentityConfig{
+			MetricName: metricName,
+			From:       identity,
+			Mode:       action_kit_api.ComSteadybitWidgetLineChartIdentityModeSelect,
+		},
+		Tooltip: new(action_kit_api.LineChartWidgetTooltipConfig{
+			MetricValueTitle: new(title),
+			AdditionalContent: []action_kit_api.LineChartWidgetTooltipContent{
+				{From: "k8s.namespace", Title: "Namespace"},
+				{From: "k8s.node.name", Title: "Node"},
+			},
+		}),
+	}
+}
+
+func (f ResourceUsageMetricsAction) Prepare(_ context.Context, state *ResourceUsageMetricsState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config ResourceUsageMetricsConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.End = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.LastTimestamps = make(map[string]time.Time)
+	if f.workload != nil {
+		state.Workload = f.workload(request)
+		state.Namespace = state.Workload.Namespace
+	} else {
+		state.Namespace = strings.TrimSpace(config.Namespace)
+	}
+	return nil, nil
+}
+
+func (f ResourceUsageMetricsAction) Start(_ context.Context, _ *ResourceUsageMetricsState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f ResourceUsageMetricsAction) Status(ctx context.Context, state *ResourceUsageMetricsState) (*action_kit_api.StatusResult, error) {
+	return statusResourceUsageMetricsInternal(ctx, client.K8S, state), nil
+}
+
+func statusResourceUsageMetricsInternal(ctx context.Context, k8s *client.Client, state *ResourceUsageMetricsState) *action_kit_api.StatusResult {
+	now := time.Now()
+	result := &action_kit_api.StatusResult{
+		Completed: now.After(state.End),
+	}
+
+	podUsages, err := k8s.PodMetrics(ctx, state.Namespace)
+	if err != nil {
+		result.Messages = state.reportError(err)
+		return result
+	}
+
+	var pods []*corev1.Pod
+	if state.Workload != nil {
+		pods = state.Workload.pods(k8s)
+	} else {
+		pods = k8s.Pods()
+	}
+	podsByKey := make(map[string]*corev1.Pod, len(pods))
+	nodeNames := make(map[string]bool)
+	for _, pod := range pods {
+		if state.Namespace != "" && pod.Namespace != state.Namespace {
+			continue
+		}
+		podsByKey[pod.Namespace+"/"+pod.Name] = pod
+		nodeNames[pod.Spec.NodeName] = true
+	}
+
+	var metrics []action_kit_api.Metric
+	for _, usage := range podUsages {
+		pod, ok := podsByKey[usage.Namespace+"/"+usage.Name]
+		if !ok && state.Workload != nil {
+			continue
+		}
+		if !state.hasNewSample("pod/"+usage.Namespace+"/"+usage.Name, usage.Timestamp) {
+			continue
+		}
+		labels := map[string]string{}
+		if pod != nil {
+			labels["k8s.node.name"] = pod.Spec.NodeName
+		}
+		if state.Workload != nil {
+			labels[state.Workload.labelKey()] = state.Workload.Name
+		}
+		metrics = append(metrics, extcommon.BuildPodResourceUsageMetrics(usage, labels)...)
+	}
+
+	// Nodes are cluster-scoped and not visible when the extension is restricted to a namespace.
+	if !extconfig.HasNamespaceFilter() {
+		nodeUsages, err := k8s.NodeMetrics(ctx)
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to fetch node metrics")
+		}
+		for _, usage := range nodeUsages {
+			if (state.Workload != nil || state.Namespace != "") && !nodeNames[usage.Name] {
+				continue
+			}
+			if !state.hasNewSample("node/"+usage.Name, usage.Timestamp) {
+				continue
+			}
+			metrics = append(metrics, extcommon.BuildNodeResourceUsageMetrics(usage)...)
+		}
+	}
+
+	result.Metrics = new(metrics)
+	return result
+}
+
+func (s *ResourceUsageMetricsState) hasNewSample(key string, timestamp time.Time) bool {
+	if last, ok := s.LastTimestamps[key]; ok && !timestamp.After(last) {
+		return false
+	}
+	s.LastTimestamps[key] = timestamp
+	return true
+}
+
+// reportError degrades gracefully if metrics-server is missing: the user is informed once, the action keeps running.
+func (s *ResourceUsageMetricsState) reportError(err error) *action_kit_api.Messages {
+	if !errors.Is(err, client.ErrMetricsUnavailable) {
+		log.Warn().Err(err).Msg("Failed to fetch pod metrics")
+		return nil
+	}
+	if s.UnavailableReported {
+		return nil
+	}
+	s.UnavailableReported = true
+	log.Warn().Err(err).Msg("Pod metrics are not available")
+	return new([]action_kit_api.Message{
+		{
+			Message: "No resource usage metrics available: " + err.Error(),
+			Level:   extutil.Ptr(action_kit_api.Warn),
+		},
+	})
+}
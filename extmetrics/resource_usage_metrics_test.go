@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+var sampleTime = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func TestPrepareResolvesWorkload(t *testing.T) {
+	// Given
+	action := NewDeploymentResourceUsageMetricsAction()
+	state := action.NewEmptyState()
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"duration": 1000 * 60,
+		},
+		Target: &action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		},
+	}
+
+	// When
+	result, err := action.Prepare(context.TODO(), &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.True(t, state.End.After(time.Now()))
+	require.Equal(t, &Workload{Kind: "Deployment", Namespace: "shop", Name: "checkout"}, state.Workload)
+	require.Equal(t, "shop", state.Namespace)
+}
+
+func TestStatusReturnsPodAndNodeMetrics(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	dynamicClient := testutil.NewFakeDynamicClient()
+	createPodMetrics(t, dynamicClient, "shop", "checkout-1", "250m", "128Mi")
+	createPodMetrics(t, dynamicClient, "shop", "cart-1", "100m", "64Mi")
+	createNodeMetrics(t, dynamicClient, "node-1", "1500m", "2Gi")
+	createNodeMetrics(t, dynamicClient, "node-2", "500m", "1Gi")
+
+	k8s := client.CreateClient(testclient.NewClientset(
+		runningPod("shop", "checkout-1", "node-1"),
+		runningPod("shop", "cart-1", "node-1"),
+	), stopCh, "", client.MockAllPermitted(), dynamicClient)
+	state := ResourceUsageMetricsState{End: time.Now().Add(time.Minute), LastTimestamps: map[string]time.Time{}}
+
+	// When
+	result := statusResourceUsageMetricsInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	metrics := metricsByName(*result.Metrics)
+	require.Len(t, *result.Metrics, 8)
+	require.Equal(t, float64(250), metrics["pod_cpu_usage_millicores/checkout-1"].Value)
+	require.Equal(t, float64(128*1024*1024), metrics["pod_memory_usage_bytes/checkout-1"].Value)
+	require.Equal(t, map[string]string{
+		"k8s.cluster-name": "development",
+		"k8s.namespace":    "shop",
+		"k8s.pod.name":     "checkout-1",
+		"k8s.node.name":    "node-1",
+	}, metrics["pod_cpu_usage_millicores/checkout-1"].Metric)
+	require.Equal(t, float64(1500), metrics["node_cpu_usage_millicores/node-1"].Value)
+	require.Equal(t, sampleTime, metrics["node_cpu_usage_millicores/node-1"].Timestamp)
+
+	// unchanged samples are not reported again
+	result = statusResourceUsageMetricsInternal(context.Background(), k8s, &state)
+	require.Empty(t, *result.Metrics)
+}
+
+func TestStatusReturnsOnlyMetricsOfWorkload(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	dynamicClient := testutil.NewFakeDynamicClient()
+	createPodMetrics(t, dynamicClient, "shop", "checkout-1", "250m", "128Mi")
+	createPodMetrics(t, dynamicClient, "shop", "cart-1", "100m", "64Mi")
+	createNodeMetrics(t, dynamicClient, "node-1", "1500m", "2Gi")
+	createNodeMetrics(t, dynamicClient, "node-2", "500m", "1Gi")
+
+	checkout := runningPod("shop", "checkout-1", "node-1")
+	checkout.OwnerReferences = []metav1.OwnerReference{{Kind: "StatefulSet", Name: "checkout", UID: "sts-uid"}}
+	k8s := client.CreateClient(testclient.NewClientset(
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop", UID: "sts-uid"}},
+		checkout,
+		runningPod("shop", "cart-1", "node-2"),
+	), stopCh, "", client.MockAllPermitted(), dynamicClient)
+	state := ResourceUsageMetricsState{
+		End:            time.Now().Add(time.Minute),
+		Namespace:      "shop",
+		Workload:       &Workload{Kind: "StatefulSet", Namespace: "shop", Name: "checkout"},
+		LastTimestamps: map[string]time.Time{},
+	}
+
+	// When
+	result := statusResourceUsageMetricsInternal(context.Background(), k8s, &state)
+
+	// Then
+	metrics := metricsByName(*result.Metrics)
+	require.Len(t, *result.Metrics, 4)
+	require.Contains(t, metrics, "pod_cpu_usage_millicores/checkout-1")
+	require.Equal(t, "checkout", metrics["pod_cpu_usage_millicores/checkout-1"].Metric["k8s.statefulset"])
+	require.Contains(t, metrics, "node_cpu_usage_millicores/node-1")
+	require.NotContains(t, metrics, "node_cpu_usage_millicores/node-2")
+}
+
+func TestStatusDegradesGracefullyWithoutMetricsServer(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	dynamicClient := testutil.NewFakeDynamicClient()
+	dynamicClient.PrependReactor("list", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if action.GetResource().Group != client.MetricsGroup {
+			return false, nil, nil
+		}
+		return true, nil, k8sErrors.NewNotFound(client.PodMetricsGVR.GroupResource(), "")
+	})
+	k8s := client.CreateClient(testclient.NewClientset(), stopCh, "", client.MockAllPermitted(), dynamicClient)
+	state := ResourceUsageMetricsState{End: time.Now().Add(time.Minute), LastTimestamps: map[string]time.Time{}}
+
+	// When
+	result := statusResourceUsageMetricsInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+	require.Len(t, *result.Messages, 1)
+	require.Equal(t, action_kit_api.Warn, *(*result.Messages)[0].Level)
+
+	// the warning is only reported once
+	result = statusResourceUsageMetricsInternal(context.Background(), k8s, &state)
+	require.Nil(t, result.Messages)
+}
+
+func runningPod(namespace, name, node string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func createPodMetrics(t *testing.T, dynamicClient *fake.FakeDynamicClient, namespace, name, cpu, memory string) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"kind":       "PodMetrics",
+		"metadata":   map[string]any{"name": name, "namespace": namespace},
+		"timestamp":  sampleTime.Format(time.RFC3339),
+		"window":     "15s",
+		"containers": []any{
+			map[string]any{"name": "main", "usage": map[string]any{"cpu": cpu, "memory": memory}},
+		},
+	}}
+	_, err := dynamicClient.Resource(client.PodMetricsGVR).Namespace(namespace).Create(context.Background(), obj, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func createNodeMetrics(t *testing.T, dynamicClient *fake.FakeDynamicClient, name, cpu, memory string) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"kind":       "NodeMetrics",
+		"metadata":   map[string]any{"name": name},
+		"timestamp":  sampleTime.Format(time.RFC3339),
+		"window":     "15s",
+		"usage":      map[string]any{"cpu": cpu, "memory": memory},
+	}}
+	_, err := dynamicClient.Resource(client.NodeMetricsGVR).Create(context.Background(), obj, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func metricsByName(metrics []action_kit_api.Metric) map[string]action_kit_api.Metric {
+	result := make(map[string]action_kit_api.Metric)
+	for _, m := range metrics {
+		name := m.Metric["k8s.pod.name"]
+		if name == "" {
+			name = m.Metric["k8s.node.name"]
+		}
+		result[*m.Name+"/"+name] = m
+	}
+	return result
+}
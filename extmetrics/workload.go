@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extdaemonset"
+	"github.com/steadybit/extension-kubernetes/v2/extdeployment"
+	"github.com/steadybit/extension-kubernetes/v2/extstatefulset"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	DeploymentResourceUsageMetricsActionId  = "com.steadybit.extension_kubernetes.resource_usage_metrics_deployment"
+	StatefulSetResourceUsageMetricsActionId = "com.steadybit.extension_kubernetes.resource_usage_metrics_statefulset"
+	DaemonSetResourceUsageMetricsActionId   = "com.steadybit.extension_kubernetes.resource_usage_metrics_daemonset"
+)
+
+// Workload identifies the workload whose pods (and the nodes they run on) are reported.
+type Workload struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func NewDeploymentResourceUsageMetricsAction() action_kit_sdk.Action[ResourceUsageMetricsState] {
+	return newWorkloadResourceUsageMetricsAction(DeploymentResourceUsageMetricsActionId, extdeployment.DeploymentTargetType, "Deployment", "k8s.deployment")
+}
+
+func NewStatefulSetResourceUsageMetricsAction() action_kit_sdk.Action[ResourceUsageMetricsState] {
+	return newWorkloadResourceUsageMetricsAction(StatefulSetResourceUsageMetricsActionId, extstatefulset.StatefulSetTargetType, "StatefulSet", "k8s.statefulset")
+}
+
+func NewDaemonSetResourceUsageMetricsAction() action_kit_sdk.Action[ResourceUsageMetricsState] {
+	return newWorkloadResourceUsageMetricsAction(DaemonSetResourceUsageMetricsActionId, extdaemonset.DaemonSetTargetType, "DaemonSet", "k8s.daemonset")
+}
+
+func newWorkloadResourceUsageMetricsAction(actionId, targetType, kind, nameAttribute string) ResourceUsageMetricsAction {
+	return ResourceUsageMetricsAction{
+		actionId:        actionId,
+		targetType:      targetType,
+		targetTypeLabel: kind,
+		selectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+			{
+				Label:       strings.ToLower(kind),
+				Description: new("Find " + kind + " by cluster, namespace and name"),
+				Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND " + nameAttribute + "=\"\"",
+			},
+		}),
+		workload: func(request action_kit_api.PrepareActionRequestBody) *Workload {
+			return &Workload{
+				Kind:      kind,
+				Namespace: request.Target.Attributes["k8s.namespace"][0],
+				Name:      request.Target.Attributes[nameAttribute][0],
+			}
+		},
+	}
+}
+
+func (w *Workload) labelKey() string {
+	return "k8s." + strings.ToLower(w.Kind)
+}
+
+func (w *Workload) pods(k8s *client.Client) []*corev1.Pod {
+	switch w.Kind {
+	case "Deployment":
+		if d := k8s.DeploymentByNamespaceAndName(w.Namespace, w.Name); d != nil {
+			return k8s.PodsOwnedByDeployment(d.UID, d.Namespace)
+		}
+	case "StatefulSet":
+		if s := k8s.StatefulSetByNamespaceAndName(w.Namespace, w.Name); s != nil {
+			return k8s.PodsByOwnerUid(s.UID, s.Namespace)
+		}
+	case "DaemonSet":
+		if d := k8s.DaemonSetByNamespaceAndName(w.Namespace, w.Name); d != nil {
+			return k8s.PodsByOwnerUid(d.UID, d.Namespace)
+		}
+	}
+	return nil
+}
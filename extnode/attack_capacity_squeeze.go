@@ -0,0 +1,314 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	balloonImage = "registry.k8s.io/pause:3.10"
+	// balloonLabel marks the balloon pods, so left-overs can be found and removed by hand.
+	balloonLabel = "steadybit.com/capacity-squeeze"
+)
+
+type CapacitySqueezeAction struct {
+	k8s *client.Client
+}
+
+type CapacitySqueezeState struct {
+	Namespace string
+	Balloons  []Balloon
+}
+
+// Balloon is the placeholder pod squeezing one node.
+type Balloon struct {
+	NodeName string
+	PodName  string
+	Pod      *corev1.Pod
+	// LastStatus is the last reported status of the balloon pod, changes are reported as messages.
+	LastStatus string
+}
+
+type CapacitySqueezeConfig struct {
+	CpuPercentage     int
+	MemoryPercentage  int
+	NodePoolLabel     string
+	Namespace         string
+	PriorityClassName string
+}
+
+func NewCapacitySqueezeAction(k8s *client.Client) action_kit_sdk.Action[CapacitySqueezeState] {
+	return &CapacitySqueezeAction{k8s: k8s}
+}
+
+var _ action_kit_sdk.Action[CapacitySqueezeState] = (*CapacitySqueezeAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[CapacitySqueezeState] = (*CapacitySqueezeAction)(nil)
+var _ action_kit_sdk.ActionWithStop[CapacitySqueezeState] = (*CapacitySqueezeAction)(nil)
+
+func (a *CapacitySqueezeAction) NewEmptyState() CapacitySqueezeState {
+	return CapacitySqueezeState{}
+}
+
+func (a *CapacitySqueezeAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:              CapacitySqueezeActionId,
+		Label:           "Squeeze Node Capacity",
+		Description:     "Reserves a share of the allocatable CPU and memory of a node, or of all nodes of its node pool, with placeholder pods, so the nodes appear full to the scheduler.",
+		Version:         extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:            new("data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M11.65%202.064a.993.993%200%2001.7%200l10%203.776a1.01%201.01%200%20010%201.889l-10%203.773a.993.993%200%2001-.7%200l-10-3.773A1.008%201.008%200%20011%206.784c0-.42.259-.796.65-.944l10-3.776zM1.063%2017.03a.998.998%200%20011.287-.591L12%2020.082l9.649-3.644a.998.998%200%20011.287.59%201.01%201.01%200%2001-.586%201.299l-10%203.776a.993.993%200%2001-.7%200l-10-3.776a1.01%201.01%200%2001-.586-1.298zm1.287-5.89a.998.998%200%2000-1.287.59%201.01%201.01%200%2000.586%201.299l10%203.776a.993.993%200%2000.7%200l10-3.776a1.01%201.01%200%2000.586-1.298.998.998%200%2000-1.287-.59L12%2014.782l-9.649-3.644z%22%20fill%3D%22currentColor%22%2F%3E%3C%2Fsvg%3E"),
+		Technology:      new("Kubernetes"),
+		TargetSelection: new(targetSelectionTemplates),
+		TimeControl:     action_kit_api.TimeControlExternal,
+		Kind:            action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "Duration",
+				Name:         "duration",
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				Description:  new("The duration of the action. The placeholder pods are deleted after the action."),
+				Required:     new(true),
+				DefaultValue: new("180s"),
+				Order:        new(0),
+			},
+			{
+				Label:        "CPU",
+				Name:         "cpuPercentage",
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				Description:  new("The share of each node's allocatable CPU requested by its placeholder pod."),
+				Required:     new(true),
+				DefaultValue: new("80"),
+				MinValue:     new(0),
+				MaxValue:     new(100),
+				Order:        new(1),
+			},
+			{
+				Label:        "Memory",
+				Name:         "memoryPercentage",
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				Description:  new("The share of each node's allocatable memory requested by its placeholder pod."),
+				Required:     new(true),
+				DefaultValue: new("80"),
+				MinValue:     new(0),
+				MaxValue:     new(100),
+				Order:        new(2),
+			},
+			{
+				Label:       "Node pool label",
+				Name:        "nodePoolLabel",
+				Type:        action_kit_api.ActionParameterTypeString,
+				Description: new("Squeezes all nodes having the same value of this label as the target node, e.g. eks.amazonaws.com/nodegroup or topology.kubernetes.io/zone. Select a single node of the pool then. Without a label only the target node is squeezed."),
+				Required:    new(false),
+				Order:       new(3),
+			},
+			{
+				Label:        "Namespace",
+				Name:         "namespace",
+				Type:         action_kit_api.ActionParameterTypeString,
+				Description:  new("The namespace the placeholder pods are created in."),
+				Required:     new(true),
+				DefaultValue: new("default"),
+				Advanced:     new(true),
+				Order:        new(4),
+			},
+			{
+				Label:       "Priority class",
+				Name:        "priorityClassName",
+				Type:        action_kit_api.ActionParameterTypeString,
+				Description: new("The priority class of the placeholder pods. With a low priority the pods are preempted by workloads, with the default priority they compete with them."),
+				Required:    new(false),
+				Advanced:    new(true),
+				Order:       new(5),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *CapacitySqueezeAction) Prepare(_ context.Context, state *CapacitySqueezeState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config CapacitySqueezeConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	nodeName := request.Target.Attributes["host.hostname"][0]
+	node := a.k8s.NodeByName(nodeName)
+	if node == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Node %s not found.", nodeName), nil)
+	}
+
+	nodes := []*corev1.Node{node}
+	if config.NodePoolLabel != "" {
+		pool, ok := node.Labels[config.NodePoolLabel]
+		if !ok {
+			return nil, extension_kit.ToError(fmt.Sprintf("Node %s has no label %s.", nodeName, config.NodePoolLabel), nil)
+		}
+		nodes = a.nodesOfPool(config.NodePoolLabel, pool)
+	}
+
+	state.Namespace = config.Namespace
+	state.Balloons = make([]Balloon, 0, len(nodes))
+	for _, node := range nodes {
+		state.Balloons = append(state.Balloons, Balloon{NodeName: node.Name, Pod: balloonPod(node, config)})
+	}
+	return nil, nil
+}
+
+// nodesOfPool returns the nodes with the given label value, sorted by name. Nodes excluded from discovery are skipped.
+func (a *CapacitySqueezeAction) nodesOfPool(label, value string) []*corev1.Node {
+	var nodes []*corev1.Node
+	for _, node := range a.k8s.Nodes() {
+		if node.Labels[label] == value && !client.IsExcludedFromDiscovery(node.ObjectMeta) {
+			nodes = append(nodes, node)
+		}
+	}
+	slices.SortFunc(nodes, func(a, b *corev1.Node) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return nodes
+}
+
+// balloonPod requests the configured share of the node's allocatable resources. The pod is bound to the node by
+// affinity instead of spec.nodeName, so it passes the scheduler and takes part in preemption.
+func balloonPod(node *corev1.Node, config CapacitySqueezeConfig) *corev1.Pod {
+	cpu := resource.NewMilliQuantity(node.Status.Allocatable.Cpu().MilliValue()*int64(config.CpuPercentage)/100, resource.DecimalSI)
+	memory := resource.NewQuantity(node.Status.Allocatable.Memory().Value()*int64(config.MemoryPercentage)/100, resource.BinarySI)
+	resources := corev1.ResourceList{corev1.ResourceCPU: *cpu, corev1.ResourceMemory: *memory}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "steadybit-balloon-",
+			Namespace:    config.Namespace,
+			Labels:       map[string]string{balloonLabel: "true"},
+		},
+		Spec: corev1.PodSpec{
+			Affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchFields: []corev1.NodeSelectorRequirement{{
+								Key:      metav1.ObjectNameField,
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{node.Name},
+							}},
+						}},
+					},
+				},
+			},
+			// tolerate the taints of dedicated node pools, cordoned nodes stay unschedulable nevertheless
+			Tolerations:                   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			PriorityClassName:             config.PriorityClassName,
+			TerminationGracePeriodSeconds: new(int64(0)),
+			AutomountServiceAccountToken:  new(false),
+			Containers: []corev1.Container{{
+				Name:  "balloon",
+				Image: balloonImage,
+				Resources: corev1.ResourceRequirements{
+					Requests: resources,
+					Limits:   resources,
+				},
+			}},
+		},
+	}
+}
+
+func (a *CapacitySqueezeAction) Start(ctx context.Context, state *CapacitySqueezeState) (*action_kit_api.StartResult, error) {
+	var messages []action_kit_api.Message
+	for i := range state.Balloons {
+		balloon := &state.Balloons[i]
+		pod, err := a.k8s.CreatePod(ctx, balloon.Pod)
+		if err != nil {
+			// balloons created so far are deleted by stop
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to create the balloon pod for node %s.", balloon.NodeName), err)
+		}
+		balloon.PodName = pod.Name
+		requests := pod.Spec.Containers[0].Resources.Requests
+		messages = append(messages, NodeMessage(balloon.NodeName, fmt.Sprintf("squeezed by balloon pod %s/%s requesting %s CPU and %s memory", pod.Namespace, pod.Name, requests.Cpu(), requests.Memory()), action_kit_api.Info))
+	}
+	return &action_kit_api.StartResult{Messages: &messages}, nil
+}
+
+func (a *CapacitySqueezeAction) Status(ctx context.Context, state *CapacitySqueezeState) (*action_kit_api.StatusResult, error) {
+	var messages []action_kit_api.Message
+	for i := range state.Balloons {
+		balloon := &state.Balloons[i]
+		pod, err := a.k8s.GetPod(ctx, state.Namespace, balloon.PodName)
+		if k8sErrors.IsNotFound(err) {
+			pod = nil
+		} else if err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to read balloon pod %s/%s.", state.Namespace, balloon.PodName), err)
+		}
+
+		status, level := balloonStatus(pod)
+		if status == balloon.LastStatus {
+			continue
+		}
+		balloon.LastStatus = status
+		messages = append(messages, NodeMessage(balloon.NodeName, fmt.Sprintf("balloon pod %s/%s %s", state.Namespace, balloon.PodName, status), level))
+	}
+	if len(messages) == 0 {
+		return &action_kit_api.StatusResult{}, nil
+	}
+	return &action_kit_api.StatusResult{Messages: &messages}, nil
+}
+
+func balloonStatus(pod *corev1.Pod) (string, action_kit_api.MessageLevel) {
+	if pod == nil || pod.DeletionTimestamp != nil {
+		return "was removed, e.g. preempted by a pod with higher priority", action_kit_api.Warn
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodScheduled {
+			continue
+		}
+		if condition.Status == corev1.ConditionTrue {
+			return "is scheduled", action_kit_api.Info
+		}
+		return fmt.Sprintf("is pending: %s", condition.Message), action_kit_api.Warn
+	}
+	return "is waiting to be scheduled", action_kit_api.Info
+}
+
+func (a *CapacitySqueezeAction) Stop(ctx context.Context, state *CapacitySqueezeState) (*action_kit_api.StopResult, error) {
+	var messages []action_kit_api.Message
+	var errs []error
+	for i := range state.Balloons {
+		balloon := &state.Balloons[i]
+		if balloon.PodName == "" {
+			continue
+		}
+		err := a.k8s.DeletePod(ctx, state.Namespace, balloon.PodName, new(int64(0)))
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete balloon pod %s/%s: %w", state.Namespace, balloon.PodName, err))
+			continue
+		}
+		messages = append(messages, NodeMessage(balloon.NodeName, fmt.Sprintf("released by deleting balloon pod %s/%s", state.Namespace, balloon.PodName), action_kit_api.Info))
+		balloon.PodName = ""
+	}
+	if len(errs) > 0 {
+		return nil, extension_kit.ToError("Failed to delete the balloon pods.", errors.Join(errs...))
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	return &action_kit_api.StopResult{Messages: &messages}, nil
+}
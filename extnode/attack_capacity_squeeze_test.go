@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestCapacitySqueezeCreatesAndDeletesBalloonPod(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("16Gi"),
+		}},
+	})
+	// the fake clientset doesn't generate names
+	clientset.PrependReactor("create", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8sTesting.CreateAction).GetObject().(*corev1.Pod)
+		pod.Name = pod.GenerateName + "x7k2p"
+		return false, nil, nil
+	})
+	k8s := createConditionCheckClient(t, clientset)
+	require.Eventually(t, func() bool { return k8s.NodeByName("worker-1") != nil }, time.Second, 10*time.Millisecond)
+	action := &CapacitySqueezeAction{k8s: k8s}
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"duration":          60000,
+			"cpuPercentage":     75,
+			"memoryPercentage":  50,
+			"namespace":         "chaos",
+			"priorityClassName": "low-priority",
+		},
+		Target: new(action_kit_api.Target{Attributes: map[string][]string{"host.hostname": {"worker-1"}}}),
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, state.Balloons, 1)
+	balloon := state.Balloons[0].Pod
+	container := balloon.Spec.Containers[0]
+	assert.Equal(t, "3", container.Resources.Requests.Cpu().String())
+	assert.Equal(t, "8Gi", container.Resources.Requests.Memory().String())
+	assert.Equal(t, container.Resources.Requests, container.Resources.Limits)
+	assert.Equal(t, "low-priority", balloon.Spec.PriorityClassName)
+	assert.Equal(t, []string{"worker-1"}, balloon.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values)
+
+	// When
+	start, err := action.Start(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "Node worker-1 squeezed by balloon pod chaos/steadybit-balloon-x7k2p requesting 3 CPU and 8Gi memory", (*start.Messages)[0].Message)
+
+	// When
+	pod, _ := clientset.CoreV1().Pods("chaos").Get(context.Background(), state.Balloons[0].PodName, metav1.GetOptions{})
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Message: "0/1 nodes are available: 1 Insufficient cpu."}}
+	_, _ = clientset.CoreV1().Pods("chaos").Update(context.Background(), pod, metav1.UpdateOptions{})
+	status, err := action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Node worker-1 balloon pod chaos/steadybit-balloon-x7k2p is pending: 0/1 nodes are available: 1 Insufficient cpu."}, messageTexts(status.Messages))
+
+	// When
+	status, err = action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Nil(t, status.Messages, "unchanged status is reported once")
+
+	// When
+	_, err = action.Stop(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	pods, _ := clientset.CoreV1().Pods("chaos").List(context.Background(), metav1.ListOptions{})
+	assert.Empty(t, pods.Items)
+}
+
+func TestCapacitySqueezeReportsPreemptedBalloonPod(t *testing.T) {
+	// Given
+	action := &CapacitySqueezeAction{k8s: createConditionCheckClient(t, testclient.NewClientset())}
+	state := CapacitySqueezeState{Namespace: "chaos", Balloons: []Balloon{{NodeName: "worker-1", PodName: "steadybit-balloon-x7k2p", LastStatus: "is scheduled"}}}
+
+	// When
+	status, err := action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, *status.Messages, 1)
+	assert.Equal(t, "Node worker-1 balloon pod chaos/steadybit-balloon-x7k2p was removed, e.g. preempted by a pod with higher priority", (*status.Messages)[0].Message)
+	assert.Equal(t, action_kit_api.Warn, *(*status.Messages)[0].Level)
+}
+
+func TestCapacitySqueezeSqueezesAllNodesOfThePool(t *testing.T) {
+	// Given
+	poolNode := func(name, pool string, excluded bool) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"eks.amazonaws.com/nodegroup": pool}},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}},
+		}
+		if excluded {
+			node.Labels["steadybit.com.discovery-disabled"] = "true"
+		}
+		return node
+	}
+	clientset := testclient.NewClientset(
+		poolNode("worker-2", "workers", false),
+		poolNode("worker-1", "workers", false),
+		poolNode("worker-3", "workers", true),
+		poolNode("system-1", "system", false),
+	)
+	created := 0
+	clientset.PrependReactor("create", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		created++
+		pod := action.(k8sTesting.CreateAction).GetObject().(*corev1.Pod)
+		pod.Name = fmt.Sprintf("%s%d", pod.GenerateName, created)
+		return false, nil, nil
+	})
+	k8s := createConditionCheckClient(t, clientset)
+	require.Eventually(t, func() bool { return len(k8s.Nodes()) == 4 }, time.Second, 10*time.Millisecond)
+	action := &CapacitySqueezeAction{k8s: k8s}
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"duration":         60000,
+			"cpuPercentage":    50,
+			"memoryPercentage": 50,
+			"nodePoolLabel":    "eks.amazonaws.com/nodegroup",
+			"namespace":        "chaos",
+		},
+		Target: new(action_kit_api.Target{Attributes: map[string][]string{"host.hostname": {"worker-2"}}}),
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, state.Balloons, 2)
+	assert.Equal(t, "worker-1", state.Balloons[0].NodeName)
+	assert.Equal(t, "worker-2", state.Balloons[1].NodeName)
+
+	// When
+	start, err := action.Start(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Node worker-1 squeezed by balloon pod chaos/steadybit-balloon-1 requesting 1 CPU and 4Gi memory",
+		"Node worker-2 squeezed by balloon pod chaos/steadybit-balloon-2 requesting 1 CPU and 4Gi memory",
+	}, messageTexts(start.Messages))
+
+	// When
+	stop, err := action.Stop(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, *stop.Messages, 2)
+	pods, _ := clientset.CoreV1().Pods("chaos").List(context.Background(), metav1.ListOptions{})
+	assert.Empty(t, pods.Items)
+}
+
+func TestCapacitySqueezeRejectsNodeWithoutPoolLabel(t *testing.T) {
+	// Given
+	k8s := createConditionCheckClient(t, testclient.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}}))
+	require.Eventually(t, func() bool { return k8s.NodeByName("worker-1") != nil }, time.Second, 10*time.Millisecond)
+	action := &CapacitySqueezeAction{k8s: k8s}
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"cpuPercentage": 50, "memoryPercentage": 50, "nodePoolLabel": "eks.amazonaws.com/nodegroup", "namespace": "chaos"},
+		Target: new(action_kit_api.Target{Attributes: map[string][]string{"host.hostname": {"worker-1"}}}),
+	})
+
+	// Then
+	require.ErrorContains(t, err, "Node worker-1 has no label eks.amazonaws.com/nodegroup.")
+}
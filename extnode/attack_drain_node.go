@@ -5,21 +5,55 @@ package extnode
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
 	"github.com/steadybit/extension-kit/extbuild"
-	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
-func NewDrainNodeAction() action_kit_sdk.Action[extcommon.KubectlActionState] {
-	return &extcommon.KubectlAction{
-		Description:  getDrainNodeDescription(),
-		OptsProvider: drainNode(),
-	}
+type DrainNodeAction struct {
+	k8s *client.Client
+}
+
+type DrainNodeState struct {
+	NodeName string
+	Options  DrainOptions
+	// Deadline is the unix millis until the node has to be drained, zero waits until the attack ends.
+	Deadline int64
+	Timeout  time.Duration
+	Cordoned bool
+	Progress DrainProgress
 }
 
-func getDrainNodeDescription() action_kit_api.ActionDescription {
+type DrainNodeConfig struct {
+	GracePeriod                 int64
+	Timeout                     int64
+	PodSelector                 string
+	SkipLocalStorage            bool
+	RespectPodDisruptionBudgets bool
+}
+
+func NewDrainNodeAction(k8s *client.Client) action_kit_sdk.Action[DrainNodeState] {
+	return &DrainNodeAction{k8s: k8s}
+}
+
+var _ action_kit_sdk.Action[DrainNodeState] = (*DrainNodeAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[DrainNodeState] = (*DrainNodeAction)(nil)
+var _ action_kit_sdk.ActionWithStop[DrainNodeState] = (*DrainNodeAction)(nil)
+
+func (a *DrainNodeAction) NewEmptyState() DrainNodeState {
+	return DrainNodeState{}
+}
+
+func (a *DrainNodeAction) Describe() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:              DrainNodeActionId,
 		Label:           "Drain Node",
@@ -41,47 +75,140 @@ func getDrainNodeDescription() action_kit_api.ActionDescription {
 				DefaultValue: new("180s"),
 				Order:        new(0),
 			},
+			{
+				Label:        "Respect PodDisruptionBudgets",
+				Name:         "respectPodDisruptionBudgets",
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				Description:  new("Evict the pods using the Eviction API, which waits for PodDisruptionBudgets. Otherwise the pods are deleted right away."),
+				Advanced:     new(true),
+				Required:     new(true),
+				DefaultValue: new("true"),
+				Order:        new(1),
+			},
+			{
+				Label:       "Timeout",
+				Name:        "timeout",
+				Type:        action_kit_api.ActionParameterTypeDuration,
+				Description: new("The action fails if the node isn't drained within the timeout, e.g. because evictions are blocked by PodDisruptionBudgets. Without a timeout the drain continues until the action ends."),
+				Advanced:    new(true),
+				Required:    new(false),
+				Order:       new(2),
+			},
+			{
+				Label:       "Grace period",
+				Name:        "gracePeriod",
+				Type:        action_kit_api.ActionParameterTypeDuration,
+				Description: new("Overrides the termination grace period of the pods. Without a value the grace period of each pod is used."),
+				Advanced:    new(true),
+				Required:    new(false),
+				Order:       new(3),
+			},
+			{
+				Label:        "Pod selector",
+				Name:         "podSelector",
+				Type:         action_kit_api.ActionParameterTypeString,
+				Description:  new("Label selector restricting the drain to the matching pods, e.g. app=checkout,tier!=database."),
+				Advanced:     new(true),
+				Required:     new(false),
+				DefaultValue: new(""),
+				Order:        new(4),
+			},
+			{
+				Label:        "Skip pods with local storage",
+				Name:         "skipLocalStorage",
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				Description:  new("Leave pods using emptyDir volumes on the node, their data would be lost otherwise."),
+				Advanced:     new(true),
+				Required:     new(true),
+				DefaultValue: new("false"),
+				Order:        new(5),
+			},
 		},
 		Prepare: action_kit_api.MutatingEndpointReference{},
 		Start:   action_kit_api.MutatingEndpointReference{},
-		Status:  &action_kit_api.MutatingEndpointReferenceWithCallInterval{},
-		Stop:    &action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
 	}
 }
 
-func drainNode() extcommon.KubectlOptsProvider {
-	return func(ctx context.Context, request action_kit_api.PrepareActionRequestBody) (*extcommon.KubectlOpts, error) {
-		nodeName := request.Target.Attributes["host.hostname"][0]
-
-		command := []string{
-			"kubectl",
-			"drain",
-			nodeName,
-			"--pod-selector=steadybit.com/extension!=true,steadybit.com/agent!=true",
-			"--delete-emptydir-data",
-			"--ignore-daemonsets",
-			"--force"}
-
-		rollbackPreconditionCommand := []string{
-			"kubectl",
-			"get",
-			"node",
-			nodeName,
-		}
-
-		rollbackCommand := []string{
-			"kubectl",
-			"uncordon",
-			nodeName,
-		}
-
-		return &extcommon.KubectlOpts{
-			Command:                     command,
-			RollbackPreconditionCommand: rollbackPreconditionCommand,
-			RollbackCommand:             rollbackCommand,
-			LogTargetType:               "node",
-			LogTargetName:               nodeName,
-			LogActionName:               "drain node",
+func (a *DrainNodeAction) Prepare(_ context.Context, state *DrainNodeState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	// PodDisruptionBudgets are respected unless explicitly disabled
+	config := DrainNodeConfig{RespectPodDisruptionBudgets: true}
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	if _, err := labels.Parse(config.PodSelector); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid pod selector: %s", config.PodSelector), err)
+	}
+	if !config.RespectPodDisruptionBudgets && !a.k8s.Permissions().IsDeletePodPermitted() {
+		return nil, extension_kit.ToError("Deleting pods without respecting PodDisruptionBudgets requires the permission to delete pods.", nil)
+	}
+
+	state.NodeName = request.Target.Attributes["host.hostname"][0]
+	state.Timeout = time.Duration(config.Timeout) * time.Millisecond
+	state.Options = DrainOptions{
+		PodSelector:      config.PodSelector,
+		SkipLocalStorage: config.SkipLocalStorage,
+		Force:            !config.RespectPodDisruptionBudgets,
+	}
+	if config.GracePeriod > 0 {
+		state.Options.GracePeriodSeconds = new(config.GracePeriod / 1000)
+	}
+	return nil, nil
+}
+
+func (a *DrainNodeAction) Start(ctx context.Context, state *DrainNodeState) (*action_kit_api.StartResult, error) {
+	cordoned, err := a.k8s.SetNodeUnschedulable(ctx, state.NodeName, true)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to cordon node %s.", state.NodeName), err)
+	}
+	state.Cordoned = cordoned
+	if state.Timeout > 0 {
+		state.Deadline = time.Now().Add(state.Timeout).UnixMilli()
+	}
+
+	messages := []action_kit_api.Message{NodeMessage(state.NodeName, "cordoned", action_kit_api.Info)}
+	if !cordoned {
+		messages[0] = NodeMessage(state.NodeName, "already cordoned, it stays cordoned after the action", action_kit_api.Info)
+	}
+	drainMessages, err := state.Progress.Step(ctx, a.k8s, state.NodeName, state.Options)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to drain node %s.", state.NodeName), err)
+	}
+	messages = append(messages, drainMessages...)
+	return &action_kit_api.StartResult{Messages: &messages}, nil
+}
+
+func (a *DrainNodeAction) Status(ctx context.Context, state *DrainNodeState) (*action_kit_api.StatusResult, error) {
+	messages, err := state.Progress.Step(ctx, a.k8s, state.NodeName, state.Options)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to drain node %s.", state.NodeName), err)
+	}
+	if !state.Progress.Drained && state.Deadline > 0 && time.Now().UnixMilli() > state.Deadline {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Messages:  &messages,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Node %s was not drained within %s.", state.NodeName, state.Timeout),
+				Detail: new("Remaining pods: " + strings.Join(state.Progress.Remaining, ", ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
 		}, nil
 	}
+	return &action_kit_api.StatusResult{Messages: &messages}, nil
+}
+
+func (a *DrainNodeAction) Stop(ctx context.Context, state *DrainNodeState) (*action_kit_api.StopResult, error) {
+	if !state.Cordoned {
+		return nil, nil
+	}
+	if _, err := a.k8s.SetNodeUnschedulable(ctx, state.NodeName, false); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to uncordon node %s.", state.NodeName), err)
+	}
+	state.Cordoned = false
+	return &action_kit_api.StopResult{Messages: &[]action_kit_api.Message{
+		NodeMessage(state.NodeName, "uncordoned", action_kit_api.Info),
+	}}, nil
 }
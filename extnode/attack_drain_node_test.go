@@ -6,13 +6,177 @@ package extnode
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
 )
 
-func TestDrainNodePrepareCommands(t *testing.T) {
+func TestDrainNodePrepare(t *testing.T) {
 	// Given
+	action := &DrainNodeAction{k8s: createConditionCheckClient(t, testclient.NewClientset())}
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, drainNodeRequest(map[string]any{
+		"gracePeriod": 30000,
+		"timeout":     60000,
+		"podSelector": "tier=frontend",
+	}))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "test", state.NodeName)
+	assert.Equal(t, 60*time.Second, state.Timeout)
+	assert.Equal(t, DrainOptions{
+		GracePeriodSeconds: new(int64(30)),
+		PodSelector:        "tier=frontend",
+	}, state.Options)
+
+	// When
+	_, err = action.Prepare(context.Background(), &state, drainNodeRequest(map[string]any{"podSelector": "tier in (frontend"}))
+
+	// Then
+	require.ErrorContains(t, err, "Invalid pod selector: tier in (frontend")
+}
+
+func TestDrainNodeEvictsPodsAndUncordons(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+		drainPod("checkout", "frontend"),
+		drainPod("cart", "frontend"),
+		drainPod("postgres", "database"),
+		withEmptyDir(drainPod("cache", "frontend")),
+	)
+	pdbBlocked := true
+	evictions := mockEvictions(clientset, func(name string) bool { return name == "cart" && pdbBlocked })
+	action := &DrainNodeAction{k8s: createConditionCheckClient(t, clientset)}
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, drainNodeRequest(map[string]any{
+		"gracePeriod":      10000,
+		"podSelector":      "tier=frontend",
+		"skipLocalStorage": true,
+	}))
+	require.NoError(t, err)
+
+	// When
+	start, err := action.Start(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Node test cordoned",
+		"Node test skipped pod shop/cache with local storage",
+		"Node test eviction of pod shop/cart blocked by PodDisruptionBudget, retrying",
+		"Node test evicted pod shop/checkout",
+	}, messageTexts(start.Messages))
+	assert.Equal(t, int64(10), *(*evictions)[0].DeleteOptions.GracePeriodSeconds)
+	node, _ := clientset.CoreV1().Nodes().Get(context.Background(), "test", metav1.GetOptions{})
+	assert.True(t, node.Spec.Unschedulable)
+
+	// When
+	status, err := action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, messageTexts(status.Messages), "blocked evictions are reported once")
+	assert.Equal(t, []string{"shop/cart"}, state.Progress.Remaining)
+
+	// When
+	pdbBlocked = false
+	_, err = action.Status(context.Background(), &state)
+	require.NoError(t, err)
+	status, err = action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Node test drained"}, messageTexts(status.Messages))
+	pods, _ := clientset.CoreV1().Pods("shop").List(context.Background(), metav1.ListOptions{})
+	assert.ElementsMatch(t, []string{"cache", "postgres"}, podNames(pods.Items))
+
+	// When
+	stop, err := action.Stop(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Node test uncordoned"}, messageTexts(stop.Messages))
+	node, _ = clientset.CoreV1().Nodes().Get(context.Background(), "test", metav1.GetOptions{})
+	assert.False(t, node.Spec.Unschedulable)
+}
+
+func TestDrainNodeKeepsNodeCordonedByOthers(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: corev1.NodeSpec{Unschedulable: true}})
+	action := &DrainNodeAction{k8s: createConditionCheckClient(t, clientset)}
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, drainNodeRequest(map[string]any{}))
+	require.NoError(t, err)
+
+	// When
+	_, err = action.Start(context.Background(), &state)
+	require.NoError(t, err)
+	stop, err := action.Stop(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Nil(t, stop)
+	node, _ := clientset.CoreV1().Nodes().Get(context.Background(), "test", metav1.GetOptions{})
+	assert.True(t, node.Spec.Unschedulable)
+}
+
+func TestDrainNodeFailsAfterTimeout(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, drainPod("cart", "frontend"))
+	mockEvictions(clientset, func(string) bool { return true })
+	action := &DrainNodeAction{k8s: createConditionCheckClient(t, clientset)}
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, drainNodeRequest(map[string]any{"timeout": 1000}))
+	require.NoError(t, err)
+	_, err = action.Start(context.Background(), &state)
+	require.NoError(t, err)
+
+	// When
+	state.Deadline = time.Now().Add(-time.Second).UnixMilli()
+	status, err := action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Equal(t, "Node test was not drained within 1s.", status.Error.Title)
+	assert.Equal(t, "Remaining pods: shop/cart", *status.Error.Detail)
+}
+
+func TestDrainNodeDeletesPodsWithoutRespectingPodDisruptionBudgets(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, drainPod("cart", "frontend"))
+	mockEvictions(clientset, func(string) bool { return true })
+	action := &DrainNodeAction{k8s: createConditionCheckClient(t, clientset)}
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, drainNodeRequest(map[string]any{"respectPodDisruptionBudgets": false}))
+	require.NoError(t, err)
+
+	// When
+	start, err := action.Start(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Node test cordoned", "Node test deleted pod shop/cart"}, messageTexts(start.Messages))
+	pods, _ := clientset.CoreV1().Pods("shop").List(context.Background(), metav1.ListOptions{})
+	assert.Empty(t, pods.Items)
+}
+
+func drainNodeRequest(config map[string]any) action_kit_api.PrepareActionRequestBody {
 	request := action_kit_api.PrepareActionRequestBody{
 		Config: map[string]any{
 			"duration": 100000,
@@ -23,16 +187,55 @@ func TestDrainNodePrepareCommands(t *testing.T) {
 			},
 		}),
 	}
+	for key, value := range config {
+		request.Config[key] = value
+	}
+	return request
+}
 
-	action := NewDrainNodeAction()
-	state := action.NewEmptyState()
+// mockEvictions lets the fake clientset evict pods by deleting them, unless blocked returns true for the pod. The
+// returned slice records all evictions.
+func mockEvictions(clientset *testclient.Clientset, blocked func(name string) bool) *[]*policyv1.Eviction {
+	var evictions []*policyv1.Eviction
+	clientset.PrependReactor("create", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8sTesting.CreateAction).GetObject().(*policyv1.Eviction)
+		evictions = append(evictions, eviction)
+		if blocked(eviction.Name) {
+			return true, nil, k8sErrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		return true, nil, clientset.Tracker().Delete(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, eviction.Namespace, eviction.Name)
+	})
+	return &evictions
+}
 
-	// When
-	_, err := action.Prepare(context.Background(), &state, request)
-	require.NoError(t, err)
+func drainPod(name, tier string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{"tier": tier}},
+		Spec:       corev1.PodSpec{NodeName: "test"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
 
-	// Then
-	require.Equal(t, []string{"kubectl", "drain", "test", "--pod-selector=steadybit.com/extension!=true,steadybit.com/agent!=true", "--delete-emptydir-data", "--ignore-daemonsets", "--force"}, state.Opts.Command)
-	require.Equal(t, []string{"kubectl", "get", "node", "test"}, state.Opts.RollbackPreconditionCommand)
-	require.Equal(t, []string{"kubectl", "uncordon", "test"}, state.Opts.RollbackCommand)
+func withEmptyDir(pod *corev1.Pod) *corev1.Pod {
+	pod.Spec.Volumes = []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	return pod
+}
+
+func podNames(pods []corev1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func messageTexts(messages *action_kit_api.Messages) []string {
+	var texts []string
+	for _, message := range *messages {
+		texts = append(texts, message.Message)
+	}
+	return texts
 }
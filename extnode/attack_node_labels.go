@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type NodeLabelAction struct {
+	k8s *client.Client
+}
+
+type NodeLabelState struct {
+	NodeName  string
+	Overwrite map[string]string
+	Remove    []string
+	// Original holds the values of the changed labels before the attack, nil if the label didn't exist.
+	Original map[string]*string
+}
+
+func NewNodeLabelAction(k8s *client.Client) action_kit_sdk.Action[NodeLabelState] {
+	return &NodeLabelAction{k8s: k8s}
+}
+
+var _ action_kit_sdk.Action[NodeLabelState] = (*NodeLabelAction)(nil)
+var _ action_kit_sdk.ActionWithStop[NodeLabelState] = (*NodeLabelAction)(nil)
+
+func (a *NodeLabelAction) NewEmptyState() NodeLabelState {
+	return NodeLabelState{}
+}
+
+func (a *NodeLabelAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:              NodeLabelActionId,
+		Label:           "Change Node Labels",
+		Description:     "Overwrites or removes labels of a node, e.g. the zone or node pool labels used by nodeSelectors and affinities. The original labels are restored after the action.",
+		Version:         extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:            new("data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M11.65%202.064a.993.993%200%2001.7%200l10%203.776a1.01%201.01%200%20010%201.889l-10%203.773a.993.993%200%2001-.7%200l-10-3.773A1.008%201.008%200%20011%206.784c0-.42.259-.796.65-.944l10-3.776zM1.063%2017.03a.998.998%200%20011.287-.591L12%2020.082l9.649-3.644a.998.998%200%20011.287.59%201.01%201.01%200%2001-.586%201.299l-10%203.776a.993.993%200%2001-.7%200l-10-3.776a1.01%201.01%200%2001-.586-1.298zm1.287-5.89a.998.998%200%2000-1.287.59%201.01%201.01%200%2000.586%201.299l10%203.776a.993.993%200%2000.7%200l10-3.776a1.01%201.01%200%2000.586-1.298.998.998%200%2000-1.287-.59L12%2014.782l-9.649-3.644z%22%20fill%3D%22currentColor%22%2F%3E%3C%2Fsvg%3E"),
+		Technology:      new("Kubernetes"),
+		TargetSelection: new(targetSelectionTemplates),
+		TimeControl:     action_kit_api.TimeControlExternal,
+		Kind:            action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "Duration",
+				Name:         "duration",
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				Description:  new("The duration of the action. The original labels are restored after the action."),
+				Required:     new(true),
+				DefaultValue: new("180s"),
+				Order:        new(0),
+			},
+			{
+				Label:       "Overwrite labels",
+				Name:        "overwriteLabels",
+				Type:        action_kit_api.ActionParameterTypeKeyValue,
+				Description: new("Labels set on the node, existing labels with the same key are overwritten."),
+				Required:    new(false),
+				Order:       new(1),
+			},
+			{
+				Label:       "Remove labels",
+				Name:        "removeLabels",
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Description: new("The keys of the labels removed from the node, e.g. topology.kubernetes.io/zone."),
+				Required:    new(false),
+				Order:       new(2),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *NodeLabelAction) Prepare(_ context.Context, state *NodeLabelState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	overwrite, err := extutil.ToKeyValue(request.Config, "overwriteLabels")
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to read the labels to overwrite.", err)
+	}
+	remove := extutil.ToStringArray(request.Config["removeLabels"])
+	if len(overwrite) == 0 && len(remove) == 0 {
+		return nil, extension_kit.ToError("No labels to overwrite or remove.", nil)
+	}
+
+	var problems []string
+	for key, value := range overwrite {
+		for _, problem := range validation.IsQualifiedName(key) {
+			problems = append(problems, fmt.Sprintf("label %s: %s", key, problem))
+		}
+		for _, problem := range validation.IsValidLabelValue(value) {
+			problems = append(problems, fmt.Sprintf("label %s value %s: %s", key, value, problem))
+		}
+		if slices.Contains(remove, key) {
+			problems = append(problems, fmt.Sprintf("label %s can't be overwritten and removed", key))
+		}
+	}
+	if len(problems) > 0 {
+		slices.Sort(problems)
+		return nil, extension_kit.ToError("Invalid labels: "+strings.Join(problems, "; "), nil)
+	}
+
+	state.NodeName = request.Target.Attributes["host.hostname"][0]
+	state.Overwrite = overwrite
+	state.Remove = remove
+	return nil, nil
+}
+
+func (a *NodeLabelAction) Start(ctx context.Context, state *NodeLabelState) (*action_kit_api.StartResult, error) {
+	node, err := a.k8s.GetNode(ctx, state.NodeName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to read node %s.", state.NodeName), err)
+	}
+
+	original := map[string]*string{}
+	changes := map[string]*string{}
+	var messages []action_kit_api.Message
+	for _, key := range slices.Sorted(maps.Keys(state.Overwrite)) {
+		value := state.Overwrite[key]
+		current, exists := node.Labels[key]
+		original[key] = originalLabel(current, exists)
+		changes[key] = new(value)
+		messages = append(messages, NodeMessage(state.NodeName, fmt.Sprintf("label %s set to %q (was %s)", key, value, describeLabel(current, exists)), action_kit_api.Info))
+	}
+	for _, key := range state.Remove {
+		current, exists := node.Labels[key]
+		if !exists {
+			messages = append(messages, NodeMessage(state.NodeName, fmt.Sprintf("label %s not present, nothing to remove", key), action_kit_api.Warn))
+			continue
+		}
+		original[key] = new(current)
+		changes[key] = nil
+		messages = append(messages, NodeMessage(state.NodeName, fmt.Sprintf("label %s removed (was %q)", key, current), action_kit_api.Info))
+	}
+
+	if len(changes) > 0 {
+		if err := a.k8s.PatchNodeLabels(ctx, state.NodeName, changes); err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to change the labels of node %s.", state.NodeName), err)
+		}
+	}
+	state.Original = original
+	return &action_kit_api.StartResult{Messages: &messages}, nil
+}
+
+func (a *NodeLabelAction) Stop(ctx context.Context, state *NodeLabelState) (*action_kit_api.StopResult, error) {
+	if len(state.Original) == 0 {
+		return nil, nil
+	}
+	if err := a.k8s.PatchNodeLabels(ctx, state.NodeName, state.Original); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore the labels of node %s.", state.NodeName), err)
+	}
+	state.Original = nil
+	return &action_kit_api.StopResult{Messages: &[]action_kit_api.Message{
+		NodeMessage(state.NodeName, "labels restored", action_kit_api.Info),
+	}}, nil
+}
+
+func originalLabel(value string, exists bool) *string {
+	if !exists {
+		return nil
+	}
+	return new(value)
+}
+
+func describeLabel(value string, exists bool) string {
+	if !exists {
+		return "not present"
+	}
+	return fmt.Sprintf("%q", value)
+}
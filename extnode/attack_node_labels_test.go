@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNodeLabelPrepareRejectsInvalidLabels(t *testing.T) {
+	// Given
+	action := &NodeLabelAction{k8s: createConditionCheckClient(t, testclient.NewClientset())}
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, nodeLabelRequest(nil, nil))
+
+	// Then
+	require.ErrorContains(t, err, "No labels to overwrite or remove.")
+
+	// When
+	_, err = action.Prepare(context.Background(), &state, nodeLabelRequest([]any{
+		map[string]any{"key": "pool", "value": "not valid"},
+	}, []any{"pool"}))
+
+	// Then
+	require.ErrorContains(t, err, "label pool can't be overwritten and removed")
+	require.ErrorContains(t, err, "label pool value not valid:")
+}
+
+func TestNodeLabelChangesAndRestoresLabels(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: "worker-1",
+		Labels: map[string]string{
+			"topology.kubernetes.io/zone": "eu-central-1a",
+			"pool":                        "workers",
+			"kubernetes.io/os":            "linux",
+		},
+	}})
+	action := &NodeLabelAction{k8s: createConditionCheckClient(t, clientset)}
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, nodeLabelRequest([]any{
+		map[string]any{"key": "pool", "value": "batch"},
+		map[string]any{"key": "disktype", "value": "ssd"},
+	}, []any{"topology.kubernetes.io/zone", "missing"}))
+	require.NoError(t, err)
+
+	// When
+	start, err := action.Start(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`Node worker-1 label disktype set to "ssd" (was not present)`,
+		`Node worker-1 label pool set to "batch" (was "workers")`,
+		`Node worker-1 label topology.kubernetes.io/zone removed (was "eu-central-1a")`,
+		`Node worker-1 label missing not present, nothing to remove`,
+	}, messageTexts(start.Messages))
+	node, _ := clientset.CoreV1().Nodes().Get(context.Background(), "worker-1", metav1.GetOptions{})
+	assert.Equal(t, map[string]string{"pool": "batch", "disktype": "ssd", "kubernetes.io/os": "linux"}, node.Labels)
+
+	// When
+	_, err = action.Stop(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	node, _ = clientset.CoreV1().Nodes().Get(context.Background(), "worker-1", metav1.GetOptions{})
+	assert.Equal(t, map[string]string{
+		"topology.kubernetes.io/zone": "eu-central-1a",
+		"pool":                        "workers",
+		"kubernetes.io/os":            "linux",
+	}, node.Labels)
+}
+
+func nodeLabelRequest(overwrite []any, remove []any) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"duration":        60000,
+			"overwriteLabels": overwrite,
+			"removeLabels":    remove,
+		},
+		Target: new(action_kit_api.Target{Attributes: map[string][]string{"host.hostname": {"worker-1"}}}),
+	}
+}
@@ -0,0 +1,359 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcluster"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// preemptionLabel marks the filler pods with the name of their PriorityClass.
+	preemptionLabel = "steadybit.com/preemption"
+	// maxUserPriority is the highest priority allowed for user defined PriorityClasses.
+	maxUserPriority = 1000000000
+)
+
+type PreemptionAction struct {
+	k8s *client.Client
+}
+
+type PreemptionState struct {
+	PriorityClass *schedulingv1.PriorityClass
+	Pod           *corev1.Pod
+	Replicas      int
+	StartedAt     time.Time
+	PodNames      []string
+	// LastScheduled is the last reported number of scheduled filler pods.
+	LastScheduled int
+	// Victims holds the preempted pods, they are reported only once.
+	Victims map[string]bool
+}
+
+type PreemptionConfig struct {
+	Priority        int32
+	Replicas        int
+	Cpu             string
+	Memory          string
+	NodeSelector    string
+	TargetNamespace string
+	Namespace       string
+}
+
+func NewPreemptionAction(k8s *client.Client) action_kit_sdk.Action[PreemptionState] {
+	return &PreemptionAction{k8s: k8s}
+}
+
+var _ action_kit_sdk.Action[PreemptionState] = (*PreemptionAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[PreemptionState] = (*PreemptionAction)(nil)
+var _ action_kit_sdk.ActionWithStop[PreemptionState] = (*PreemptionAction)(nil)
+
+func (a *PreemptionAction) NewEmptyState() PreemptionState {
+	return PreemptionState{}
+}
+
+func (a *PreemptionAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          PreemptionActionId,
+		Label:       "Force Preemption",
+		Description: "Creates filler pods with a temporary PriorityClass, so the scheduler preempts workloads of lower priority.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new("data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M11.65%202.064a.993.993%200%2001.7%200l10%203.776a1.01%201.01%200%20010%201.889l-10%203.773a.993.993%200%2001-.7%200l-10-3.773A1.008%201.008%200%20011%206.784c0-.42.259-.796.65-.944l10-3.776zM1.063%2017.03a.998.998%200%20011.287-.591L12%2020.082l9.649-3.644a.998.998%200%20011.287.59%201.01%201.01%200%2001-.586%201.299l-10%203.776a.993.993%200%2001-.7%200l-10-3.776a1.01%201.01%200%2001-.586-1.298zm1.287-5.89a.998.998%200%2000-1.287.59%201.01%201.01%200%2000.586%201.299l10%203.776a.993.993%200%2000.7%200l10-3.776a1.01%201.01%200%2000.586-1.298.998.998%200%2000-1.287-.59L12%2014.782l-9.649-3.644z%22%20fill%3D%22currentColor%22%2F%3E%3C%2Fsvg%3E"),
+		Technology:  new("Kubernetes"),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.QuantityRestrictionExactlyOne),
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "cluster name",
+					Description: new("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "Duration",
+				Name:         "duration",
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				Description:  new("The duration of the action. The filler pods and the PriorityClass are deleted after the action."),
+				Required:     new(true),
+				DefaultValue: new("180s"),
+				Order:        new(0),
+			},
+			{
+				Label:        "Priority",
+				Name:         "priority",
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				Description:  new("The priority of the filler pods. Pods with a lower priority are preempted to make room for them."),
+				Required:     new(true),
+				DefaultValue: new("1000000"),
+				MinValue:     new(0),
+				MaxValue:     new(maxUserPriority),
+				Order:        new(1),
+			},
+			{
+				Label:        "Filler pods",
+				Name:         "replicas",
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				Description:  new("The number of filler pods."),
+				Required:     new(true),
+				DefaultValue: new("10"),
+				MinValue:     new(1),
+				MaxValue:     new(500),
+				Order:        new(2),
+			},
+			{
+				Label:        "CPU per pod",
+				Name:         "cpu",
+				Type:         action_kit_api.ActionParameterTypeString,
+				Description:  new("The CPU requested by each filler pod, e.g. 500m."),
+				Required:     new(true),
+				DefaultValue: new("1"),
+				Order:        new(3),
+			},
+			{
+				Label:        "Memory per pod",
+				Name:         "memory",
+				Type:         action_kit_api.ActionParameterTypeString,
+				Description:  new("The memory requested by each filler pod, e.g. 512Mi."),
+				Required:     new(true),
+				DefaultValue: new("1Gi"),
+				Order:        new(4),
+			},
+			{
+				Label:       "Node selector",
+				Name:        "nodeSelector",
+				Type:        action_kit_api.ActionParameterTypeString,
+				Description: new("Restricts the filler pods to a node pool, e.g. eks.amazonaws.com/nodegroup=workers. Without a selector the whole cluster is targeted."),
+				Required:    new(false),
+				Order:       new(5),
+			},
+			{
+				Label:       "Target namespace",
+				Name:        "targetNamespace",
+				Type:        action_kit_api.ActionParameterTypeString,
+				Description: new("Restricts the filler pods to the nodes running pods of this namespace."),
+				Required:    new(false),
+				Order:       new(6),
+			},
+			{
+				Label:        "Namespace",
+				Name:         "namespace",
+				Type:         action_kit_api.ActionParameterTypeString,
+				Description:  new("The namespace the filler pods are created in."),
+				Required:     new(true),
+				DefaultValue: new("default"),
+				Advanced:     new(true),
+				Order:        new(7),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *PreemptionAction) Prepare(_ context.Context, state *PreemptionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config PreemptionConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	if config.Priority < 0 || config.Priority > maxUserPriority {
+		return nil, extension_kit.ToError(fmt.Sprintf("Priority must be between 0 and %d.", maxUserPriority), nil)
+	}
+	cpu, err := resource.ParseQuantity(config.Cpu)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid CPU: %s", config.Cpu), err)
+	}
+	memory, err := resource.ParseQuantity(config.Memory)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid memory: %s", config.Memory), err)
+	}
+	nodeSelector, err := labels.ConvertSelectorToLabelsMap(config.NodeSelector)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid node selector, only key=value pairs are supported: %s", config.NodeSelector), err)
+	}
+	var nodeNames []string
+	if config.TargetNamespace != "" {
+		nodeNames = a.nodesRunningNamespace(config.TargetNamespace)
+		if len(nodeNames) == 0 {
+			return nil, extension_kit.ToError(fmt.Sprintf("No node is running pods of namespace %s.", config.TargetNamespace), nil)
+		}
+	}
+
+	name := "steadybit-preemption-" + strings.Split(request.ExecutionId.String(), "-")[0]
+	state.PriorityClass = &schedulingv1.PriorityClass{
+		ObjectMeta:       metav1.ObjectMeta{Name: name},
+		Value:            config.Priority,
+		PreemptionPolicy: new(corev1.PreemptLowerPriority),
+		Description:      "Temporary PriorityClass of a Steadybit preemption attack, it is deleted when the attack ends.",
+	}
+	state.Pod = fillerPod(name, config.Namespace, corev1.ResourceList{corev1.ResourceCPU: cpu, corev1.ResourceMemory: memory}, nodeSelector, nodeNames)
+	state.Replicas = config.Replicas
+	state.Victims = map[string]bool{}
+	return nil, nil
+}
+
+func (a *PreemptionAction) nodesRunningNamespace(namespace string) []string {
+	var nodeNames []string
+	for _, pod := range a.k8s.Pods() {
+		if pod.Namespace == namespace && pod.Spec.NodeName != "" && !slices.Contains(nodeNames, pod.Spec.NodeName) {
+			nodeNames = append(nodeNames, pod.Spec.NodeName)
+		}
+	}
+	slices.Sort(nodeNames)
+	return nodeNames
+}
+
+func fillerPod(priorityClassName, namespace string, resources corev1.ResourceList, nodeSelector map[string]string, nodeNames []string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "steadybit-filler-",
+			Namespace:    namespace,
+			Labels:       map[string]string{preemptionLabel: priorityClassName},
+		},
+		Spec: corev1.PodSpec{
+			PriorityClassName:             priorityClassName,
+			NodeSelector:                  nodeSelector,
+			TerminationGracePeriodSeconds: new(int64(0)),
+			AutomountServiceAccountToken:  new(false),
+			Containers: []corev1.Container{{
+				Name:  "filler",
+				Image: balloonImage,
+				Resources: corev1.ResourceRequirements{
+					Requests: resources,
+					Limits:   resources,
+				},
+			}},
+		},
+	}
+	if len(nodeNames) > 0 {
+		pod.Spec.Affinity = &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchFields: []corev1.NodeSelectorRequirement{{
+							Key:      metav1.ObjectNameField,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   nodeNames,
+						}},
+					}},
+				},
+			},
+		}
+	}
+	return pod
+}
+
+func (a *PreemptionAction) Start(ctx context.Context, state *PreemptionState) (*action_kit_api.StartResult, error) {
+	state.StartedAt = time.Now()
+	if err := a.k8s.CreatePriorityClass(ctx, state.PriorityClass); err != nil {
+		return nil, extension_kit.ToError("Failed to create the PriorityClass.", err)
+	}
+	for range state.Replicas {
+		pod, err := a.k8s.CreatePod(ctx, state.Pod)
+		if err != nil {
+			// pods created so far are deleted by stop
+			return nil, extension_kit.ToError("Failed to create the filler pods.", err)
+		}
+		state.PodNames = append(state.PodNames, pod.Name)
+	}
+	requests := state.Pod.Spec.Containers[0].Resources.Requests
+	return &action_kit_api.StartResult{Messages: &[]action_kit_api.Message{{
+		Message: fmt.Sprintf("Created %d filler pods with priority %d, each requesting %s CPU and %s memory", state.Replicas, state.PriorityClass.Value, requests.Cpu(), requests.Memory()),
+		Level:   extutil.Ptr(action_kit_api.Info),
+	}}}, nil
+}
+
+func (a *PreemptionAction) Status(ctx context.Context, state *PreemptionState) (*action_kit_api.StatusResult, error) {
+	var messages []action_kit_api.Message
+	for _, event := range *a.k8s.Events(state.StartedAt) {
+		if event.Reason != "Preempted" || event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		victim := event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+		if state.Victims[victim] {
+			continue
+		}
+		state.Victims[victim] = true
+		messages = append(messages, action_kit_api.Message{
+			Message: fmt.Sprintf("Pod %s preempted: %s", victim, event.Message),
+			Level:   extutil.Ptr(action_kit_api.Warn),
+			Fields: &action_kit_api.MessageFields{
+				"namespace": event.InvolvedObject.Namespace,
+				"pod":       event.InvolvedObject.Name,
+			},
+		})
+	}
+
+	pods, err := a.k8s.PodsWithLabels(ctx, state.Pod.Namespace, preemptionLabel+"="+state.PriorityClass.Name)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to read the filler pods.", err)
+	}
+	scheduled := 0
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			scheduled++
+		}
+	}
+	if scheduled != state.LastScheduled {
+		state.LastScheduled = scheduled
+		messages = append(messages, action_kit_api.Message{
+			Message: fmt.Sprintf("%d of %d filler pods scheduled", scheduled, state.Replicas),
+			Level:   extutil.Ptr(action_kit_api.Info),
+		})
+	}
+	return &action_kit_api.StatusResult{Messages: &messages}, nil
+}
+
+func (a *PreemptionAction) Stop(ctx context.Context, state *PreemptionState) (*action_kit_api.StopResult, error) {
+	if state.PriorityClass == nil {
+		return nil, nil
+	}
+	var errs []error
+	remaining := state.PodNames[:0]
+	for _, name := range state.PodNames {
+		err := a.k8s.DeletePod(ctx, state.Pod.Namespace, name, new(int64(0)))
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			errs = append(errs, err)
+			remaining = append(remaining, name)
+		}
+	}
+	state.PodNames = remaining
+	if err := a.k8s.DeletePriorityClass(ctx, state.PriorityClass.Name); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, extension_kit.ToError("Failed to clean up the preemption attack.", errors.Join(errs...))
+	}
+	return &action_kit_api.StopResult{Messages: &[]action_kit_api.Message{{
+		Message: fmt.Sprintf("Deleted the filler pods and PriorityClass %s", state.PriorityClass.Name),
+		Level:   extutil.Ptr(action_kit_api.Info),
+	}}}, nil
+}
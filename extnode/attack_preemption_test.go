@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestPreemptionPrepare(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec:       corev1.PodSpec{NodeName: "worker-2"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+	k8s := createConditionCheckClient(t, clientset)
+	require.Eventually(t, func() bool { return len(k8s.Pods()) == 1 }, time.Second, 10*time.Millisecond)
+	action := &PreemptionAction{k8s: k8s}
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, preemptionRequest(map[string]any{
+		"nodeSelector":    "pool=workers",
+		"targetNamespace": "shop",
+	}))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "steadybit-preemption-2f1c6c1e", state.PriorityClass.Name)
+	assert.Equal(t, int32(1000000), state.PriorityClass.Value)
+	assert.Equal(t, "steadybit-preemption-2f1c6c1e", state.Pod.Spec.PriorityClassName)
+	assert.Equal(t, map[string]string{"pool": "workers"}, state.Pod.Spec.NodeSelector)
+	assert.Equal(t, []string{"worker-2"}, state.Pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values)
+	assert.Equal(t, "500m", state.Pod.Spec.Containers[0].Resources.Requests.Cpu().String())
+
+	// When
+	_, err = action.Prepare(context.Background(), &state, preemptionRequest(map[string]any{"nodeSelector": "pool in (workers)"}))
+
+	// Then
+	require.ErrorContains(t, err, "Invalid node selector")
+
+	// When
+	_, err = action.Prepare(context.Background(), &state, preemptionRequest(map[string]any{"targetNamespace": "unknown"}))
+
+	// Then
+	require.ErrorContains(t, err, "No node is running pods of namespace unknown.")
+}
+
+func TestPreemptionCreatesFillerPodsAndCleansUp(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset()
+	created := 0
+	clientset.PrependReactor("create", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8sTesting.CreateAction).GetObject().(*corev1.Pod)
+		created++
+		pod.Name = pod.GenerateName + string(rune('a'+created))
+		if created == 1 {
+			pod.Spec.NodeName = "worker-1"
+		}
+		return false, nil, nil
+	})
+	k8s := createConditionCheckClient(t, clientset)
+	action := &PreemptionAction{k8s: k8s}
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, preemptionRequest(map[string]any{"replicas": 2}))
+	require.NoError(t, err)
+
+	// When
+	start, err := action.Start(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "Created 2 filler pods with priority 1000000, each requesting 500m CPU and 1Gi memory", (*start.Messages)[0].Message)
+	assert.Equal(t, []string{"steadybit-filler-b", "steadybit-filler-c"}, state.PodNames)
+	_, err = clientset.SchedulingV1().PriorityClasses().Get(context.Background(), state.PriorityClass.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// When
+	_, err = clientset.CoreV1().Events("shop").Create(context.Background(), &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "cart.preempted", Namespace: "shop"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "cart", Namespace: "shop"},
+		Reason:         "Preempted",
+		Message:        "Preempted by pod 1c5e on node worker-1",
+		LastTimestamp:  metav1.Now(),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(*k8s.Events(state.StartedAt)) == 1 }, time.Second, 10*time.Millisecond)
+	status, err := action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Pod shop/cart preempted: Preempted by pod 1c5e on node worker-1",
+		"1 of 2 filler pods scheduled",
+	}, messageTexts(status.Messages))
+
+	// When
+	status, err = action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, messageTexts(status.Messages))
+
+	// When
+	_, err = action.Stop(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	pods, _ := clientset.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{})
+	assert.Empty(t, pods.Items)
+	priorityClasses, _ := clientset.SchedulingV1().PriorityClasses().List(context.Background(), metav1.ListOptions{})
+	assert.Empty(t, priorityClasses.Items)
+}
+
+func preemptionRequest(config map[string]any) action_kit_api.PrepareActionRequestBody {
+	request := action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.MustParse("2f1c6c1e-6f0e-4a53-9a4b-0c6a3e4b8d11"),
+		Config: map[string]any{
+			"duration":  60000,
+			"priority":  1000000,
+			"replicas":  10,
+			"cpu":       "500m",
+			"memory":    "1Gi",
+			"namespace": "default",
+		},
+		Target: new(action_kit_api.Target{Attributes: map[string][]string{"k8s.cluster-name": {"development"}}}),
+	}
+	for key, value := range config {
+		request.Config[key] = value
+	}
+	return request
+}
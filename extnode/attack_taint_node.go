@@ -5,29 +5,53 @@ package extnode
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
 	extension_kit "github.com/steadybit/extension-kit"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extconversion"
-	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
 )
 
-func NewTaintNodeAction() action_kit_sdk.Action[extcommon.KubectlActionState] {
-	return &extcommon.KubectlAction{
-		Description:  getTaintNodeDescription(),
-		OptsProvider: taintNode(),
-	}
+type TaintNodeAction struct {
+	k8s *client.Client
+}
+
+type TaintNodeState struct {
+	NodeName string
+	Taints   []corev1.Taint
+	// Original holds the taints of the node before the attack, nil until the taints are applied.
+	Original []corev1.Taint
+	Applied  bool
 }
 
 type TaintNodeConfig struct {
-	Key    string
-	Value  string
-	Effect string
+	Key              string
+	Value            string
+	Effect           string
+	AdditionalTaints []string
 }
 
-func getTaintNodeDescription() action_kit_api.ActionDescription {
+func NewTaintNodeAction(k8s *client.Client) action_kit_sdk.Action[TaintNodeState] {
+	return &TaintNodeAction{k8s: k8s}
+}
+
+var _ action_kit_sdk.Action[TaintNodeState] = (*TaintNodeAction)(nil)
+var _ action_kit_sdk.ActionWithStop[TaintNodeState] = (*TaintNodeAction)(nil)
+
+func (a *TaintNodeAction) NewEmptyState() TaintNodeState {
+	return TaintNodeState{}
+}
+
+func (a *TaintNodeAction) Describe() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:              TaintNodeActionId,
 		Label:           "Taint Node",
@@ -65,7 +89,7 @@ func getTaintNodeDescription() action_kit_api.ActionDescription {
 				Description: new("The optional value of the taint."),
 				Advanced:    new(false),
 				Required:    new(false),
-				Order:       new(1),
+				Order:       new(2),
 			},
 			{
 				Label:        "Effect",
@@ -75,7 +99,7 @@ func getTaintNodeDescription() action_kit_api.ActionDescription {
 				Advanced:     new(false),
 				Required:     new(true),
 				DefaultValue: new("NoSchedule"),
-				Order:        new(2),
+				Order:        new(3),
 				Options: new([]action_kit_api.ParameterOption{
 					action_kit_api.ExplicitParameterOption{
 						Label: "NoSchedule",
@@ -91,48 +115,185 @@ func getTaintNodeDescription() action_kit_api.ActionDescription {
 					},
 				}),
 			},
+			{
+				Label:       "Additional taints",
+				Name:        "additionalTaints",
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Description: new("Further taints in the format of kubectl taint, e.g. dedicated=gpu:NoExecute or spot:NoSchedule."),
+				Advanced:    new(true),
+				Required:    new(false),
+				Order:       new(4),
+			},
 		},
 		Prepare: action_kit_api.MutatingEndpointReference{},
 		Start:   action_kit_api.MutatingEndpointReference{},
-		Status:  &action_kit_api.MutatingEndpointReferenceWithCallInterval{},
-		Stop:    &action_kit_api.MutatingEndpointReference{},
+		Stop:    new(action_kit_api.MutatingEndpointReference{}),
 	}
 }
 
-func taintNode() extcommon.KubectlOptsProvider {
-	return func(ctx context.Context, request action_kit_api.PrepareActionRequestBody) (*extcommon.KubectlOpts, error) {
-		nodeName := request.Target.Attributes["host.hostname"][0]
-		var config TaintNodeConfig
-		if err := extconversion.Convert(request.Config, &config); err != nil {
-			return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+func (a *TaintNodeAction) Prepare(ctx context.Context, state *TaintNodeState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config TaintNodeConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	taints := []corev1.Taint{{Key: config.Key, Value: config.Value, Effect: corev1.TaintEffect(config.Effect)}}
+	for _, spec := range config.AdditionalTaints {
+		taint, err := parseTaint(spec)
+		if err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Invalid taint %s.", spec), err)
 		}
+		taints = append(taints, taint)
+	}
+	for i, taint := range taints {
+		if err := validateTaint(taint); err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Invalid taint %s.", taint.ToString()), err)
+		}
+		if slices.ContainsFunc(taints[:i], matchesTaint(taint)) {
+			return nil, extension_kit.ToError(fmt.Sprintf("Taint %s is configured twice.", taint.ToString()), nil)
+		}
+	}
+
+	state.NodeName = request.Target.Attributes["host.hostname"][0]
+	state.Taints = taints
+
+	if !slices.ContainsFunc(taints, func(taint corev1.Taint) bool { return taint.Effect == corev1.TaintEffectNoExecute }) {
+		return nil, nil
+	}
+	pods, err := a.k8s.PodsOnNode(ctx, state.NodeName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to list the pods of node %s.", state.NodeName), err)
+	}
+	return &action_kit_api.PrepareResult{Messages: &[]action_kit_api.Message{
+		NodeMessage(state.NodeName, noExecutePreview(pods, taints), action_kit_api.Info),
+	}}, nil
+}
+
+// parseTaint parses a taint in the format of kubectl taint: key[=value]:effect.
+func parseTaint(spec string) (corev1.Taint, error) {
+	keyValue, effect, found := strings.Cut(spec, ":")
+	if !found {
+		return corev1.Taint{}, fmt.Errorf("expected the format key[=value]:effect")
+	}
+	key, value, _ := strings.Cut(keyValue, "=")
+	return corev1.Taint{Key: key, Value: value, Effect: corev1.TaintEffect(effect)}, nil
+}
 
-		taint := config.Key
-		if config.Value != "" {
-			taint = taint + "=" + config.Value
+func validateTaint(taint corev1.Taint) error {
+	var problems []string
+	problems = append(problems, validation.IsQualifiedName(taint.Key)...)
+	if taint.Value != "" {
+		problems = append(problems, validation.IsValidLabelValue(taint.Value)...)
+	}
+	switch taint.Effect {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown effect %q", taint.Effect))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// noExecutePreview describes the running pods evicted by the NoExecute taints, i.e. the pods without a toleration for
+// each of them. Pods tolerating a taint only for a limited time are evicted after that time.
+func noExecutePreview(pods []corev1.Pod, taints []corev1.Taint) string {
+	var evicted []string
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		key := pod.Namespace + "/" + pod.Name
+		var tolerationSeconds *int64
+		tolerated := true
+		for _, taint := range taints {
+			if taint.Effect != corev1.TaintEffectNoExecute {
+				continue
+			}
+			toleration := findToleration(pod.Spec.Tolerations, &taint)
+			if toleration == nil {
+				tolerated = false
+				break
+			}
+			if toleration.TolerationSeconds != nil && (tolerationSeconds == nil || *toleration.TolerationSeconds < *tolerationSeconds) {
+				tolerationSeconds = toleration.TolerationSeconds
+			}
+		}
+		if !tolerated {
+			evicted = append(evicted, key)
+		} else if tolerationSeconds != nil {
+			evicted = append(evicted, fmt.Sprintf("%s (after %ds)", key, max(*tolerationSeconds, 0)))
+		}
+	}
+	if len(evicted) == 0 {
+		return "NoExecute taint evicts no running pod"
+	}
+	slices.Sort(evicted)
+	return fmt.Sprintf("NoExecute taint evicts %d running pods: %s", len(evicted), strings.Join(evicted, ", "))
+}
+
+func findToleration(tolerations []corev1.Toleration, taint *corev1.Taint) *corev1.Toleration {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(klog.Background(), taint, true) {
+			return &tolerations[i]
 		}
-		taint = taint + ":" + config.Effect
-
-		command := []string{"kubectl",
-			"taint",
-			"node",
-			nodeName,
-			taint}
-
-		rollbackCommand := []string{
-			"kubectl",
-			"taint",
-			"node",
-			nodeName,
-			taint + "-",
+	}
+	return nil
+}
+
+func (a *TaintNodeAction) Start(ctx context.Context, state *TaintNodeState) (*action_kit_api.StartResult, error) {
+	now := metav1.Now()
+	original, err := a.k8s.UpdateNodeTaints(ctx, state.NodeName, func(current []corev1.Taint) []corev1.Taint {
+		// taints with the same key and effect are replaced, they are restored on stop
+		result := slices.DeleteFunc(current, func(taint corev1.Taint) bool { return slices.ContainsFunc(state.Taints, matchesTaint(taint)) })
+		for _, taint := range state.Taints {
+			if taint.Effect == corev1.TaintEffectNoExecute {
+				taint.TimeAdded = &now
+			}
+			result = append(result, taint)
+		}
+		return result
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to taint node %s.", state.NodeName), err)
+	}
+	state.Original = original
+	state.Applied = true
+
+	messages := make([]action_kit_api.Message, 0, len(state.Taints))
+	for _, taint := range state.Taints {
+		messages = append(messages, NodeMessage(state.NodeName, "tainted with "+taint.ToString(), action_kit_api.Info))
+	}
+	return &action_kit_api.StartResult{Messages: &messages}, nil
+}
+
+// Stop restores the prior taints for the keys and effects changed by the attack. Taints added by others in the
+// meantime, e.g. by the node lifecycle controller, are kept.
+func (a *TaintNodeAction) Stop(ctx context.Context, state *TaintNodeState) (*action_kit_api.StopResult, error) {
+	if !state.Applied {
+		return nil, nil
+	}
+	_, err := a.k8s.UpdateNodeTaints(ctx, state.NodeName, func(current []corev1.Taint) []corev1.Taint {
+		changed := func(taint corev1.Taint) bool { return slices.ContainsFunc(state.Taints, matchesTaint(taint)) }
+		result := slices.DeleteFunc(current, changed)
+		for _, taint := range state.Original {
+			if changed(taint) {
+				result = append(result, taint)
+			}
 		}
+		return result
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore the taints of node %s.", state.NodeName), err)
+	}
+	state.Applied = false
+	return &action_kit_api.StopResult{Messages: &[]action_kit_api.Message{
+		NodeMessage(state.NodeName, "taints restored", action_kit_api.Info),
+	}}, nil
+}
 
-		return &extcommon.KubectlOpts{
-			Command:         command,
-			RollbackCommand: rollbackCommand,
-			LogTargetType:   "node",
-			LogTargetName:   nodeName,
-			LogActionName:   "taint node",
-		}, nil
+func matchesTaint(taint corev1.Taint) func(corev1.Taint) bool {
+	return func(other corev1.Taint) bool {
+		return taint.MatchTaint(&other)
 	}
 }
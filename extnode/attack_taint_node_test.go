@@ -8,17 +8,112 @@ import (
 	"testing"
 
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
 )
 
-func TestTaintNodePreparesCommands(t *testing.T) {
+func TestTaintNodePrepareParsesTaints(t *testing.T) {
 	// Given
-	request := action_kit_api.PrepareActionRequestBody{
+	action := &TaintNodeAction{k8s: createConditionCheckClient(t, testclient.NewClientset())}
+	state := action.NewEmptyState()
+
+	// When
+	result, err := action.Prepare(context.Background(), &state, taintNodeRequest("test", "abc", "NoSchedule", "dedicated=gpu:PreferNoSchedule", "spot:NoSchedule"))
+
+	// Then
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "test", state.NodeName)
+	assert.Equal(t, []corev1.Taint{
+		{Key: "test", Value: "abc", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectPreferNoSchedule},
+		{Key: "spot", Effect: corev1.TaintEffectNoSchedule},
+	}, state.Taints)
+
+	// When
+	_, err = action.Prepare(context.Background(), &state, taintNodeRequest("test", "abc", "NoSchedule", "dedicated=gpu"))
+
+	// Then
+	require.ErrorContains(t, err, "Invalid taint dedicated=gpu.")
+
+	// When
+	_, err = action.Prepare(context.Background(), &state, taintNodeRequest("test", "abc", "NoSchedule", "test=other:NoSchedule"))
+
+	// Then
+	require.ErrorContains(t, err, "Taint test=other:NoSchedule is configured twice.")
+}
+
+func TestTaintNodePreparePreviewsNoExecuteEvictions(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(
+		taintPod("checkout"),
+		taintPod("monitoring", corev1.Toleration{Operator: corev1.TolerationOpExists}),
+		taintPod("cart", corev1.Toleration{Key: "outage", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: new(int64(30))}),
+		taintPod("payment", corev1.Toleration{Key: "outage", Operator: corev1.TolerationOpEqual, Value: "other"}),
+	)
+	action := &TaintNodeAction{k8s: createConditionCheckClient(t, clientset)}
+	state := action.NewEmptyState()
+
+	// When
+	result, err := action.Prepare(context.Background(), &state, taintNodeRequest("outage", "zone", "NoExecute"))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Node test NoExecute taint evicts 3 running pods: shop/cart (after 30s), shop/checkout, shop/payment"}, messageTexts(result.Messages))
+}
+
+func TestTaintNodeRestoresPriorTaints(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "dedicated", Value: "batch", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+		}},
+	})
+	action := &TaintNodeAction{k8s: createConditionCheckClient(t, clientset)}
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, taintNodeRequest("dedicated", "gpu", "NoSchedule", "outage:NoExecute"))
+	require.NoError(t, err)
+
+	// When
+	start, err := action.Start(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Node test tainted with dedicated=gpu:NoSchedule", "Node test tainted with outage:NoExecute"}, messageTexts(start.Messages))
+	node, _ := clientset.CoreV1().Nodes().Get(context.Background(), "test", metav1.GetOptions{})
+	require.Len(t, node.Spec.Taints, 3)
+	assert.Equal(t, corev1.Taint{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule}, node.Spec.Taints[0])
+	assert.Equal(t, "gpu", node.Spec.Taints[1].Value)
+	assert.NotNil(t, node.Spec.Taints[2].TimeAdded)
+
+	// When
+	node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{Key: "node.kubernetes.io/unreachable", Effect: corev1.TaintEffectNoSchedule})
+	_, _ = clientset.CoreV1().Nodes().Update(context.Background(), node, metav1.UpdateOptions{})
+	_, err = action.Stop(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	node, _ = clientset.CoreV1().Nodes().Get(context.Background(), "test", metav1.GetOptions{})
+	assert.Equal(t, []corev1.Taint{
+		{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+		{Key: "node.kubernetes.io/unreachable", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "batch", Effect: corev1.TaintEffectNoSchedule},
+	}, node.Spec.Taints)
+}
+
+func taintNodeRequest(key, value, effect string, additionalTaints ...any) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
 		Config: map[string]any{
-			"duration": 100000,
-			"key":      "test",
-			"value":    "abc",
-			"effect":   "NoSchedule",
+			"duration":         100000,
+			"key":              key,
+			"value":            value,
+			"effect":           effect,
+			"additionalTaints": additionalTaints,
 		},
 		Target: new(action_kit_api.Target{
 			Attributes: map[string][]string{
@@ -26,15 +121,12 @@ func TestTaintNodePreparesCommands(t *testing.T) {
 			},
 		}),
 	}
+}
 
-	action := NewTaintNodeAction()
-	state := action.NewEmptyState()
-
-	// When
-	_, err := action.Prepare(context.Background(), &state, request)
-	require.NoError(t, err)
-
-	// Then
-	require.Equal(t, []string{"kubectl", "taint", "node", "test", "test=abc:NoSchedule"}, state.Opts.Command)
-	require.Equal(t, []string{"kubectl", "taint", "node", "test", "test=abc:NoSchedule-"}, state.Opts.RollbackCommand)
+func taintPod(name string, tolerations ...corev1.Toleration) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+		Spec:       corev1.PodSpec{NodeName: "test", Tolerations: tolerations},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
 }
@@ -8,10 +8,16 @@ import (
 )
 
 const (
-	NodeTargetType         = "com.steadybit.extension_kubernetes.kubernetes-node"
-	DrainNodeActionId      = "com.steadybit.extension_kubernetes.drain_node"
-	TaintNodeActionId      = "com.steadybit.extension_kubernetes.taint_node"
-	NodeCountCheckActionId = "com.steadybit.extension_kubernetes.node_count_check"
+	NodeTargetType          = "com.steadybit.extension_kubernetes.kubernetes-node"
+	DrainNodeActionId       = "com.steadybit.extension_kubernetes.drain_node"
+	TaintNodeActionId       = "com.steadybit.extension_kubernetes.taint_node"
+	NodeCountCheckActionId  = "com.steadybit.extension_kubernetes.node_count_check"
+	CapacitySqueezeActionId = "com.steadybit.extension_kubernetes.capacity_squeeze"
+	PreemptionActionId      = "com.steadybit.extension_kubernetes.preemption"
+	NodeLabelActionId       = "com.steadybit.extension_kubernetes.node_label"
+
+	NodeConditionCheckActionId        = "com.steadybit.extension_kubernetes.node_condition_check"
+	ClusterNodeConditionCheckActionId = "com.steadybit.extension_kubernetes.node_condition_check_cluster"
 )
 
 var (
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// mirrorPodAnnotation marks static pods, they are managed by the kubelet and can't be evicted.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// drainExcludedLabels mark the pods of steadybit itself, they are never evicted to keep the experiment running.
+var drainExcludedLabels = map[string]string{
+	"steadybit.com/extension": "true",
+	"steadybit.com/agent":     "true",
+}
+
+// IsEvictable reports whether draining a node evicts the pod. Like kubectl drain --ignore-daemonsets, pods managed by
+// a DaemonSet, static pods, completed pods and the pods of steadybit are left alone.
+func IsEvictable(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller != nil && *owner.Controller && owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+	for key, value := range drainExcludedLabels {
+		if pod.Labels[key] == value {
+			return false
+		}
+	}
+	return true
+}
+
+func hasLocalStorage(pod *corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// DrainOptions control which pods a drain removes from a node and how.
+type DrainOptions struct {
+	// GracePeriodSeconds overrides the termination grace period of the pods, nil keeps the pods' own.
+	GracePeriodSeconds *int64
+	// PodSelector is a label selector restricting the drain to the matching pods.
+	PodSelector string
+	// SkipLocalStorage leaves pods with emptyDir volumes on the node, their data would be lost otherwise.
+	SkipLocalStorage bool
+	// Force deletes the pods instead of evicting them, which bypasses PodDisruptionBudgets.
+	Force bool
+}
+
+// DrainProgress tracks the drain of a node across status calls. It is part of the action state, so every outcome of a
+// pod is reported only once.
+type DrainProgress struct {
+	Drained bool
+	// Attempts counts the evictions of a pod blocked by a PodDisruptionBudget.
+	Attempts map[string]int
+	// Skipped holds the pods left on the node because of their local storage.
+	Skipped map[string]bool
+	// Remaining holds the pods still running on the node after the last step.
+	Remaining []string
+}
+
+// Step evicts the remaining pods of the node once. Evictions blocked by a PodDisruptionBudget are retried with the
+// next step, the node is drained as soon as all pods are gone.
+func (p *DrainProgress) Step(ctx context.Context, k8s *client.Client, nodeName string, options DrainOptions) ([]action_kit_api.Message, error) {
+	if p.Drained {
+		return nil, nil
+	}
+	if p.Attempts == nil {
+		p.Attempts = map[string]int{}
+	}
+	if p.Skipped == nil {
+		p.Skipped = map[string]bool{}
+	}
+	selector, err := labels.Parse(options.PodSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod selector %q: %w", options.PodSelector, err)
+	}
+
+	pods, err := k8s.PodsOnNode(ctx, nodeName)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to list pods of node %s", nodeName)
+		return nil, nil
+	}
+
+	var messages []action_kit_api.Message
+	p.Remaining = nil
+	for _, pod := range pods {
+		if !IsEvictable(&pod) || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		key := pod.Namespace + "/" + pod.Name
+		if options.SkipLocalStorage && hasLocalStorage(&pod) {
+			if !p.Skipped[key] {
+				p.Skipped[key] = true
+				messages = append(messages, NodeMessage(nodeName, "skipped pod "+key+" with local storage", action_kit_api.Info))
+			}
+			continue
+		}
+		p.Remaining = append(p.Remaining, key)
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		if options.Force {
+			err = k8s.DeletePod(ctx, pod.Namespace, pod.Name, options.GracePeriodSeconds)
+		} else {
+			err = k8s.EvictPod(ctx, pod.Namespace, pod.Name, options.GracePeriodSeconds)
+		}
+		switch {
+		case err == nil && options.Force:
+			messages = append(messages, NodeMessage(nodeName, "deleted pod "+key, action_kit_api.Info))
+		case err == nil && p.Attempts[key] > 0:
+			messages = append(messages, NodeMessage(nodeName, fmt.Sprintf("evicted pod %s after %d attempts", key, p.Attempts[key]+1), action_kit_api.Info))
+		case err == nil:
+			messages = append(messages, NodeMessage(nodeName, "evicted pod "+key, action_kit_api.Info))
+		case k8sErrors.IsTooManyRequests(err):
+			p.Attempts[key]++
+			if p.Attempts[key] == 1 {
+				messages = append(messages, NodeMessage(nodeName, "eviction of pod "+key+" blocked by PodDisruptionBudget, retrying", action_kit_api.Warn))
+			}
+		case k8sErrors.IsNotFound(err):
+			p.Remaining = p.Remaining[:len(p.Remaining)-1]
+		default:
+			messages = append(messages, NodeMessage(nodeName, fmt.Sprintf("failed to evict pod %s: %s", key, err), action_kit_api.Warn))
+		}
+	}
+	if len(p.Remaining) == 0 {
+		p.Drained = true
+		messages = append(messages, NodeMessage(nodeName, "drained", action_kit_api.Info))
+	}
+	return messages, nil
+}
+
+// NodeMessage creates an action message about the node.
+func NodeMessage(nodeName, message string, level action_kit_api.MessageLevel) action_kit_api.Message {
+	return action_kit_api.Message{
+		Message: fmt.Sprintf("Node %s %s", nodeName, message),
+		Level:   extutil.Ptr(level),
+		Fields: &action_kit_api.MessageFields{
+			"node": nodeName,
+		},
+	}
+}
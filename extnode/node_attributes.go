@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeResources are the resources reported as capacity and allocatable attributes.
+var nodeResources = []corev1.ResourceName{
+	corev1.ResourceCPU,
+	corev1.ResourceMemory,
+	corev1.ResourcePods,
+	corev1.ResourceEphemeralStorage,
+}
+
+// addNodeAttributes adds the attributes describing the node itself: its resources, conditions, versions, taints and
+// cloud metadata.
+func addNodeAttributes(attributes map[string][]string, node *corev1.Node) {
+	for _, name := range nodeResources {
+		if quantity, ok := node.Status.Capacity[name]; ok {
+			attributes["k8s.node.capacity."+string(name)] = []string{quantity.String()}
+		}
+		if quantity, ok := node.Status.Allocatable[name]; ok {
+			attributes["k8s.node.allocatable."+string(name)] = []string{quantity.String()}
+		}
+	}
+
+	for _, condition := range node.Status.Conditions {
+		attributes["k8s.node.condition."+string(condition.Type)] = []string{string(condition.Status)}
+	}
+
+	info := node.Status.NodeInfo
+	addIfPresent(attributes, "k8s.node.kubelet-version", info.KubeletVersion)
+	addIfPresent(attributes, "k8s.node.container-runtime-version", info.ContainerRuntimeVersion)
+	addIfPresent(attributes, "k8s.node.kernel-version", info.KernelVersion)
+	addIfPresent(attributes, "k8s.node.os-image", info.OSImage)
+	addIfPresent(attributes, "k8s.node.operating-system", info.OperatingSystem)
+	addIfPresent(attributes, "k8s.node.architecture", info.Architecture)
+
+	attributes["k8s.node.unschedulable"] = []string{strconv.FormatBool(node.Spec.Unschedulable)}
+	if len(node.Spec.Taints) > 0 {
+		taints := make([]string, 0, len(node.Spec.Taints))
+		for _, taint := range node.Spec.Taints {
+			taints = append(taints, taint.ToString())
+		}
+		slices.Sort(taints)
+		attributes["k8s.node.taint"] = taints
+	}
+
+	if node.Spec.ProviderID != "" {
+		attributes["k8s.node.provider-id"] = []string{node.Spec.ProviderID}
+		provider := parseProviderID(node.Spec.ProviderID)
+		addIfPresent(attributes, "k8s.node.cloud", provider.cloud)
+		addIfPresent(attributes, "k8s.node.instance-id", provider.instanceID)
+		if provider.region == "" {
+			provider.region = node.Labels[corev1.LabelTopologyRegion]
+		}
+		addIfPresent(attributes, "k8s.node.region", provider.region)
+	}
+}
+
+func addIfPresent(attributes map[string][]string, key string, value string) {
+	if value != "" {
+		attributes[key] = []string{value}
+	}
+}
+
+type providerID struct {
+	cloud      string
+	region     string
+	instanceID string
+}
+
+// parseProviderID splits the provider ID set by the cloud controller into cloud, region and instance ID. The formats
+// differ per cloud, e.g.:
+//
+//	aws:///eu-central-1a/i-0123456789abcdef0
+//	gce://my-project/europe-west3-b/gke-pool-1-abcd
+//	azure:///subscriptions/<id>/resourceGroups/<group>/providers/Microsoft.Compute/virtualMachines/<name>
+//
+// Only AWS and GCE encode the zone, the region is derived from it. For other clouds the instance ID is the last
+// segment of the path.
+func parseProviderID(id string) providerID {
+	cloud, path, found := strings.Cut(id, "://")
+	if !found {
+		return providerID{}
+	}
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return providerID{cloud: cloud}
+	}
+	result := providerID{cloud: cloud, instanceID: segments[len(segments)-1]}
+	switch cloud {
+	case "aws":
+		// availability zones are the region plus a letter, e.g. eu-central-1a
+		if len(segments) == 2 && len(segments[0]) > 1 {
+			result.region = segments[0][:len(segments[0])-1]
+		}
+	case "gce":
+		// zones are the region plus a suffix, e.g. europe-west3-b
+		if len(segments) == 3 {
+			if i := strings.LastIndex(segments[1], "-"); i > 0 {
+				result.region = segments[1][:i]
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_addNodeAttributes(t *testing.T) {
+	// Given
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Spec: corev1.NodeSpec{
+			ProviderID:    "aws:///eu-central-1a/i-0123456789abcdef0",
+			Unschedulable: true,
+			Taints: []corev1.Taint{
+				{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3920m"),
+				corev1.ResourceMemory: resource.MustParse("15Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+			},
+			NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion:          "v1.31.2",
+				ContainerRuntimeVersion: "containerd://1.7.22",
+				KernelVersion:           "6.1.112",
+				OSImage:                 "Amazon Linux 2023",
+				OperatingSystem:         "linux",
+				Architecture:            "arm64",
+			},
+		},
+	}
+	attributes := map[string][]string{}
+
+	// When
+	addNodeAttributes(attributes, node)
+
+	// Then
+	assert.Equal(t, map[string][]string{
+		"k8s.node.capacity.cpu":              {"4"},
+		"k8s.node.capacity.memory":           {"16Gi"},
+		"k8s.node.capacity.pods":             {"110"},
+		"k8s.node.allocatable.cpu":           {"3920m"},
+		"k8s.node.allocatable.memory":        {"15Gi"},
+		"k8s.node.allocatable.pods":          {"110"},
+		"k8s.node.condition.Ready":           {"True"},
+		"k8s.node.condition.MemoryPressure":  {"False"},
+		"k8s.node.kubelet-version":           {"v1.31.2"},
+		"k8s.node.container-runtime-version": {"containerd://1.7.22"},
+		"k8s.node.kernel-version":            {"6.1.112"},
+		"k8s.node.os-image":                  {"Amazon Linux 2023"},
+		"k8s.node.operating-system":          {"linux"},
+		"k8s.node.architecture":              {"arm64"},
+		"k8s.node.unschedulable":             {"true"},
+		"k8s.node.taint":                     {"dedicated=gpu:NoExecute", "node.kubernetes.io/unschedulable:NoSchedule"},
+		"k8s.node.provider-id":               {"aws:///eu-central-1a/i-0123456789abcdef0"},
+		"k8s.node.cloud":                     {"aws"},
+		"k8s.node.region":                    {"eu-central-1"},
+		"k8s.node.instance-id":               {"i-0123456789abcdef0"},
+	}, attributes)
+}
+
+func Test_parseProviderID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want providerID
+	}{
+		{"aws:///eu-central-1a/i-0123456789abcdef0", providerID{"aws", "eu-central-1", "i-0123456789abcdef0"}},
+		{"gce://my-project/europe-west3-b/gke-pool-1-abcd", providerID{"gce", "europe-west3", "gke-pool-1-abcd"}},
+		{"azure:///subscriptions/1234/resourceGroups/mc_rg/providers/Microsoft.Compute/virtualMachineScaleSets/aks-pool-1/virtualMachines/3", providerID{"azure", "", "3"}},
+		{"kind://docker/kind/kind-control-plane", providerID{"kind", "", "kind-control-plane"}},
+		{"digitalocean://412345678", providerID{"digitalocean", "", "412345678"}},
+		{"i-0123456789abcdef0", providerID{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseProviderID(tt.id))
+		})
+	}
+}
@@ -0,0 +1,356 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcluster"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// conditionSchedulable is not a node condition, it reflects spec.unschedulable (i.e. whether the node is cordoned).
+const conditionSchedulable = "Schedulable"
+
+// healthyConditionStatus is the status every asserted condition is expected to have.
+var healthyConditionStatus = map[string]corev1.ConditionStatus{
+	string(corev1.NodeReady):              corev1.ConditionTrue,
+	string(corev1.NodeMemoryPressure):     corev1.ConditionFalse,
+	string(corev1.NodeDiskPressure):       corev1.ConditionFalse,
+	string(corev1.NodePIDPressure):        corev1.ConditionFalse,
+	string(corev1.NodeNetworkUnavailable): corev1.ConditionFalse,
+	conditionSchedulable:                  corev1.ConditionTrue,
+}
+
+type NodeConditionCheckAction struct {
+	actionId        string
+	targetType      string
+	targetTypeLabel string
+	targetSelection *action_kit_api.TargetSelection
+	// nodeName resolves the checked node. The cluster-wide check verifies all nodes if nil.
+	nodeName func(request action_kit_api.PrepareActionRequestBody) string
+}
+
+type NodeConditionCheckState struct {
+	Timeout         time.Time
+	Target          string
+	NodeName        string
+	Conditions      []string
+	StatusCheckMode extcommon.StatusCheckMode
+	// LastStatus holds the last observed status per node and condition to detect flips.
+	LastStatus map[string]map[string]corev1.ConditionStatus
+	Flips      []NodeConditionFlip
+}
+
+// NodeConditionFlip records a change of a condition of a node observed during the check.
+type NodeConditionFlip struct {
+	Node      string
+	Condition string
+	From      corev1.ConditionStatus
+	To        corev1.ConditionStatus
+	Time      time.Time
+}
+
+type NodeConditionCheckConfig struct {
+	Duration        int
+	Conditions      []string
+	Schedulable     bool
+	StatusCheckMode extcommon.StatusCheckMode
+}
+
+func NewNodeConditionCheckAction() action_kit_sdk.Action[NodeConditionCheckState] {
+	return NodeConditionCheckAction{
+		actionId:        NodeConditionCheckActionId,
+		targetType:      NodeTargetType,
+		targetTypeLabel: "Node",
+		targetSelection: &targetSelectionTemplates,
+		nodeName: func(request action_kit_api.PrepareActionRequestBody) string {
+			return request.Target.Attributes["k8s.node.name"][0]
+		},
+	}
+}
+
+func NewClusterNodeConditionCheckAction() action_kit_sdk.Action[NodeConditionCheckState] {
+	return NodeConditionCheckAction{
+		actionId:        ClusterNodeConditionCheckActionId,
+		targetType:      extcluster.ClusterTargetType,
+		targetTypeLabel: "Cluster Node",
+		targetSelection: new(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.QuantityRestrictionExactlyOne),
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "cluster name",
+					Description: new("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+	}
+}
+
+var _ action_kit_sdk.Action[NodeConditionCheckState] = (*NodeConditionCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[NodeConditionCheckState] = (*NodeConditionCheckAction)(nil)
+
+func (f NodeConditionCheckAction) NewEmptyState() NodeConditionCheckState {
+	return NodeConditionCheckState{}
+}
+
+func (f NodeConditionCheckAction) Describe() action_kit_api.ActionDescription {
+	description := "Verify that the node is ready, schedulable and under no resource pressure."
+	if f.nodeName == nil {
+		description = "Verify that all nodes of the cluster are ready, schedulable and under no resource pressure."
+	}
+	return action_kit_api.ActionDescription{
+		Id:          f.actionId,
+		Label:       f.targetTypeLabel + " Conditions",
+		Description: description,
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new("data:image/svg+xml;base64,PHN2ZyB3aWR0aD0iMjQiIGhlaWdodD0iMjQiIHZpZXdCb3g9IjAgMCAyNCAyNCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KPHBhdGggZmlsbC1ydWxlPSJldmVub2RkIiBjbGlwLXJ1bGU9ImV2ZW5vZGQiIGQ9Ik02LjQ5IDkuNjNMMi41OSA4LjE2QzIuMjMgOC4wMyAyIDcuNjkgMiA3LjMxQzIgNi45MyAyLjIzIDYuNTkgMi41OSA2LjQ2TDExLjYgMy4wNkMxMS44IDIuOTggMTIuMDMgMi45OCAxMi4yMyAzLjA2TDIxLjI0IDYuNDZDMjEuNiA2LjU5IDIxLjgzIDYuOTMgMjEuODMgNy4zMUMyMS44MyA3LjY5IDIxLjU5IDguMDMgMjEuMjQgOC4xNkwxNy40OSA5LjU4QzE2LjU1IDcuNDcgMTQuNDcgNiAxMiA2QzkuNTMgNiA3LjQxIDcuNDkgNi40OSA5LjYzWk0xNCAxMC4wMUwxMS4xNyAxMi45OEwxMC4wMSAxMS43NUM5Ljc0IDExLjQ3IDkuMyAxMS40NyA5LjAyIDExLjczQzguNzQgMTIgOC43NCAxMi40NCA5IDEyLjcyTDEwLjY2IDE0LjQ3QzEwLjc5IDE0LjYxIDEwLjk3IDE0LjY4IDExLjE2IDE0LjY4QzExLjM1IDE0LjY4IDExLjUzIDE0LjYgMTEuNjYgMTQuNDdMMTUgMTAuOTdDMTUuMjcgMTAuNjkgMTUuMjYgMTAuMjUgMTQuOTggOS45OEMxNC43IDkuNzEgMTQuMjYgOS43MiAxMy45OSAxMEwxNCAxMC4wMVpNMy4yMTk5OCAxMS4yM0MyLjc0OTk4IDExLjA1IDIuMjI5OTggMTEuMjkgMi4wNTk5OCAxMS43NkMxLjg4OTk4IDEyLjIzIDIuMTE5OTggMTIuNzUgMi41ODk5OCAxMi45M0w2LjUxOTk4IDE0LjQxQzYuMjI5OTggMTMuNzUgNi4wNTk5OCAxMy4wNCA2LjAxOTk4IDEyLjI4TDMuMjE5OTggMTEuMjJWMTEuMjNaTTIwLjYgMTYuMDFMMTEuOTEgMTkuMjlMMy4yMTk5OCAxNi4wMUMyLjc0OTk4IDE1LjgzIDIuMjI5OTggMTYuMDcgMi4wNTk5OCAxNi41NEMxLjg4OTk4IDE3LjAxIDIuMTE5OTggMTcuNTMgMi41ODk5OCAxNy43MUwxMS42IDIxLjExQzExLjggMjEuMTkgMTIuMDMgMjEuMTkgMTIuMjMgMjEuMTFMMjEuMjQgMTcuNzFDMjEuNzEgMTcuNTMgMjEuOTQgMTcuMDEgMjEuNzcgMTYuNTRDMjEuNiAxNi4wNyAyMS4wOCAxNS44MyAyMC42MSAxNi4wMUgyMC42Wk0xNy45OCAxMi4yMkwyMC42IDExLjIzQzIxLjA3IDExLjA1IDIxLjU5IDExLjI5IDIxLjc2IDExLjc2QzIxLjkzIDEyLjIzIDIxLjcgMTIuNzUgMjEuMjMgMTIuOTNMMTcuNTIgMTQuMzNDMTcuOCAxMy42OCAxNy45NSAxMi45NyAxNy45OCAxMi4yMloiIGZpbGw9IiMxRDI2MzIiLz4KPC9zdmc+Cg=="),
+		Technology:  new("Kubernetes"),
+
+		Kind:            action_kit_api.Check,
+		TimeControl:     action_kit_api.TimeControlInternal,
+		TargetSelection: f.targetSelection,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new("How long should the node conditions be checked."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:         "conditions",
+				Label:        "Conditions",
+				Description:  new("Conditions that have to be healthy: Ready has to be true, all others false."),
+				Type:         action_kit_api.ActionParameterTypeStringArray,
+				DefaultValue: new("[\"Ready\",\"MemoryPressure\",\"DiskPressure\",\"PIDPressure\",\"NetworkUnavailable\"]"),
+				Order:        new(2),
+				Required:     new(true),
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "Ready",
+						Value: string(corev1.NodeReady),
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "No memory pressure",
+						Value: string(corev1.NodeMemoryPressure),
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "No disk pressure",
+						Value: string(corev1.NodeDiskPressure),
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "No PID pressure",
+						Value: string(corev1.NodePIDPressure),
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Network available",
+						Value: string(corev1.NodeNetworkUnavailable),
+					},
+				}),
+			},
+			{
+				Name:         "schedulable",
+				Label:        "Schedulable",
+				Description:  new("The node must not be cordoned."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: new("true"),
+				Order:        new(3),
+				Required:     new(false),
+			},
+			{
+				Name:         "statusCheckMode",
+				Label:        "Status Check Mode",
+				Description:  new("All the time: the conditions have to be healthy during the complete duration. Eventually: the conditions have to be healthy at least once within the given duration."),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: new(string(extcommon.StatusCheckModeAllTheTime)),
+				Order:        new(4),
+				Required:     new(true),
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "All the time",
+						Value: string(extcommon.StatusCheckModeAllTheTime),
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Eventually",
+						Value: string(extcommon.StatusCheckModeAtLeastOnce),
+					},
+				}),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("1s"),
+		}),
+	}
+}
+
+func (f NodeConditionCheckAction) Prepare(_ context.Context, state *NodeConditionCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config NodeConditionCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	if len(config.Conditions) == 0 && !config.Schedulable {
+		return nil, extension_kit.ToError("At least one condition or schedulable is required.", nil)
+	}
+	for _, condition := range config.Conditions {
+		if _, ok := healthyConditionStatus[condition]; !ok || condition == conditionSchedulable {
+			return nil, extension_kit.ToError(fmt.Sprintf("Unknown node condition: %s", condition), nil)
+		}
+	}
+
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Conditions = config.Conditions
+	if config.Schedulable {
+		state.Conditions = append(state.Conditions, conditionSchedulable)
+	}
+	state.StatusCheckMode = config.StatusCheckMode
+	if state.StatusCheckMode == "" {
+		state.StatusCheckMode = extcommon.StatusCheckModeAllTheTime
+	}
+	if f.nodeName != nil {
+		state.NodeName = f.nodeName(request)
+		state.Target = state.NodeName
+	} else {
+		state.Target = request.Target.Attributes["k8s.cluster-name"][0]
+	}
+	state.LastStatus = make(map[string]map[string]corev1.ConditionStatus)
+	return nil, nil
+}
+
+func (f NodeConditionCheckAction) Start(_ context.Context, _ *NodeConditionCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f NodeConditionCheckAction) Status(_ context.Context, state *NodeConditionCheckState) (*action_kit_api.StatusResult, error) {
+	return statusNodeConditionCheckInternal(client.K8S, state), nil
+}
+
+func statusNodeConditionCheckInternal(k8s *client.Client, state *NodeConditionCheckState) *action_kit_api.StatusResult {
+	now := time.Now()
+
+	var nodes []*corev1.Node
+	var violations []string
+	if state.NodeName != "" {
+		if node := k8s.NodeByName(state.NodeName); node != nil {
+			nodes = append(nodes, node)
+		} else {
+			violations = append(violations, fmt.Sprintf("%s not found", state.NodeName))
+		}
+	} else {
+		nodes = k8s.Nodes()
+	}
+
+	var messages []action_kit_api.Message
+	for _, node := range nodes {
+		last, seen := state.LastStatus[node.Name]
+		if !seen {
+			last = make(map[string]corev1.ConditionStatus)
+			state.LastStatus[node.Name] = last
+		}
+		for _, condition := range state.Conditions {
+			status := nodeConditionStatus(node, condition)
+			healthy := status == healthyConditionStatus[condition]
+			if !healthy {
+				violations = append(violations, fmt.Sprintf("%s %s=%s", node.Name, condition, status))
+			}
+			if previous, ok := last[condition]; ok && previous != status {
+				flip := NodeConditionFlip{Node: node.Name, Condition: condition, From: previous, To: status, Time: now}
+				state.Flips = append(state.Flips, flip)
+				messages = append(messages, flip.message(healthy))
+			}
+			last[condition] = status
+		}
+	}
+
+	var checkError *action_kit_api.ActionKitError
+	if len(violations) > 0 {
+		checkError = &action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has unhealthy node conditions: %s", state.Target, strings.Join(violations, ", ")),
+			Detail: state.flipReport(),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		}
+	}
+
+	result := &action_kit_api.StatusResult{}
+	if len(messages) > 0 {
+		result.Messages = &messages
+	}
+	if state.StatusCheckMode == extcommon.StatusCheckModeAllTheTime {
+		result.Completed = checkError != nil || now.After(state.Timeout)
+		result.Error = checkError
+		return result
+	}
+	result.Completed = checkError == nil || now.After(state.Timeout)
+	if now.After(state.Timeout) {
+		result.Error = checkError
+	}
+	return result
+}
+
+// nodeConditionStatus returns the status of the condition, conditions not reported by the node are Unknown.
+func nodeConditionStatus(node *corev1.Node, condition string) corev1.ConditionStatus {
+	if condition == conditionSchedulable {
+		if node.Spec.Unschedulable {
+			return corev1.ConditionFalse
+		}
+		return corev1.ConditionTrue
+	}
+	idx := slices.IndexFunc(node.Status.Conditions, func(c corev1.NodeCondition) bool {
+		return string(c.Type) == condition
+	})
+	if idx < 0 {
+		return corev1.ConditionUnknown
+	}
+	return node.Status.Conditions[idx].Status
+}
+
+func (s *NodeConditionCheckState) flipReport() *string {
+	if len(s.Flips) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(s.Flips))
+	for _, flip := range s.Flips {
+		lines = append(lines, flip.String())
+	}
+	return new("Observed condition changes:\n" + strings.Join(lines, "\n"))
+}
+
+func (f NodeConditionFlip) String() string {
+	return fmt.Sprintf("%s %s %s -> %s at %s", f.Node, f.Condition, f.From, f.To, f.Time.UTC().Format(time.RFC3339))
+}
+
+func (f NodeConditionFlip) message(healthy bool) action_kit_api.Message {
+	level := action_kit_api.Warn
+	if healthy {
+		level = action_kit_api.Info
+	}
+	return action_kit_api.Message{
+		Message:   f.String(),
+		Level:     extutil.Ptr(level),
+		Timestamp: new(f.Time),
+		Fields: &action_kit_api.MessageFields{
+			"node":      f.Node,
+			"condition": f.Condition,
+			"status":    string(f.To),
+		},
+	}
+}
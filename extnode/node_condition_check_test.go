@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPrepareNodeConditionCheck(t *testing.T) {
+	// Given
+	action := NewNodeConditionCheckAction()
+	state := action.NewEmptyState()
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"duration":        1000 * 10,
+			"conditions":      []any{"Ready", "MemoryPressure"},
+			"schedulable":     true,
+			"statusCheckMode": "atLeastOnce",
+		},
+		Target: new(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.node.name": {"node-1"},
+			},
+		}),
+	}
+
+	// When
+	_, err := action.Prepare(context.TODO(), &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "node-1", state.NodeName)
+	require.Equal(t, []string{"Ready", "MemoryPressure", "Schedulable"}, state.Conditions)
+	require.Equal(t, extcommon.StatusCheckModeAtLeastOnce, state.StatusCheckMode)
+}
+
+func TestPrepareNodeConditionCheckRejectsUnknownCondition(t *testing.T) {
+	action := NewClusterNodeConditionCheckAction()
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.TODO(), &state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"duration": 1000, "conditions": []any{"Bogus"}},
+		Target: new(action_kit_api.Target{Attributes: map[string][]string{"k8s.cluster-name": {"dev"}}}),
+	})
+	require.ErrorContains(t, err, "Unknown node condition: Bogus")
+}
+
+func TestPrepareNodeConditionCheckRejectsEmptyCheck(t *testing.T) {
+	action := NewClusterNodeConditionCheckAction()
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.TODO(), &state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"duration": 1000, "conditions": []any{}, "schedulable": false},
+		Target: new(action_kit_api.Target{Attributes: map[string][]string{"k8s.cluster-name": {"dev"}}}),
+	})
+	require.ErrorContains(t, err, "At least one condition or schedulable is required")
+}
+
+func TestNodeConditionCheckAllTheTimeReportsFlips(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(conditionNode("node-1", corev1.ConditionFalse), conditionNode("node-2", corev1.ConditionFalse))
+	k8s := createConditionCheckClient(t, clientset)
+	state := NodeConditionCheckState{
+		Timeout:         time.Now().Add(time.Minute),
+		Target:          "dev",
+		Conditions:      []string{"Ready", "MemoryPressure", "Schedulable"},
+		StatusCheckMode: extcommon.StatusCheckModeAllTheTime,
+		LastStatus:      map[string]map[string]corev1.ConditionStatus{},
+	}
+
+	// When
+	result := statusNodeConditionCheckInternal(k8s, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+
+	// When
+	updateNode(t, clientset, conditionNode("node-2", corev1.ConditionTrue))
+	require.Eventually(t, func() bool {
+		return nodeConditionStatus(k8s.NodeByName("node-2"), "MemoryPressure") == corev1.ConditionTrue
+	}, time.Second, 10*time.Millisecond)
+	result = statusNodeConditionCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "dev has unhealthy node conditions: node-2 MemoryPressure=True", result.Error.Title)
+	require.Contains(t, *result.Error.Detail, "node-2 MemoryPressure False -> True at ")
+	require.Len(t, *result.Messages, 1)
+	require.Equal(t, action_kit_api.Warn, *(*result.Messages)[0].Level)
+}
+
+func TestNodeConditionCheckEventually(t *testing.T) {
+	// Given
+	node := conditionNode("node-1", corev1.ConditionFalse)
+	node.Spec.Unschedulable = true
+	clientset := testclient.NewClientset(node)
+	k8s := createConditionCheckClient(t, clientset)
+	state := NodeConditionCheckState{
+		Timeout:         time.Now().Add(time.Minute),
+		Target:          "node-1",
+		NodeName:        "node-1",
+		Conditions:      []string{"Ready", "Schedulable"},
+		StatusCheckMode: extcommon.StatusCheckModeAtLeastOnce,
+		LastStatus:      map[string]map[string]corev1.ConditionStatus{},
+	}
+
+	// When
+	result := statusNodeConditionCheckInternal(k8s, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+
+	// When
+	updateNode(t, clientset, conditionNode("node-1", corev1.ConditionFalse))
+	require.Eventually(t, func() bool {
+		return !k8s.NodeByName("node-1").Spec.Unschedulable
+	}, time.Second, 10*time.Millisecond)
+	result = statusNodeConditionCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+	require.Equal(t, "node-1 Schedulable False -> True at ", (*result.Messages)[0].Message[:len("node-1 Schedulable False -> True at ")])
+}
+
+func TestNodeConditionCheckEventuallyFailsAfterTimeout(t *testing.T) {
+	// Given
+	k8s := createConditionCheckClient(t, testclient.NewClientset())
+	state := NodeConditionCheckState{
+		Timeout:         time.Now().Add(-time.Second),
+		Target:          "node-1",
+		NodeName:        "node-1",
+		Conditions:      []string{"Ready"},
+		StatusCheckMode: extcommon.StatusCheckModeAtLeastOnce,
+		LastStatus:      map[string]map[string]corev1.ConditionStatus{},
+	}
+
+	// When
+	result := statusNodeConditionCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "node-1 has unhealthy node conditions: node-1 not found", result.Error.Title)
+}
+
+func createConditionCheckClient(t *testing.T, clientset kubernetes.Interface) *client.Client {
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	return client.CreateClient(clientset, stopCh, "", client.MockAllPermitted(), testutil.NewFakeDynamicClient())
+}
+
+func updateNode(t *testing.T, clientset kubernetes.Interface, node *corev1.Node) {
+	_, err := clientset.CoreV1().Nodes().Update(context.Background(), node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+}
+
+func conditionNode(name string, memoryPressure corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: memoryPressure},
+			},
+		},
+	}
+}
+
+func TestNodeConditionCheckLabels(t *testing.T) {
+	require.Equal(t, "Node Conditions", NewNodeConditionCheckAction().Describe().Label)
+	require.Equal(t, "Cluster Node Conditions", NewClusterNodeConditionCheckAction().Describe().Label)
+}
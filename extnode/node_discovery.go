@@ -21,7 +21,8 @@ import (
 )
 
 type nodeDiscovery struct {
-	k8s *client.Client
+	k8s     *client.Client
+	targets *extcommon.ResultCache[discovery_kit_api.Target]
 }
 
 var (
@@ -30,7 +31,7 @@ var (
 )
 
 func NewNodeDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &nodeDiscovery{k8s: k8s}
+	discovery := &nodeDiscovery{k8s: k8s, targets: extcommon.NewResultCache[discovery_kit_api.Target]()}
 	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeFor[corev1.Pod](), reflect.TypeFor[corev1.Node]())
 	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
 		discovery_kit_sdk.WithRefreshTargetsNow(),
@@ -148,105 +149,119 @@ func (d *nodeDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.
 		filteredNodes = append(filteredNodes, node)
 	}
 
+	defer d.targets.EndRun()
+
+	// service selectors are matched against the pod labels, so any service change may affect every node
+	servicesFingerprint := extcommon.Fingerprint(d.k8s.Services()...)
+	cache := client.NewDiscoveryCache(d.k8s)
 	targets := make([]discovery_kit_api.Target, len(filteredNodes))
 	for i, node := range filteredNodes {
-		attributes := map[string][]string{
-			"k8s.node.name":    {node.Name},
-			"k8s.cluster-name": {extconfig.Config.ClusterName},
-			"host.hostname":    {extcommon.GetHostname(node)},
-			"host.domainname":  extcommon.GetDomainnames(node),
-			"k8s.distribution": {d.k8s.Distribution},
-		}
+		pods := d.k8s.PodsByNodeName(node.Name)
+		fingerprint := extcommon.Fingerprints(extcommon.Fingerprint(node), extcommon.Fingerprint(pods...), servicesFingerprint)
+		targets[i] = d.targets.Get(node.Name, fingerprint, func() discovery_kit_api.Target {
+			return d.toTarget(node, pods, cache)
+		})
+	}
+	return targets, nil
+}
 
-		extcommon.AddLabels(attributes, node.ObjectMeta.Labels, "k8s.node.label", "k8s.label")
-		extcommon.AddNamespaceLabels(attributes, d.k8s, node.Namespace)
+func (d *nodeDiscovery) toTarget(node *corev1.Node, pods []*corev1.Pod, cache *client.DiscoveryCache) discovery_kit_api.Target {
+	attributes := map[string][]string{
+		"k8s.node.name":    {node.Name},
+		"k8s.cluster-name": {extconfig.Config.ClusterName},
+		"host.hostname":    {extcommon.GetHostname(node)},
+		"host.domainname":  extcommon.GetDomainnames(node),
+		"k8s.distribution": {d.k8s.Distribution},
+	}
+
+	addNodeAttributes(attributes, node)
+	extcommon.AddLabels(attributes, node.ObjectMeta.Labels, "k8s.node.label", "k8s.label")
+	extcommon.AddNamespaceLabels(attributes, d.k8s, node.Namespace)
 
-		pods := d.k8s.Pods()
-		if len(pods) > 0 {
-			var podNames []string
-			var containerIds []string
-			var containerIdsWithoutPrefix []string
-			deployments := make(map[string]bool)
-			statefulSets := make(map[string]bool)
-			daemonSets := make(map[string]bool)
-			replicaSets := make(map[string]bool)
-			namespaces := make(map[string]bool)
-			serviceNames := make(map[string]bool)
-			for _, pod := range pods {
-				if pod.Spec.NodeName == node.Name && !client.IsExcludedFromDiscovery(pod.ObjectMeta) {
-					podNames = append(podNames, pod.Name)
-					for _, container := range pod.Status.ContainerStatuses {
-						if container.ContainerID == "" {
-							continue
-						}
-						containerIds = append(containerIds, container.ContainerID)
-						containerIdsWithoutPrefix = append(containerIdsWithoutPrefix, strings.SplitAfter(container.ContainerID, "://")[1])
+	if len(pods) > 0 {
+		var podNames []string
+		var containerIds []string
+		var containerIdsWithoutPrefix []string
+		deployments := make(map[string]bool)
+		statefulSets := make(map[string]bool)
+		daemonSets := make(map[string]bool)
+		replicaSets := make(map[string]bool)
+		namespaces := make(map[string]bool)
+		serviceNames := make(map[string]bool)
+		for _, pod := range pods {
+			if !client.IsExcludedFromDiscovery(pod.ObjectMeta) {
+				podNames = append(podNames, pod.Name)
+				for _, container := range pod.Status.ContainerStatuses {
+					if container.ContainerID == "" {
+						continue
+					}
+					containerIds = append(containerIds, container.ContainerID)
+					containerIdsWithoutPrefix = append(containerIdsWithoutPrefix, strings.SplitAfter(container.ContainerID, "://")[1])
+				}
+				namespaces[pod.Namespace] = true
+				ownerReferences := cache.OwnerReferences(&pod.ObjectMeta)
+				for _, ownerReference := range ownerReferences.OwnerRefs {
+					if ownerReference.Kind == "replicaset" {
+						replicaSets[ownerReference.Name] = true
 					}
-					namespaces[pod.Namespace] = true
-					ownerReferences := client.OwnerReferences(d.k8s, &pod.ObjectMeta)
-					for _, ownerReference := range ownerReferences.OwnerRefs {
-						if ownerReference.Kind == "replicaset" {
-							replicaSets[ownerReference.Name] = true
-						}
-						if ownerReference.Kind == "statefulset" {
-							statefulSets[ownerReference.Name] = true
-						}
-						if ownerReference.Kind == "deployment" {
-							deployments[ownerReference.Name] = true
-						}
-						if ownerReference.Kind == "daemonset" {
-							daemonSets[ownerReference.Name] = true
-						}
+					if ownerReference.Kind == "statefulset" {
+						statefulSets[ownerReference.Name] = true
 					}
-					services := d.k8s.ServicesMatchingToPodLabels(pod.Namespace, pod.ObjectMeta.Labels)
-					if len(services) > 0 {
-						for _, service := range services {
-							serviceNames[service.Name] = true
-						}
+					if ownerReference.Kind == "deployment" {
+						deployments[ownerReference.Name] = true
+					}
+					if ownerReference.Kind == "daemonset" {
+						daemonSets[ownerReference.Name] = true
+					}
+				}
+				services := cache.ServicesMatchingToPodLabels(pod.Namespace, pod.ObjectMeta.Labels)
+				if len(services) > 0 {
+					for _, service := range services {
+						serviceNames[service.Name] = true
 					}
 				}
-			}
-
-			if len(containerIds) > 0 {
-				slices.Sort(containerIds)
-				attributes["k8s.container.id"] = containerIds
-			}
-			if len(containerIdsWithoutPrefix) > 0 {
-				slices.Sort(containerIdsWithoutPrefix)
-				attributes["k8s.container.id.stripped"] = containerIdsWithoutPrefix
-			}
-			if len(podNames) > 0 {
-				slices.Sort(podNames)
-				attributes["k8s.pod.name"] = podNames
-			}
-			if len(replicaSets) > 0 {
-				attributes["k8s.replicaset"] = keys(replicaSets)
-			}
-			if len(statefulSets) > 0 {
-				attributes["k8s.statefulset"] = keys(statefulSets)
-			}
-			if len(deployments) > 0 {
-				attributes["k8s.deployment"] = keys(deployments)
-			}
-			if len(daemonSets) > 0 {
-				attributes["k8s.daemonset"] = keys(daemonSets)
-			}
-			if len(namespaces) > 0 {
-				attributes["k8s.namespace"] = keys(namespaces)
-			}
-			if len(serviceNames) > 0 {
-				attributes["k8s.service.name"] = keys(serviceNames)
 			}
 		}
 
-		targets[i] = discovery_kit_api.Target{
-			Id:         node.Name,
-			TargetType: NodeTargetType,
-			Label:      node.Name,
-			Attributes: attributes,
+		if len(containerIds) > 0 {
+			slices.Sort(containerIds)
+			attributes["k8s.container.id"] = containerIds
+		}
+		if len(containerIdsWithoutPrefix) > 0 {
+			slices.Sort(containerIdsWithoutPrefix)
+			attributes["k8s.container.id.stripped"] = containerIdsWithoutPrefix
+		}
+		if len(podNames) > 0 {
+			slices.Sort(podNames)
+			attributes["k8s.pod.name"] = podNames
+		}
+		if len(replicaSets) > 0 {
+			attributes["k8s.replicaset"] = keys(replicaSets)
 		}
+		if len(statefulSets) > 0 {
+			attributes["k8s.statefulset"] = keys(statefulSets)
+		}
+		if len(deployments) > 0 {
+			attributes["k8s.deployment"] = keys(deployments)
+		}
+		if len(daemonSets) > 0 {
+			attributes["k8s.daemonset"] = keys(daemonSets)
+		}
+		if len(namespaces) > 0 {
+			attributes["k8s.namespace"] = keys(namespaces)
+		}
+		if len(serviceNames) > 0 {
+			attributes["k8s.service.name"] = keys(serviceNames)
+		}
+	}
+
+	target := discovery_kit_api.Target{
+		Id:         node.Name,
+		TargetType: NodeTargetType,
+		Label:      node.Name,
+		Attributes: attributes,
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesNode), nil
+	return discovery_kit_commons.ApplyAttributeExcludes([]discovery_kit_api.Target{target}, extconfig.Config.DiscoveryAttributesExcludesNode)[0]
 }
 
 func keys(m map[string]bool) []string {
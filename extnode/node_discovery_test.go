@@ -5,11 +5,13 @@ package extnode
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
 	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
 	"github.com/steadybit/extension-kubernetes/v2/extconfig"
 	"github.com/steadybit/extension-kubernetes/v2/testutil"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	testclient "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -161,6 +164,7 @@ func Test_nodeDiscovery(t *testing.T) {
 		"k8s.distribution":          {"kubernetes"},
 		"k8s.namespace":             {"default"},
 		"k8s.node.name":             {"node-123"},
+		"k8s.node.unschedulable":    {"false"},
 		"k8s.pod.name":              {"shop-pod-11"},
 		"k8s.service.name":          {"shop-service"},
 		"k8s.label.label1":          {"value1"},
@@ -250,7 +254,107 @@ func Test_nodeDiscovery_MultiValueAttributesAreSorted(t *testing.T) {
 	}
 }
 
+func Test_nodeDiscovery_RecomputesChangedNodes(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	pod := func(name, nodeName, resourceVersion, containerId string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name), ResourceVersion: resourceVersion},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+			Status: v1.PodStatus{
+				Phase:             v1.PodRunning,
+				ContainerStatuses: []v1.ContainerStatus{{ContainerID: containerId}},
+			},
+		}
+	}
+	clientset := testclient.NewClientset(
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", UID: "node-1", ResourceVersion: "1"}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2", UID: "node-2", ResourceVersion: "1"}},
+		pod("pod-1", "node-1", "1", "containerd://a"),
+		pod("pod-2", "node-2", "1", "containerd://b"),
+	)
+	k8s := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted(), testutil.NewFakeDynamicClient())
+	d := &nodeDiscovery{k8s: k8s, targets: extcommon.NewResultCache[discovery_kit_api.Target]()}
+
+	containerIds := func() map[string][]string {
+		targets, err := d.DiscoverTargets(context.Background())
+		require.NoError(t, err)
+		result := map[string][]string{}
+		for _, target := range targets {
+			result[target.Id] = target.Attributes["k8s.container.id"]
+		}
+		return result
+	}
+	assert.Equal(t, map[string][]string{"node-1": {"containerd://a"}, "node-2": {"containerd://b"}}, containerIds())
+
+	// When
+	_, err := clientset.CoreV1().Pods("default").Update(context.Background(), pod("pod-1", "node-1", "2", "containerd://c"), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// Then
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, map[string][]string{"node-1": {"containerd://c"}, "node-2": {"containerd://b"}}, containerIds())
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 func getTestClient(stopCh <-chan struct{}, objects ...runtime.Object) *client.Client {
 	dynamicClient := testutil.NewFakeDynamicClient()
 	return client.CreateClient(testclient.NewClientset(objects...), stopCh, "", client.MockAllPermitted(), dynamicClient)
 }
+
+// largeClusterObjects returns a cluster of 500 nodes running 1000 deployments with 20 pods each.
+func largeClusterObjects() ([]runtime.Object, int) {
+	const nodeCount = 500
+	const deploymentCount = 1000
+	const podsPerDeployment = 20
+
+	objects := make([]runtime.Object, 0, nodeCount+deploymentCount*(podsPerDeployment+3))
+	for n := range nodeCount {
+		objects = append(objects, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", n)}})
+	}
+	for d := range deploymentCount {
+		namespace := fmt.Sprintf("namespace-%d", d%50)
+		name := fmt.Sprintf("deployment-%d", d)
+		objects = append(objects,
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Name:            name + "-rs",
+				Namespace:       namespace,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: name}},
+			}},
+			&v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec:       v1.ServiceSpec{Selector: map[string]string{"app": name}},
+			},
+		)
+		for p := range podsPerDeployment {
+			objects = append(objects, &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            fmt.Sprintf("%s-pod-%d", name, p),
+					Namespace:       namespace,
+					Labels:          map[string]string{"app": name},
+					OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: name + "-rs"}},
+				},
+				Spec: v1.PodSpec{NodeName: fmt.Sprintf("node-%d", (d*podsPerDeployment+p)%nodeCount)},
+				Status: v1.PodStatus{
+					Phase:             v1.PodRunning,
+					ContainerStatuses: []v1.ContainerStatus{{ContainerID: fmt.Sprintf("containerd://%d-%d", d, p)}},
+				},
+			})
+		}
+	}
+	return objects, nodeCount
+}
+
+func BenchmarkNodeDiscovery(b *testing.B) {
+	objects, nodeCount := largeClusterObjects()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	d := &nodeDiscovery{k8s: getTestClient(stopCh, objects...)}
+
+	for b.Loop() {
+		targets, _ := d.DiscoverTargets(context.Background())
+		require.Len(b, targets, nodeCount)
+	}
+}
@@ -23,7 +23,9 @@ import (
 )
 
 type statefulSetDiscovery struct {
-	k8s *client.Client
+	k8s        *client.Client
+	targets    *extcommon.ResultCache[discovery_kit_api.Target]
+	kubeScores *extcommon.ResultCache[map[string][]string]
 }
 
 var (
@@ -32,7 +34,11 @@ var (
 )
 
 func NewStatefulSetDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &statefulSetDiscovery{k8s: k8s}
+	discovery := &statefulSetDiscovery{
+		k8s:        k8s,
+		targets:    extcommon.NewResultCache[discovery_kit_api.Target](),
+		kubeScores: extcommon.NewResultCache[map[string][]string](),
+	}
 	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
 		reflect.TypeFor[corev1.Pod](),
 		reflect.TypeFor[appsv1.StatefulSet](),
@@ -88,51 +94,89 @@ func (d *statefulSetDiscovery) DiscoverTargets(_ context.Context) ([]discovery_k
 		filteredStatefulSets = append(filteredStatefulSets, sts)
 	}
 
+	defer d.targets.EndRun()
+	defer d.kubeScores.EndRun()
+
 	nodes := d.k8s.Nodes()
 	targets := make([]discovery_kit_api.Target, len(filteredStatefulSets))
 	for i, sts := range filteredStatefulSets {
-		attributes := map[string][]string{
-			"k8s.namespace":      {sts.Namespace},
-			"k8s.statefulset":    {sts.Name},
-			"k8s.workload-type":  {"statefulset"},
-			"k8s.workload-owner": {sts.Name},
-			"k8s.cluster-name":   {extconfig.Config.ClusterName},
-			"k8s.distribution":   {d.k8s.Distribution},
+		pods := d.k8s.PodsByOwnerUid(sts.UID, sts.Namespace)
+		services := d.k8s.ServicesMatchingToPodLabels(sts.Namespace, sts.Spec.Template.Labels)
+		var hpas []*autoscalingv2.HorizontalPodAutoscaler
+		if d.k8s.Permissions().CanReadHorizontalPodAutoscalers() {
+			hpas = d.k8s.HorizontalPodAutoscalersByNamespaceKindAndName(sts.Namespace, "StatefulSet", sts.Name)
 		}
-
-		if sts.Spec.Replicas != nil {
-			attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", *sts.Spec.Replicas)}
+		var pdbs []*policyv1.PodDisruptionBudget
+		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+			pdbs = d.k8s.PodDisruptionBudgetsForPodLabels(sts.Namespace, sts.Spec.Template.Labels)
 		}
 
-		extcommon.AddLabels(attributes, sts.ObjectMeta.Labels, "k8s.statefulset.label", "k8s.label")
-		extcommon.AddNamespaceLabels(attributes, d.k8s, sts.Namespace)
-		extcommon.MergeAttributes(
-			attributes,
-			extcommon.GetPodBasedAttributes("statefulset", sts.ObjectMeta, d.k8s.PodsByOwnerUid(sts.UID, sts.Namespace), nodes),
-			extcommon.GetServiceNames(d.k8s.ServicesMatchingToPodLabels(sts.Namespace, sts.Spec.Template.Labels)),
+		fingerprint := extcommon.Fingerprints(
+			extcommon.Fingerprint(sts),
+			extcommon.PodsFingerprint(pods, nodes),
+			extcommon.Fingerprint(services...),
+			extcommon.Fingerprint(hpas...),
+			extcommon.Fingerprint(pdbs...),
+			extcommon.NamespaceFingerprint(d.k8s, sts.Namespace),
 		)
+		// kube-score results are requested for every statefulset, cached targets included, so EndRun keeps them
+		kubeScore := d.kubeScore(sts, services)
+		targets[i] = d.targets.Get(string(sts.UID), fingerprint, func() discovery_kit_api.Target {
+			return d.toTarget(sts, pods, nodes, services, hpas, pdbs, kubeScore)
+		})
+	}
+	return targets, nil
+}
 
-		if !extconfig.Config.DisableAdvice {
-			extcommon.MergeAttributes(attributes, extcommon.GetKubeScoreForStatefulSet(sts, d.k8s.ServicesMatchingToPodLabels(sts.Namespace, sts.Spec.Template.Labels)))
-		}
+func (d *statefulSetDiscovery) kubeScore(sts *appsv1.StatefulSet, services []*corev1.Service) map[string][]string {
+	if extconfig.Config.DisableAdvice {
+		return nil
+	}
+	return d.kubeScores.Get(string(sts.UID), extcommon.KubeScoreFingerprint(sts, services, nil), func() map[string][]string {
+		return extcommon.GetKubeScoreForStatefulSet(sts, services)
+	})
+}
 
-		if d.k8s.Permissions().CanReadHorizontalPodAutoscalers() {
-			extcommon.AddHpaAttributes(attributes, d.k8s.HorizontalPodAutoscalersByNamespaceKindAndName(sts.Namespace, "StatefulSet", sts.Name))
-		}
-		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
-			extcommon.AddPdbAttributes(attributes, d.k8s.PodDisruptionBudgetsForPodLabels(sts.Namespace, sts.Spec.Template.Labels))
-		}
+func (d *statefulSetDiscovery) toTarget(sts *appsv1.StatefulSet, pods []*corev1.Pod, nodes []*corev1.Node, services []*corev1.Service, hpas []*autoscalingv2.HorizontalPodAutoscaler, pdbs []*policyv1.PodDisruptionBudget, kubeScore map[string][]string) discovery_kit_api.Target {
+	attributes := map[string][]string{
+		"k8s.namespace":      {sts.Namespace},
+		"k8s.statefulset":    {sts.Name},
+		"k8s.workload-type":  {"statefulset"},
+		"k8s.workload-owner": {sts.Name},
+		"k8s.cluster-name":   {extconfig.Config.ClusterName},
+		"k8s.distribution":   {d.k8s.Distribution},
+	}
 
-		extcommon.AddProbePathAttributes(attributes, sts.Spec.Template.Spec.Containers)
+	if sts.Spec.Replicas != nil {
+		attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", *sts.Spec.Replicas)}
+	}
 
-		targets[i] = discovery_kit_api.Target{
-			Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, sts.Namespace, sts.Name),
-			TargetType: StatefulSetTargetType,
-			Label:      sts.Name,
-			Attributes: attributes,
-		}
+	extcommon.AddLabels(attributes, sts.ObjectMeta.Labels, "k8s.statefulset.label", "k8s.label")
+	extcommon.AddNamespaceLabels(attributes, d.k8s, sts.Namespace)
+	extcommon.MergeAttributes(
+		attributes,
+		extcommon.GetPodBasedAttributes("statefulset", sts.ObjectMeta, pods, nodes),
+		extcommon.GetServiceNames(services),
+	)
+
+	extcommon.MergeAttributes(attributes, kubeScore)
+
+	if d.k8s.Permissions().CanReadHorizontalPodAutoscalers() {
+		extcommon.AddHpaAttributes(attributes, hpas)
+	}
+	if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+		extcommon.AddPdbAttributes(attributes, pdbs)
+	}
+
+	extcommon.AddProbePathAttributes(attributes, sts.Spec.Template.Spec.Containers)
+
+	target := discovery_kit_api.Target{
+		Id:         fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, sts.Namespace, sts.Name),
+		TargetType: StatefulSetTargetType,
+		Label:      sts.Name,
+		Attributes: attributes,
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesStatefulSet), nil
+	return discovery_kit_commons.ApplyAttributeExcludes([]discovery_kit_api.Target{target}, extconfig.Config.DiscoveryAttributesExcludesStatefulSet)[0]
 }
 
 func (d *statefulSetDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exttraefik
+
+import (
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+)
+
+func NewAbortAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &middlewareAction{
+		k8s:                   k8s,
+		description:           getAbortDescription(),
+		subtype:               "abort",
+		buildMiddlewareSpecFn: buildAbortMiddlewareSpec,
+	}
+}
+
+func getAbortDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		AbortActionId,
+		"Traefik Abort Traffic",
+		"Abort a percentage of the traffic on a Traefik route with a given HTTP status code using a forwardAuth Middleware. While the attack runs, Traefik calls the extension for every request of the route, so the route fails if the extension pod is unavailable.",
+	)
+	desc.Parameters = append(desc.Parameters,
+		percentageParameter(),
+		action_kit_api.ActionParameter{
+			Name:         "statusCode",
+			Label:        "HTTP Status Code",
+			Description:  new("The HTTP status code returned for aborted requests."),
+			Type:         action_kit_api.ActionParameterTypeInteger,
+			DefaultValue: new("503"),
+			Required:     new(true),
+			MinValue:     new(400),
+			MaxValue:     new(599),
+		},
+	)
+	return desc
+}
+
+func buildAbortMiddlewareSpec(config map[string]any, state *ActionState) (map[string]any, error) {
+	// forwardAuth lets requests pass on any 2xx answer and follows no redirects, so only error codes abort them.
+	statusCode := extutil.ToInt(config["statusCode"])
+	if statusCode < 400 || statusCode > 599 {
+		return nil, fmt.Errorf("statusCode must be between 400 and 599")
+	}
+	percentage, err := extcommon.PercentageFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	state.FaultKey = newFaultKey()
+	return map[string]any{
+		"forwardAuth": map[string]any{
+			"address": faultAddress(extconfig.Config.TraefikFaultUrl, state.FaultKey, state.ExecutionId, 0, statusCode, percentage),
+		},
+	}, nil
+}
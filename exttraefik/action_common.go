@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exttraefik
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ActionState is shared by all Traefik route attacks.
+type ActionState struct {
+	Namespace      string         `json:"namespace"`
+	RouteKind      string         `json:"routeKind"`
+	RouteName      string         `json:"routeName"`
+	MiddlewareName string         `json:"middlewareName"`
+	ExecutionId    string         `json:"executionId"`
+	MiddlewareSpec map[string]any `json:"middlewareSpec"`
+	// FaultKey signs the fault address of the delay and abort attacks, see faultKeys.
+	FaultKey string `json:"faultKey,omitempty"`
+}
+
+// middlewareAction is the common attack implementation. It creates a temporary Middleware and attaches it to the
+// IngressRoute (or to the router of the Ingress) for the duration of the attack. Each attack supplies a description
+// and a buildMiddlewareSpecFn producing the Middleware spec for the config of the attack.
+type middlewareAction struct {
+	k8s                   *client.Client
+	description           action_kit_api.ActionDescription
+	subtype               string
+	buildMiddlewareSpecFn func(config map[string]any, state *ActionState) (map[string]any, error)
+}
+
+func (a *middlewareAction) NewEmptyState() ActionState {
+	return ActionState{}
+}
+
+func (a *middlewareAction) Describe() action_kit_api.ActionDescription {
+	return a.description
+}
+
+func (a *middlewareAction) Prepare(ctx context.Context, state *ActionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	namespace := request.Target.Attributes["k8s.namespace"]
+	routeName := request.Target.Attributes[attrRoute]
+	routeKind := request.Target.Attributes[attrRouteKind]
+	if len(namespace) == 0 || len(routeName) == 0 || len(routeKind) == 0 {
+		return nil, extension_kit.ToError("Missing required target attributes k8s.namespace, k8s.traefik.route and/or k8s.traefik.route.kind.", nil)
+	}
+
+	state.Namespace = namespace[0]
+	state.RouteName = routeName[0]
+	state.RouteKind = routeKind[0]
+	state.ExecutionId = request.ExecutionId.String()
+	state.MiddlewareName = fmt.Sprintf("%s%s-%s", middlewareNamePrefix, a.subtype, request.ExecutionId.String())
+
+	spec, err := a.buildMiddlewareSpecFn(request.Config, state)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to build middleware configuration: %v", err), err)
+	}
+	state.MiddlewareSpec = spec
+
+	if err := a.checkConflict(ctx, state); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (a *middlewareAction) Start(ctx context.Context, state *ActionState) (*action_kit_api.StartResult, error) {
+	// Re-check immediately before attaching, a concurrent attack on the same route may have started since Prepare.
+	if err := a.checkConflict(ctx, state); err != nil {
+		return nil, err
+	}
+
+	if state.FaultKey != "" {
+		registerFaultKey(state.ExecutionId, state.FaultKey)
+	}
+
+	middleware := buildMiddleware(state.Namespace, state.MiddlewareName, state.ExecutionId, state.MiddlewareSpec)
+	_, err := a.k8s.DynamicClient().Resource(client.TraefikMiddlewareGVR).Namespace(state.Namespace).Create(ctx, middleware, metav1.CreateOptions{})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to create Middleware %s/%s: %v", state.Namespace, state.MiddlewareName, err), err)
+	}
+
+	if err := a.attach(ctx, state); err != nil {
+		if deleteErr := a.deleteMiddleware(ctx, state); deleteErr != nil {
+			log.Warn().Err(deleteErr).Msgf("Failed to delete Middleware %s/%s after failing to attach it", state.Namespace, state.MiddlewareName)
+		}
+		unregisterFaultKey(state.ExecutionId)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to attach Middleware %s to %s %s/%s: %v", state.MiddlewareName, state.RouteKind, state.Namespace, state.RouteName, err), err)
+	}
+
+	log.Info().Msgf("Attached Middleware %s/%s to %s %s", state.Namespace, state.MiddlewareName, state.RouteKind, state.RouteName)
+	return &action_kit_api.StartResult{
+		Messages: new([]action_kit_api.Message{
+			{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("Applied Middleware %s to %s %s/%s", state.MiddlewareName, state.RouteKind, state.Namespace, state.RouteName),
+			},
+		}),
+	}, nil
+}
+
+// Status fails the attack if it no longer affects the requests of the route: when the Middleware was detached, e.g.
+// by a GitOps controller re-syncing the route, or when Traefik cannot reach the fault endpoint of the extension.
+func (a *middlewareAction) Status(ctx context.Context, state *ActionState) (*action_kit_api.StatusResult, error) {
+	if state.FaultKey != "" {
+		// Registers the key again after a restart of the extension.
+		registerFaultKey(state.ExecutionId, state.FaultKey)
+		if err := checkFaultEndpoint(ctx, extconfig.Config.TraefikFaultUrl); err != nil {
+			return statusErrored(fmt.Sprintf("The fault endpoint %s is not reachable, Traefik fails every request of %s %s/%s: %v",
+				extconfig.Config.TraefikFaultUrl, state.RouteKind, state.Namespace, state.RouteName, err)), nil
+		}
+	}
+
+	attached, err := a.isAttached(ctx, state)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to check Middleware %s on %s %s/%s: %v", state.MiddlewareName, state.RouteKind, state.Namespace, state.RouteName, err), err)
+	}
+	if !attached {
+		return statusErrored(fmt.Sprintf("Middleware %s was removed from %s %s/%s, the attack no longer affects its requests.",
+			state.MiddlewareName, state.RouteKind, state.Namespace, state.RouteName)), nil
+	}
+	return &action_kit_api.StatusResult{}, nil
+}
+
+func statusErrored(title string) *action_kit_api.StatusResult {
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Error: new(action_kit_api.ActionKitError{
+			Title:  title,
+			Status: new(action_kit_api.Errored),
+		}),
+	}
+}
+
+// isAttached reports whether the Middleware is still attached to the route. The informer cache is checked first, the
+// route is only read from the API server if the cache does not show the Middleware, which may just be outdated.
+func (a *middlewareAction) isAttached(ctx context.Context, state *ActionState) (bool, error) {
+	if state.RouteKind == routeKindIngress {
+		ref := annotationMiddlewareRef(state.Namespace, state.MiddlewareName)
+		if ingress, err := a.k8s.IngressByNamespaceAndName(state.Namespace, state.RouteName); err == nil && slices.Contains(annotationMiddlewares(ingress.Annotations[routerMiddlewaresAnnotation]), ref) {
+			return true, nil
+		}
+		ingress, err := a.k8s.GetIngress(ctx, state.Namespace, state.RouteName)
+		if k8sErrors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		return slices.Contains(annotationMiddlewares(ingress.Annotations[routerMiddlewaresAnnotation]), ref), nil
+	}
+
+	if route := a.k8s.TraefikIngressRouteFromCache(state.Namespace, state.RouteName); route != nil && slices.Contains(ingressRouteMiddlewares(route), state.MiddlewareName) {
+		return true, nil
+	}
+	route, err := a.k8s.GetTraefikIngressRoute(ctx, state.Namespace, state.RouteName)
+	if k8sErrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return slices.Contains(ingressRouteMiddlewares(route), state.MiddlewareName), nil
+}
+
+func (a *middlewareAction) Stop(ctx context.Context, state *ActionState) (*action_kit_api.StopResult, error) {
+	if err := a.detach(ctx, state); err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to detach Middleware %s from %s %s/%s: %v", state.MiddlewareName, state.RouteKind, state.Namespace, state.RouteName, err), err)
+	}
+	if err := a.deleteMiddleware(ctx, state); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to delete Middleware %s/%s: %v", state.Namespace, state.MiddlewareName, err), err)
+	}
+	unregisterFaultKey(state.ExecutionId)
+
+	log.Info().Msgf("Removed Middleware %s/%s from %s %s", state.Namespace, state.MiddlewareName, state.RouteKind, state.RouteName)
+	return nil, nil
+}
+
+func (a *middlewareAction) attach(ctx context.Context, state *ActionState) error {
+	if state.RouteKind == routeKindIngress {
+		ref := annotationMiddlewareRef(state.Namespace, state.MiddlewareName)
+		return a.k8s.UpdateIngressAnnotationValue(ctx, state.Namespace, state.RouteName, routerMiddlewaresAnnotation, func(value string) string {
+			return withAnnotationMiddleware(value, ref)
+		})
+	}
+	return a.k8s.UpdateTraefikIngressRoute(ctx, state.Namespace, state.RouteName, func(route *unstructured.Unstructured) bool {
+		return attachToIngressRoute(route, state.MiddlewareName)
+	})
+}
+
+func (a *middlewareAction) detach(ctx context.Context, state *ActionState) error {
+	if state.RouteKind == routeKindIngress {
+		ref := annotationMiddlewareRef(state.Namespace, state.MiddlewareName)
+		return a.k8s.UpdateIngressAnnotationValue(ctx, state.Namespace, state.RouteName, routerMiddlewaresAnnotation, func(value string) string {
+			return withoutAnnotationMiddleware(value, ref)
+		})
+	}
+	return a.k8s.UpdateTraefikIngressRoute(ctx, state.Namespace, state.RouteName, func(route *unstructured.Unstructured) bool {
+		return detachFromIngressRoute(route, state.MiddlewareName)
+	})
+}
+
+func (a *middlewareAction) deleteMiddleware(ctx context.Context, state *ActionState) error {
+	err := a.k8s.DynamicClient().Resource(client.TraefikMiddlewareGVR).Namespace(state.Namespace).Delete(ctx, state.MiddlewareName, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// checkConflict fails if another attack already attached its Middleware to the route. Traefik would apply both
+// Middlewares, so the results of the attacks would be meaningless.
+func (a *middlewareAction) checkConflict(ctx context.Context, state *ActionState) error {
+	attackMiddlewares, err := a.attackMiddlewares(ctx, state.Namespace)
+	if err != nil {
+		return extension_kit.ToError(fmt.Sprintf("Failed to list Middlewares in namespace %s: %v", state.Namespace, err), err)
+	}
+	var conflict string
+	if state.RouteKind == routeKindIngress {
+		ingress, err := a.k8s.IngressByNamespaceAndName(state.Namespace, state.RouteName, true)
+		if err != nil {
+			return extension_kit.ToError(fmt.Sprintf("Failed to fetch Ingress %s/%s: %v", state.Namespace, state.RouteName, err), err)
+		}
+		conflict = findConflictingMiddleware(annotationMiddlewares(ingress.Annotations[routerMiddlewaresAnnotation]), state.Namespace+"-", state.MiddlewareName, attackMiddlewares)
+	} else {
+		route, err := a.k8s.GetTraefikIngressRoute(ctx, state.Namespace, state.RouteName)
+		if err != nil {
+			return extension_kit.ToError(fmt.Sprintf("Failed to fetch IngressRoute %s/%s: %v", state.Namespace, state.RouteName, err), err)
+		}
+		conflict = findConflictingMiddleware(ingressRouteMiddlewares(route), "", state.MiddlewareName, attackMiddlewares)
+	}
+	if conflict != "" {
+		return extension_kit.ToError(fmt.Sprintf("Another attack is already running on %s %s/%s (Middleware %s). Wait for it to finish or target a different route.",
+			state.RouteKind, state.Namespace, state.RouteName, conflict), nil)
+	}
+	return nil
+}
+
+// attackMiddlewares returns the names of the Middlewares created by attacks in the namespace, identified by their
+// managed-by label.
+func (a *middlewareAction) attackMiddlewares(ctx context.Context, namespace string) (map[string]bool, error) {
+	list, err := a.k8s.DynamicClient().Resource(client.TraefikMiddlewareGVR).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: managedByLabelKey + "=" + managedByValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(list.Items))
+	for _, middleware := range list.Items {
+		names[middleware.GetName()] = true
+	}
+	return names, nil
+}
+
+// getCommonActionDescription returns the base action description with the duration parameter and the Traefik route
+// target selection.
+func getCommonActionDescription(id, label, description string) action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          id,
+		Label:       label,
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Description: description,
+		Technology:  new("Kubernetes"),
+		Icon:        new(TraefikIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType: TraefikRouteTargetType,
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "Traefik route",
+					Description: new("Find Traefik route by cluster, namespace and route name"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.traefik.route=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new("The duration of the attack. The route will be affected for the specified duration."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Required:     new(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func percentageParameter() action_kit_api.ActionParameter {
+	return action_kit_api.ActionParameter{
+		Name:         "percentage",
+		Label:        "Traffic Percentage",
+		Description:  new("The percentage of requests the fault is applied to."),
+		Type:         action_kit_api.ActionParameterTypePercentage,
+		DefaultValue: new("100"),
+		MinValue:     new(1),
+		MaxValue:     new(100),
+		Required:     new(true),
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exttraefik
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+)
+
+func NewDelayAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &middlewareAction{
+		k8s:                   k8s,
+		description:           getDelayDescription(),
+		subtype:               "delay",
+		buildMiddlewareSpecFn: buildDelayMiddlewareSpec,
+	}
+}
+
+func getDelayDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		DelayActionId,
+		"Traefik Delay Traffic",
+		"Inject a fixed delay into a percentage of the traffic on a Traefik route using a forwardAuth Middleware. While the attack runs, Traefik calls the extension for every request of the route, so the route fails if the extension pod is unavailable.",
+	)
+	desc.Parameters = append(desc.Parameters,
+		percentageParameter(),
+		action_kit_api.ActionParameter{
+			Name:         "delay",
+			Label:        "Delay",
+			Description:  new("The fixed delay to inject into matching requests (at most 25s)."),
+			Type:         action_kit_api.ActionParameterTypeDuration,
+			DefaultValue: new("500ms"),
+			Required:     new(true),
+		},
+	)
+	return desc
+}
+
+func buildDelayMiddlewareSpec(config map[string]any, state *ActionState) (map[string]any, error) {
+	delay := time.Duration(extutil.ToInt64(config["delay"])) * time.Millisecond
+	if delay <= 0 || delay > maxFaultDelay {
+		return nil, fmt.Errorf("delay must be greater than zero and at most %s", maxFaultDelay)
+	}
+	percentage, err := extcommon.PercentageFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	state.FaultKey = newFaultKey()
+	return map[string]any{
+		"forwardAuth": map[string]any{
+			"address": faultAddress(extconfig.Config.TraefikFaultUrl, state.FaultKey, state.ExecutionId, delay, 0, percentage),
+		},
+	}, nil
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exttraefik
+
+import (
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+)
+
+func NewRateLimitAction(k8s *client.Client) action_kit_sdk.Action[ActionState] {
+	return &middlewareAction{
+		k8s:                   k8s,
+		description:           getRateLimitDescription(),
+		subtype:               "rate-limit",
+		buildMiddlewareSpecFn: buildRateLimitMiddlewareSpec,
+	}
+}
+
+func getRateLimitDescription() action_kit_api.ActionDescription {
+	desc := getCommonActionDescription(
+		RateLimitActionId,
+		"Traefik Rate Limit Traffic",
+		"Limit the request rate on a Traefik route using a rateLimit Middleware. Requests above the limit are answered with 429 Too Many Requests.",
+	)
+	desc.Parameters = append(desc.Parameters,
+		action_kit_api.ActionParameter{
+			Name:         "average",
+			Label:        "Requests per Second",
+			Description:  new("The average number of requests per second allowed per client IP."),
+			Type:         action_kit_api.ActionParameterTypeInteger,
+			DefaultValue: new("10"),
+			Required:     new(true),
+			MinValue:     new(1),
+		},
+		action_kit_api.ActionParameter{
+			Name:        "burst",
+			Label:       "Burst",
+			Description: new("The number of requests allowed to exceed the rate for a short time. Defaults to the requests per second."),
+			Type:        action_kit_api.ActionParameterTypeInteger,
+			Required:    new(false),
+			Advanced:    new(true),
+		},
+	)
+	return desc
+}
+
+func buildRateLimitMiddlewareSpec(config map[string]any, _ *ActionState) (map[string]any, error) {
+	average := extutil.ToInt64(config["average"])
+	if average < 1 {
+		return nil, fmt.Errorf("requests per second must be at least 1")
+	}
+	burst := extutil.ToInt64(config["burst"])
+	if burst < 0 {
+		return nil, fmt.Errorf("burst must not be negative")
+	} else if burst == 0 {
+		burst = average
+	}
+	return map[string]any{
+		"rateLimit": map[string]any{
+			"average": average,
+			"period":  "1s",
+			"burst":   burst,
+		},
+	}, nil
+}
@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exttraefik
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// TraefikRouteTargetType is the discovery target type for IngressRoutes and Ingresses served by Traefik.
+	TraefikRouteTargetType = "com.steadybit.extension_kubernetes.traefik-route"
+
+	// attrRoute is the discovery attribute holding the IngressRoute or Ingress name.
+	attrRoute = "k8s.traefik.route"
+	// attrRouteKind is the discovery attribute holding the route kind (IngressRoute or Ingress).
+	attrRouteKind = "k8s.traefik.route.kind"
+
+	DelayActionId     = "com.steadybit.extension_kubernetes.traefik-route-delay"
+	AbortActionId     = "com.steadybit.extension_kubernetes.traefik-route-abort"
+	RateLimitActionId = "com.steadybit.extension_kubernetes.traefik-route-rate-limit"
+
+	routeKindIngressRoute = "IngressRoute"
+	routeKindIngress      = "Ingress"
+
+	middlewareAPIVersion = "traefik.io/v1alpha1"
+	middlewareKind       = "Middleware"
+	// middlewareNamePrefix is prepended to the names of the Middlewares created by the attacks. The Middlewares are
+	// identified by their managed-by label though, as users may name their own Middlewares alike.
+	middlewareNamePrefix = "steadybit-"
+	// routerMiddlewaresAnnotation attaches Middlewares to the router Traefik creates for an Ingress.
+	routerMiddlewaresAnnotation = "traefik.ingress.kubernetes.io/router.middlewares"
+
+	// managedByLabelKey marks the Middlewares created by the attacks. A route referencing such a Middleware is
+	// already under attack.
+	managedByLabelKey = "steadybit.com/managed-by"
+	managedByValue    = "extension-kubernetes"
+	executionLabelKey = "steadybit.com/execution-id"
+
+	// TraefikIcon is a monochrome route icon (currentColor).
+	TraefikIcon = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20d%3D%22M12%202L3%206.5V17.5L12%2022L21%2017.5V6.5L12%202ZM12%204.24L19%207.74V16.26L12%2019.76L5%2016.26V7.74L12%204.24ZM8%208.5V10.5H11V16H13V10.5H16V8.5H8Z%22%20fill%3D%22currentColor%22%2F%3E%0A%3C%2Fsvg%3E%0A"
+)
+
+// buildMiddleware builds an unstructured Traefik Middleware object with the given spec.
+func buildMiddleware(namespace, name, executionId string, spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": middlewareAPIVersion,
+			"kind":       middlewareKind,
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": namespace,
+				"labels": map[string]any{
+					managedByLabelKey: managedByValue,
+					executionLabelKey: executionId,
+				},
+			},
+			"spec": spec,
+		},
+	}
+}
+
+// ingressRouteMiddlewares returns the names of the Middlewares referenced by any route of the IngressRoute.
+func ingressRouteMiddlewares(route *unstructured.Unstructured) []string {
+	routes, found, err := unstructured.NestedSlice(route.Object, "spec", "routes")
+	if err != nil || !found {
+		return nil
+	}
+	var names []string
+	for _, r := range routes {
+		routeMap, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		middlewares, _ := routeMap["middlewares"].([]any)
+		for _, m := range middlewares {
+			if ref, ok := m.(map[string]any); ok {
+				if name, ok := ref["name"].(string); ok && name != "" {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// attachToIngressRoute puts the Middleware in front of the middlewares of every route of the IngressRoute, so it
+// takes effect before authentication or other middlewares can answer the request. Returns false if it was already
+// attached everywhere.
+func attachToIngressRoute(route *unstructured.Unstructured, name string) bool {
+	return updateIngressRouteMiddlewares(route, func(middlewares []any) []any {
+		if slices.ContainsFunc(middlewares, isMiddlewareRef(name)) {
+			return middlewares
+		}
+		return append([]any{map[string]any{"name": name}}, middlewares...)
+	})
+}
+
+// detachFromIngressRoute removes the Middleware from every route of the IngressRoute. Returns false if it wasn't
+// attached.
+func detachFromIngressRoute(route *unstructured.Unstructured, name string) bool {
+	return updateIngressRouteMiddlewares(route, func(middlewares []any) []any {
+		return slices.DeleteFunc(middlewares, isMiddlewareRef(name))
+	})
+}
+
+func updateIngressRouteMiddlewares(route *unstructured.Unstructured, update func(middlewares []any) []any) bool {
+	routes, found, err := unstructured.NestedSlice(route.Object, "spec", "routes")
+	if err != nil || !found {
+		return false
+	}
+	changed := false
+	for _, r := range routes {
+		routeMap, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		middlewares, _ := routeMap["middlewares"].([]any)
+		before := len(middlewares)
+		middlewares = update(slices.Clone(middlewares))
+		if len(middlewares) == before {
+			continue
+		}
+		changed = true
+		if len(middlewares) == 0 {
+			delete(routeMap, "middlewares")
+		} else {
+			routeMap["middlewares"] = middlewares
+		}
+	}
+	if changed {
+		_ = unstructured.SetNestedSlice(route.Object, routes, "spec", "routes")
+	}
+	return changed
+}
+
+func isMiddlewareRef(name string) func(m any) bool {
+	return func(m any) bool {
+		ref, ok := m.(map[string]any)
+		return ok && ref["name"] == name
+	}
+}
+
+// annotationMiddlewareRef returns the reference to a Middleware in the router.middlewares annotation of an Ingress.
+func annotationMiddlewareRef(namespace, name string) string {
+	return fmt.Sprintf("%s-%s@kubernetescrd", namespace, name)
+}
+
+// annotationMiddlewares returns the Middleware references of the router.middlewares annotation value.
+func annotationMiddlewares(value string) []string {
+	var refs []string
+	for ref := range strings.SplitSeq(value, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// withAnnotationMiddleware puts the reference in front of the router.middlewares annotation value.
+func withAnnotationMiddleware(value, ref string) string {
+	refs := annotationMiddlewares(value)
+	if slices.Contains(refs, ref) {
+		return value
+	}
+	return strings.Join(append([]string{ref}, refs...), ",")
+}
+
+// withoutAnnotationMiddleware removes the reference from the router.middlewares annotation value.
+func withoutAnnotationMiddleware(value, ref string) string {
+	refs := annotationMiddlewares(value)
+	if !slices.Contains(refs, ref) {
+		return value
+	}
+	return strings.Join(slices.DeleteFunc(refs, func(r string) bool { return r == ref }), ",")
+}
+
+// findConflictingMiddleware returns the first of the attackMiddlewares other than ownName among the references.
+// References from the router.middlewares annotation are namespace-prefixed, which is accounted for via namespacePrefix.
+func findConflictingMiddleware(refs []string, namespacePrefix, ownName string, attackMiddlewares map[string]bool) string {
+	for _, ref := range refs {
+		name := strings.TrimSuffix(strings.TrimPrefix(ref, namespacePrefix), "@kubernetescrd")
+		if name != ownName && attackMiddlewares[name] {
+			return ref
+		}
+	}
+	return ""
+}
+
+func objectMetaFromUnstructured(obj *unstructured.Unstructured) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		Annotations: obj.GetAnnotations(),
+		Labels:      obj.GetLabels(),
+	}
+}
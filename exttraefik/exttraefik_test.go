@@ -0,0 +1,637 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exttraefik
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_buildFaultMiddlewareSpec(t *testing.T) {
+	extconfig.Config.TraefikFaultUrl = "http://extension.steadybit.svc:8088/traefik/fault"
+
+	tests := []struct {
+		name      string
+		build     func(config map[string]any, state *ActionState) (map[string]any, error)
+		config    map[string]any
+		wantQuery map[string]string
+		wantErr   string
+	}{
+		{
+			name:      "delay",
+			build:     buildDelayMiddlewareSpec,
+			config:    map[string]any{"delay": float64(500), "percentage": float64(25)},
+			wantQuery: map[string]string{"execution": "exec-1", "delay": "500", "status": "", "percentage": "25"},
+		},
+		{
+			name:    "delay without duration",
+			build:   buildDelayMiddlewareSpec,
+			config:  map[string]any{"delay": float64(0)},
+			wantErr: "delay must be greater than zero",
+		},
+		{
+			name:    "delay beyond the forwardAuth timeout",
+			build:   buildDelayMiddlewareSpec,
+			config:  map[string]any{"delay": float64(25001)},
+			wantErr: "at most 25s",
+		},
+		{
+			name:      "abort of all requests",
+			build:     buildAbortMiddlewareSpec,
+			config:    map[string]any{"statusCode": float64(503)},
+			wantQuery: map[string]string{"execution": "exec-1", "delay": "", "status": "503", "percentage": "100"},
+		},
+		{
+			name:      "abort of a fraction of the requests",
+			build:     buildAbortMiddlewareSpec,
+			config:    map[string]any{"statusCode": float64(503), "percentage": 12.5},
+			wantQuery: map[string]string{"execution": "exec-1", "delay": "", "status": "503", "percentage": "12.5"},
+		},
+		{
+			name:    "abort with a status letting the requests pass",
+			build:   buildAbortMiddlewareSpec,
+			config:  map[string]any{"statusCode": float64(200)},
+			wantErr: "statusCode must be between 400 and 599",
+		},
+		{
+			name:    "abort of no requests",
+			build:   buildAbortMiddlewareSpec,
+			config:  map[string]any{"statusCode": float64(503), "percentage": float64(0)},
+			wantErr: "percentage must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &ActionState{ExecutionId: "exec-1"}
+			spec, err := tt.build(tt.config, state)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, state.FaultKey)
+
+			address, err := url.Parse(spec["forwardAuth"].(map[string]any)["address"].(string))
+			require.NoError(t, err)
+			assert.Equal(t, "http://extension.steadybit.svc:8088/traefik/fault", address.Scheme+"://"+address.Host+address.Path)
+			query := address.Query()
+			for key, want := range tt.wantQuery {
+				assert.Equal(t, want, query.Get(key), key)
+			}
+			assert.NotEmpty(t, query.Get("token"))
+		})
+	}
+}
+
+func Test_buildRateLimitMiddlewareSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:   "burst defaults to the average",
+			config: map[string]any{"average": float64(5)},
+			want:   map[string]any{"average": int64(5), "period": "1s", "burst": int64(5)},
+		},
+		{
+			name:   "explicit burst",
+			config: map[string]any{"average": float64(5), "burst": float64(20)},
+			want:   map[string]any{"average": int64(5), "period": "1s", "burst": int64(20)},
+		},
+		{
+			name:    "no requests",
+			config:  map[string]any{"average": float64(0)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := buildRateLimitMiddlewareSpec(tt.config, &ActionState{ExecutionId: "exec-1"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec["rateLimit"])
+		})
+	}
+}
+
+func Test_handleFault(t *testing.T) {
+	key := newFaultKey()
+	registerFaultKey("exec-1", key)
+	defer unregisterFaultKey("exec-1")
+
+	tests := []struct {
+		name       string
+		address    string
+		wantStatus int
+		minLatency time.Duration
+	}{
+		{
+			name:       "aborts with the status code",
+			address:    faultAddress("", key, "exec-1", 0, 503, 100),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "lets the request pass after the delay",
+			address:    faultAddress("", key, "exec-1", 50*time.Millisecond, 0, 100),
+			wantStatus: http.StatusOK,
+			minLatency: 50 * time.Millisecond,
+		},
+		{
+			name:       "lets unsampled requests pass",
+			address:    faultAddress("", key, "exec-1", time.Second, 503, 0),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ignores faults without token",
+			address:    "?percentage=100&status=503",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ignores faults of unknown attacks",
+			address:    faultAddress("", key, "exec-2", 0, 503, 100),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ignores faults with a token of other parameters",
+			address:    strings.Replace(faultAddress("", key, "exec-1", 0, 404, 100), "status=404", "status=503", 1),
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			start := time.Now()
+			handleFault(recorder, httptest.NewRequest(http.MethodGet, FaultEndpointPath+tt.address, nil), nil)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.GreaterOrEqual(t, time.Since(start), tt.minLatency)
+		})
+	}
+}
+
+func ingressRoute(namespace, name string, middlewares ...string) *unstructured.Unstructured {
+	refs := make([]any, len(middlewares))
+	for i, m := range middlewares {
+		refs[i] = map[string]any{"name": m}
+	}
+	route := map[string]any{
+		"kind":     "Rule",
+		"match":    "Host(`shop.example.com`) && PathPrefix(`/api`)",
+		"services": []any{map[string]any{"name": "shop", "port": int64(80)}},
+	}
+	if len(refs) > 0 {
+		route["middlewares"] = refs
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "traefik.io/v1alpha1", "kind": "IngressRoute",
+		"metadata": map[string]any{"name": name, "namespace": namespace},
+		"spec": map[string]any{
+			"entryPoints": []any{"websecure"},
+			"routes":      []any{route},
+		},
+	}}
+}
+
+func Test_attachToIngressRoute(t *testing.T) {
+	route := ingressRoute("default", "shop", "auth")
+
+	assert.True(t, attachToIngressRoute(route, "steadybit-delay-1"))
+	assert.Equal(t, []string{"steadybit-delay-1", "auth"}, ingressRouteMiddlewares(route))
+	assert.False(t, attachToIngressRoute(route, "steadybit-delay-1"), "attaching twice should be a no-op")
+
+	assert.True(t, detachFromIngressRoute(route, "steadybit-delay-1"))
+	assert.Equal(t, []string{"auth"}, ingressRouteMiddlewares(route))
+	assert.False(t, detachFromIngressRoute(route, "steadybit-delay-1"))
+}
+
+func Test_withAnnotationMiddleware(t *testing.T) {
+	ref := annotationMiddlewareRef("default", "steadybit-delay-1")
+	require.Equal(t, "default-steadybit-delay-1@kubernetescrd", ref)
+
+	tests := []struct {
+		name        string
+		value       string
+		wantWith    string
+		wantWithout string
+	}{
+		{
+			name:        "no middlewares",
+			value:       "",
+			wantWith:    ref,
+			wantWithout: "",
+		},
+		{
+			name:        "other middlewares",
+			value:       "default-auth@kubernetescrd",
+			wantWith:    ref + ",default-auth@kubernetescrd",
+			wantWithout: "default-auth@kubernetescrd",
+		},
+		{
+			name:        "already attached",
+			value:       ref + ",default-auth@kubernetescrd",
+			wantWith:    ref + ",default-auth@kubernetescrd",
+			wantWithout: "default-auth@kubernetescrd",
+		},
+		{
+			name:        "only the attack middleware",
+			value:       ref,
+			wantWith:    ref,
+			wantWithout: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantWith, withAnnotationMiddleware(tt.value, ref))
+			assert.Equal(t, tt.wantWithout, withoutAnnotationMiddleware(tt.value, ref))
+		})
+	}
+}
+
+func Test_findConflictingMiddleware(t *testing.T) {
+	attackMiddlewares := map[string]bool{"steadybit-delay-1": true, "steadybit-abort-2": true}
+
+	tests := []struct {
+		name            string
+		refs            []string
+		namespacePrefix string
+		want            string
+	}{
+		{
+			name: "middleware of another attack",
+			refs: []string{"auth", "steadybit-abort-2"},
+			want: "steadybit-abort-2",
+		},
+		{
+			name: "own middleware",
+			refs: []string{"auth", "steadybit-delay-1"},
+		},
+		{
+			name: "user middleware sharing the prefix",
+			refs: []string{"steadybit-headers"},
+		},
+		{
+			name:            "annotation reference to the middleware of another attack",
+			refs:            []string{"default-steadybit-abort-2@kubernetescrd"},
+			namespacePrefix: "default-",
+			want:            "default-steadybit-abort-2@kubernetescrd",
+		},
+		{
+			name:            "annotation reference to the own middleware",
+			refs:            []string{"default-steadybit-delay-1@kubernetescrd"},
+			namespacePrefix: "default-",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, findConflictingMiddleware(tt.refs, tt.namespacePrefix, "steadybit-delay-1", attackMiddlewares))
+		})
+	}
+}
+
+func Test_hostsFromMatch(t *testing.T) {
+	tests := []struct {
+		match string
+		want  []string
+	}{
+		{match: "Host(`a.example.com`) && PathPrefix(`/`)", want: []string{"a.example.com"}},
+		{match: "Host(`a.example.com`, `b.example.com`)", want: []string{"a.example.com", "b.example.com"}},
+		{match: "PathPrefix(`/`) || Host(`c.example.com`)", want: []string{"c.example.com"}},
+		{match: "HostRegexp(`.+`)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.match, func(t *testing.T) {
+			assert.Equal(t, tt.want, hostsFromMatch(tt.match))
+		})
+	}
+}
+
+func getTestClient(stopCh <-chan struct{}, objects ...runtime.Object) (*client.Client, dynamic.Interface) {
+	extconfig.Config.DiscoveryDisabledTraefik = false
+	extconfig.Config.ClusterName = "test-cluster"
+
+	dynamicClient := testutil.NewFakeDynamicClient()
+	clientset := testclient.NewSimpleClientset(objects...)
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted(), dynamicClient)
+	k8sClient.Distribution = "kubernetes"
+	return k8sClient, dynamicClient
+}
+
+func ingress(namespace, name, className string, annotations map[string]string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID("uid-" + name), Annotations: annotations},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: &className,
+			DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+				Name: "shop",
+				Port: networkingv1.ServiceBackendPort{Number: 80},
+			}},
+			Rules: []networkingv1.IngressRule{{Host: name + ".example.com"}},
+		},
+	}
+}
+
+func create(t *testing.T, dc dynamic.Interface, obj *unstructured.Unstructured) {
+	t.Helper()
+	_, err := dc.Resource(client.TraefikIngressRouteGVR).Namespace(obj.GetNamespace()).Create(context.Background(), obj, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func Test_routeDiscovery(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh,
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "edge"},
+			Spec:       networkingv1.IngressClassSpec{Controller: "traefik.io/ingress-controller"},
+		},
+		ingress("default", "web", "edge", nil),
+		ingress("default", "other", "nginx", nil),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default", UID: "svc-shop"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "shop"}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default", UID: "deploy-shop"},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "shop"}}}},
+		},
+	)
+	create(t, dc, ingressRoute("default", "shop"))
+
+	discovery := &routeDiscovery{k8s: k8sClient, backends: extcommon.NewBackendResolver(k8sClient)}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		targets, err := discovery.DiscoverTargets(context.Background())
+		assert.NoError(c, err)
+		require.Len(c, targets, 2)
+
+		shop := targets[0]
+		assert.Equal(c, TraefikRouteTargetType, shop.TargetType)
+		assert.Equal(c, "test-cluster/default/IngressRoute/shop", shop.Id)
+		assert.Equal(c, []string{"shop"}, shop.Attributes[attrRoute])
+		assert.Equal(c, []string{"IngressRoute"}, shop.Attributes[attrRouteKind])
+		assert.Equal(c, []string{"shop.example.com"}, shop.Attributes["k8s.traefik.route.host"])
+		assert.Equal(c, []string{"websecure"}, shop.Attributes["k8s.traefik.entrypoint"])
+		assert.Equal(c, []string{"shop"}, shop.Attributes[extcommon.BackendServiceAttribute])
+		assert.Equal(c, []string{"shop"}, shop.Attributes[extcommon.BackendDeploymentAttribute])
+
+		web := targets[1]
+		assert.Equal(c, "test-cluster/default/Ingress/web", web.Id)
+		assert.Equal(c, []string{"Ingress"}, web.Attributes[attrRouteKind])
+		assert.Equal(c, []string{"edge"}, web.Attributes["k8s.ingress.class"])
+		assert.Equal(c, []string{"web.example.com"}, web.Attributes["k8s.traefik.route.host"])
+		assert.Equal(c, []string{"shop"}, web.Attributes[extcommon.BackendServiceAttribute])
+		assert.Equal(c, []string{"shop"}, web.Attributes[extcommon.BackendDeploymentAttribute])
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func Test_routeDiscovery_skipsIngressesWhichCannotBeUpdated(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh,
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "edge"},
+			Spec:       networkingv1.IngressClassSpec{Controller: "traefik.io/ingress-controller"},
+		},
+		ingress("default", "web", "edge", nil),
+	)
+	k8sClient.Permissions().Permissions["networking.k8s.io/ingresses/update"] = client.ERROR
+	create(t, dc, ingressRoute("default", "shop"))
+
+	discovery := &routeDiscovery{k8s: k8sClient, backends: extcommon.NewBackendResolver(k8sClient)}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		targets, err := discovery.DiscoverTargets(context.Background())
+		assert.NoError(c, err)
+		require.Len(c, targets, 1)
+		assert.Equal(c, "test-cluster/default/IngressRoute/shop", targets[0].Id)
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func Test_ingressRouteBackends(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []any
+		want     []extcommon.BackendRef
+	}{
+		{
+			name:     "numeric and named ports",
+			services: []any{map[string]any{"name": "shop", "port": int64(80)}, map[string]any{"name": "cart", "port": "http"}},
+			want:     []extcommon.BackendRef{{Service: "shop", Port: "80"}, {Service: "cart", Port: "http"}},
+		},
+		{
+			name:     "skips TraefikServices",
+			services: []any{map[string]any{"name": "weighted", "kind": "TraefikService"}},
+		},
+		{
+			name:     "skips services in other namespaces",
+			services: []any{map[string]any{"name": "shop", "namespace": "other", "port": int64(80)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			route := ingressRoute("default", "shop")
+			require.NoError(t, unstructured.SetNestedSlice(route.Object, []any{map[string]any{
+				"match":    "Host(`shop.example.com`) && (PathPrefix(`/api`) || Path(`/health`))",
+				"services": tt.services,
+			}}, "spec", "routes"))
+
+			// When
+			backends := ingressRouteBackends(route)
+
+			// Then
+			assert.Equal(t, tt.want, backends.Backends)
+			assert.Equal(t, []string{"shop.example.com"}, backends.Hostnames)
+			assert.Equal(t, []string{"/api", "/health"}, backends.Paths)
+		})
+	}
+}
+
+func newRateLimitRequest(executionId uuid.UUID, kind, name string) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		ExecutionId: executionId,
+		Config:      map[string]any{"duration": float64(30000), "average": float64(5)},
+		Target: new(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":          {"default"},
+				"k8s.traefik.route":      {name},
+				"k8s.traefik.route.kind": {kind},
+			},
+		}),
+	}
+}
+
+func Test_action_lifecycle(t *testing.T) {
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		routes      []*unstructured.Unstructured
+		kind        string
+		routeName   string
+		middlewares func(t *testing.T, k8sClient *client.Client) []string
+		want        func(state ActionState) []string
+		wantStopped []string
+	}{
+		{
+			name:      "IngressRoute",
+			routes:    []*unstructured.Unstructured{ingressRoute("default", "shop", "steadybit-auth")},
+			kind:      routeKindIngressRoute,
+			routeName: "shop",
+			middlewares: func(t *testing.T, k8sClient *client.Client) []string {
+				route, err := k8sClient.GetTraefikIngressRoute(context.Background(), "default", "shop")
+				require.NoError(t, err)
+				return ingressRouteMiddlewares(route)
+			},
+			want: func(state ActionState) []string {
+				return []string{state.MiddlewareName, "steadybit-auth"}
+			},
+			wantStopped: []string{"steadybit-auth"},
+		},
+		{
+			name: "Ingress",
+			objects: []runtime.Object{ingress("default", "web", "traefik", map[string]string{
+				routerMiddlewaresAnnotation: "default-auth@kubernetescrd",
+			})},
+			kind:      routeKindIngress,
+			routeName: "web",
+			middlewares: func(t *testing.T, k8sClient *client.Client) []string {
+				web, err := k8sClient.IngressByNamespaceAndName("default", "web", true)
+				require.NoError(t, err)
+				return strings.Split(web.Annotations[routerMiddlewaresAnnotation], ",")
+			},
+			want: func(state ActionState) []string {
+				return []string{annotationMiddlewareRef("default", state.MiddlewareName), "default-auth@kubernetescrd"}
+			},
+			wantStopped: []string{"default-auth@kubernetescrd"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			k8sClient, dc := getTestClient(stopCh, tt.objects...)
+			for _, route := range tt.routes {
+				create(t, dc, route)
+			}
+
+			// Given
+			action := NewRateLimitAction(k8sClient).(*middlewareAction)
+			executionId := uuid.New()
+			state := action.NewEmptyState()
+			_, err := action.Prepare(context.Background(), &state, newRateLimitRequest(executionId, tt.kind, tt.routeName))
+			require.NoError(t, err)
+			assert.Equal(t, "steadybit-rate-limit-"+executionId.String(), state.MiddlewareName)
+
+			// When
+			_, err = action.Start(context.Background(), &state)
+			require.NoError(t, err)
+
+			// Then
+			middleware, err := dc.Resource(client.TraefikMiddlewareGVR).Namespace("default").Get(context.Background(), state.MiddlewareName, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, managedByValue, middleware.GetLabels()[managedByLabelKey])
+			assert.Equal(t, tt.want(state), tt.middlewares(t, k8sClient))
+
+			other := action.NewEmptyState()
+			_, err = action.Prepare(context.Background(), &other, newRateLimitRequest(uuid.New(), tt.kind, tt.routeName))
+			assert.ErrorContains(t, err, "Another attack is already running", "a second attack on the same route is rejected")
+
+			// When
+			_, err = action.Stop(context.Background(), &state)
+			require.NoError(t, err)
+
+			// Then
+			_, err = dc.Resource(client.TraefikMiddlewareGVR).Namespace("default").Get(context.Background(), state.MiddlewareName, metav1.GetOptions{})
+			assert.Error(t, err, "middleware should be deleted on stop")
+			assert.Equal(t, tt.wantStopped, tt.middlewares(t, k8sClient))
+		})
+	}
+}
+
+func Test_action_status(t *testing.T) {
+	faultEndpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { handleFault(w, r, nil) }))
+	defer faultEndpoint.Close()
+	extconfig.Config.TraefikFaultUrl = faultEndpoint.URL
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, dc := getTestClient(stopCh)
+	create(t, dc, ingressRoute("default", "shop"))
+
+	// Given
+	action := NewAbortAction(k8sClient).(*middlewareAction)
+	state := action.NewEmptyState()
+	request := newRateLimitRequest(uuid.New(), routeKindIngressRoute, "shop")
+	request.Config = map[string]any{"duration": float64(30000), "statusCode": float64(503)}
+	_, err := action.Prepare(context.Background(), &state, request)
+	require.NoError(t, err)
+	_, err = action.Start(context.Background(), &state)
+	require.NoError(t, err)
+	defer func() { _, _ = action.Stop(context.Background(), &state) }()
+	address := state.MiddlewareSpec["forwardAuth"].(map[string]any)["address"].(string)
+	faultStatus := func() int {
+		res, err := http.Get(address)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		return res.StatusCode
+	}
+	require.Equal(t, http.StatusServiceUnavailable, faultStatus())
+
+	// When the extension restarted and lost the key
+	unregisterFaultKey(state.ExecutionId)
+	require.Equal(t, http.StatusOK, faultStatus())
+	result, err := action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Nil(t, result.Error)
+	assert.Equal(t, http.StatusServiceUnavailable, faultStatus(), "the status check registers the key again")
+
+	// When the Middleware is detached
+	require.NoError(t, k8sClient.UpdateTraefikIngressRoute(context.Background(), "default", "shop", func(route *unstructured.Unstructured) bool {
+		return detachFromIngressRoute(route, state.MiddlewareName)
+	}))
+	result, err = action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Contains(t, result.Error.Title, "was removed from IngressRoute default/shop")
+
+	// When the fault endpoint is not reachable
+	faultEndpoint.Close()
+	result, err = action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Contains(t, result.Error.Title, "is not reachable")
+}
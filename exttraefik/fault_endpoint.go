@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exttraefik
+
+import (
+	"context"
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kit/exthttp"
+)
+
+// FaultEndpointPath is the path of the endpoint the delay and abort Middlewares call via forwardAuth. Traefik has no
+// built-in middleware to delay or abort requests, but forwardAuth holds each request until the endpoint answers and
+// returns any non-2xx answer to the client. The fault is encoded in the query of the forwardAuth address, together
+// with the execution id of the attack and a token signing both.
+const FaultEndpointPath = "/traefik/fault"
+
+// maxFaultDelay stays below the 30 seconds after which Traefik gives up on a forwardAuth request and answers with an
+// error itself.
+const maxFaultDelay = 25 * time.Second
+
+// faultProbeTimeout limits how long the status check waits for the fault endpoint to answer.
+const faultProbeTimeout = 2 * time.Second
+
+// faultKeys holds the keys signing the fault addresses of the running attacks by execution id. The endpoint is served
+// on the port of the action API without authentication, so only signed faults are injected. Each attack keeps its key
+// in the action state and registers it again on every status check, so the faults verify again after a restart.
+var faultKeys sync.Map
+
+func newFaultKey() string {
+	return cryptorand.Text()
+}
+
+func registerFaultKey(executionId, key string) {
+	faultKeys.Store(executionId, key)
+}
+
+func unregisterFaultKey(executionId string) {
+	faultKeys.Delete(executionId)
+}
+
+func RegisterFaultEndpoint() {
+	// Every request to an attacked route passes this endpoint, so the request log is kept at trace level.
+	exthttp.RegisterHttpHandlerWithLogLevel(FaultEndpointPath, handleFault, zerolog.TraceLevel)
+}
+
+// faultAddress returns the forwardAuth address injecting the fault of the attack into the given percentage of
+// requests, signed with the key of the attack. A zero status lets the requests pass after the delay.
+func faultAddress(baseUrl, key, executionId string, delay time.Duration, status int, percentage float64) string {
+	query := url.Values{}
+	query.Set("execution", executionId)
+	if delay > 0 {
+		query.Set("delay", strconv.FormatInt(delay.Milliseconds(), 10))
+	}
+	if status > 0 {
+		query.Set("status", strconv.Itoa(status))
+	}
+	query.Set("percentage", strconv.FormatFloat(percentage, 'f', -1, 64))
+	query.Set("token", faultToken(key, query))
+	return baseUrl + "?" + query.Encode()
+}
+
+// faultToken signs the fault parameters of the query, all but the token itself.
+func faultToken(key string, query url.Values) string {
+	signed := url.Values{}
+	for key, values := range query {
+		if key != "token" {
+			signed[key] = values
+		}
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(signed.Encode()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func handleFault(w http.ResponseWriter, r *http.Request, _ []byte) {
+	query := r.URL.Query()
+	key, ok := faultKeys.Load(query.Get("execution"))
+	if !ok || !hmac.Equal([]byte(query.Get("token")), []byte(faultToken(key.(string), query))) {
+		// Answering with an error would break the route, so requests with an unknown fault pass unchanged.
+		log.Debug().Str("execution", query.Get("execution")).Msg("Ignoring Traefik fault request with an invalid token")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	percentage, err := strconv.ParseFloat(query.Get("percentage"), 64)
+	if err != nil {
+		percentage = 100
+	}
+	if rand.Float64()*100 >= percentage {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if delayMs, err := strconv.ParseInt(query.Get("delay"), 10, 64); err == nil && delayMs > 0 {
+		timer := time.NewTimer(time.Duration(delayMs) * time.Millisecond)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	status, err := strconv.Atoi(query.Get("status"))
+	if err != nil || status < 200 || status > 599 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+}
+
+// checkFaultEndpoint verifies that the fault endpoint answers at the address the Middlewares call. Traefik fails every
+// request of the route while the endpoint is unreachable.
+func checkFaultEndpoint(ctx context.Context, baseUrl string) error {
+	ctx, cancel := context.WithTimeout(ctx, faultProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseUrl, nil)
+	if err != nil {
+		return err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exttraefik
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type routeDiscovery struct {
+	k8s      *client.Client
+	targets  *extcommon.ResultCache[discovery_kit_api.Target]
+	backends *extcommon.BackendResolver
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*routeDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*routeDiscovery)(nil)
+)
+
+func NewRouteDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &routeDiscovery{
+		k8s:      k8s,
+		targets:  extcommon.NewResultCache[discovery_kit_api.Target](),
+		backends: extcommon.NewBackendResolver(k8s),
+	}
+	chRefresh := extcommon.TriggerOnKubernetesSpecChange(k8s,
+		reflect.TypeFor[unstructured.Unstructured](),
+		reflect.TypeFor[networkingv1.Ingress](),
+		reflect.TypeFor[networkingv1.IngressClass](),
+		reflect.TypeFor[corev1.Service](),
+		reflect.TypeFor[appsv1.Deployment](),
+		reflect.TypeFor[appsv1.StatefulSet](),
+	)
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, time.Duration(extconfig.Config.DiscoveryRefreshThrottle)*time.Second),
+	)
+}
+
+func (d *routeDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: TraefikRouteTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("30s"),
+		},
+	}
+}
+
+func (d *routeDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       TraefikRouteTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Traefik Route", Other: "Traefik Routes"},
+		Category: new("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(TraefikIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: attrRoute},
+				{Attribute: attrRouteKind},
+				{Attribute: "k8s.traefik.route.host"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{Attribute: attrRoute, Direction: "ASC"},
+			},
+		},
+	}
+}
+
+func (d *routeDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	defer d.targets.EndRun()
+	defer d.backends.EndRun()
+
+	var targets []discovery_kit_api.Target
+	for _, route := range d.k8s.TraefikIngressRoutes() {
+		if client.IsExcludedFromDiscovery(objectMetaFromUnstructured(route)) {
+			continue
+		}
+		backendAttributes, backendsFingerprint := d.backends.Resolve(route.GetNamespace(), ingressRouteBackends(route))
+		fingerprint := extcommon.Fingerprints(extcommon.Fingerprint(route), backendsFingerprint, extcommon.NamespaceFingerprint(d.k8s, route.GetNamespace()))
+		targets = append(targets, d.targets.Get(string(route.GetUID()), fingerprint, func() discovery_kit_api.Target {
+			return d.ingressRouteToTarget(route, backendAttributes)
+		}))
+	}
+
+	// The attacks reference their Middleware in an annotation of the Ingress, so Ingresses are only offered if they
+	// can be updated.
+	if d.k8s.Permissions().IsListIngressPermitted() && d.k8s.Permissions().IsListIngressClassesPermitted() && d.k8s.Permissions().IsModifyIngressPermitted() {
+		traefikClasses, hasDefaultClass := d.k8s.GetTraefikIngressClasses()
+		ingressClassesFingerprint := extcommon.Fingerprint(d.k8s.IngressClasses()...)
+		for _, ingress := range d.k8s.Ingresses() {
+			if client.IsExcludedFromDiscovery(ingress.ObjectMeta) || !isTraefikIngress(ingress, traefikClasses, hasDefaultClass) {
+				continue
+			}
+			backendAttributes, backendsFingerprint := d.backends.Resolve(ingress.Namespace, extcommon.IngressBackends(ingress))
+			fingerprint := extcommon.Fingerprints(extcommon.Fingerprint(ingress), ingressClassesFingerprint, backendsFingerprint, extcommon.NamespaceFingerprint(d.k8s, ingress.Namespace))
+			targets = append(targets, d.targets.Get(string(ingress.UID), fingerprint, func() discovery_kit_api.Target {
+				return d.ingressToTarget(ingress, backendAttributes)
+			}))
+		}
+	}
+
+	return targets, nil
+}
+
+func (d *routeDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return extcommon.GetRouteToWorkloadEnrichmentRules(TraefikRouteTargetType)
+}
+
+func (d *routeDiscovery) ingressRouteToTarget(route *unstructured.Unstructured, backendAttributes map[string][]string) discovery_kit_api.Target {
+	attributes := d.commonAttributes(route.GetNamespace(), route.GetName(), routeKindIngressRoute, route.GetLabels())
+
+	var hosts []string
+	if routes, found, err := unstructured.NestedSlice(route.Object, "spec", "routes"); err == nil && found {
+		for _, r := range routes {
+			if routeMap, ok := r.(map[string]any); ok {
+				match, _ := routeMap["match"].(string)
+				hosts = append(hosts, hostsFromMatch(match)...)
+			}
+		}
+	}
+	if len(hosts) > 0 {
+		attributes["k8s.traefik.route.host"] = extcommon.SortDedup(hosts)
+	}
+	if entryPoints, found, err := unstructured.NestedStringSlice(route.Object, "spec", "entryPoints"); err == nil && found && len(entryPoints) > 0 {
+		attributes["k8s.traefik.entrypoint"] = extcommon.SortDedup(entryPoints)
+	}
+	extcommon.MergeAttributes(attributes, backendAttributes)
+
+	return d.toTarget(route.GetNamespace(), route.GetName(), routeKindIngressRoute, attributes)
+}
+
+func (d *routeDiscovery) ingressToTarget(ingress *networkingv1.Ingress, backendAttributes map[string][]string) discovery_kit_api.Target {
+	attributes := d.commonAttributes(ingress.Namespace, ingress.Name, routeKindIngress, ingress.Labels)
+
+	var hosts []string
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	if len(hosts) > 0 {
+		attributes["k8s.traefik.route.host"] = extcommon.SortDedup(hosts)
+	}
+	if className := ingressClassName(ingress); className != "" {
+		attributes["k8s.ingress.class"] = []string{className}
+	}
+	extcommon.MergeAttributes(attributes, backendAttributes)
+
+	return d.toTarget(ingress.Namespace, ingress.Name, routeKindIngress, attributes)
+}
+
+func (d *routeDiscovery) commonAttributes(namespace, name, kind string, labels map[string]string) map[string][]string {
+	attributes := map[string][]string{
+		"k8s.namespace":    {namespace},
+		"k8s.cluster-name": {extconfig.Config.ClusterName},
+		"k8s.distribution": {d.k8s.Distribution},
+		attrRoute:          {name},
+		attrRouteKind:      {kind},
+	}
+	extcommon.AddLabels(attributes, labels, "k8s.traefik.route.label", "k8s.label")
+	extcommon.AddNamespaceLabels(attributes, d.k8s, namespace)
+	return attributes
+}
+
+func (d *routeDiscovery) toTarget(namespace, name, kind string, attributes map[string][]string) discovery_kit_api.Target {
+	target := discovery_kit_api.Target{
+		Id:         fmt.Sprintf("%s/%s/%s/%s", extconfig.Config.ClusterName, namespace, kind, name),
+		TargetType: TraefikRouteTargetType,
+		Label:      name,
+		Attributes: attributes,
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes([]discovery_kit_api.Target{target}, extconfig.Config.DiscoveryAttributesExcludesTraefik)[0]
+}
+
+var (
+	hostMatcherRegex = regexp.MustCompile(`Host\(([^)]*)\)`)
+	pathMatcherRegex = regexp.MustCompile(`Path(?:Prefix)?\(([^)]*)\)`)
+	backtickRegex    = regexp.MustCompile("`([^`]*)`")
+)
+
+// hostsFromMatch returns the hosts of the Host matchers in a Traefik rule, e.g. "Host(`a.com`) && PathPrefix(`/`)".
+func hostsFromMatch(match string) []string {
+	return matcherValues(hostMatcherRegex, match)
+}
+
+// pathsFromMatch returns the paths of the Path and PathPrefix matchers in a Traefik rule.
+func pathsFromMatch(match string) []string {
+	return matcherValues(pathMatcherRegex, match)
+}
+
+func matcherValues(matcherRegex *regexp.Regexp, match string) []string {
+	var values []string
+	for _, matcher := range matcherRegex.FindAllStringSubmatch(match, -1) {
+		for _, value := range backtickRegex.FindAllStringSubmatch(matcher[1], -1) {
+			if value[1] != "" {
+				values = append(values, value[1])
+			}
+		}
+	}
+	return values
+}
+
+// ingressRouteBackends returns the Service backends of the routes of an IngressRoute and the hosts and paths routed to
+// them. TraefikServices and Services in other namespaces are skipped.
+func ingressRouteBackends(route *unstructured.Unstructured) extcommon.RoutedBackends {
+	var result extcommon.RoutedBackends
+	routes, _, _ := unstructured.NestedSlice(route.Object, "spec", "routes")
+	for _, r := range routes {
+		routeMap, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		match, _ := routeMap["match"].(string)
+		result.Hostnames = append(result.Hostnames, hostsFromMatch(match)...)
+		result.Paths = append(result.Paths, pathsFromMatch(match)...)
+		services, _ := routeMap["services"].([]any)
+		for _, s := range services {
+			service, ok := s.(map[string]any)
+			if !ok {
+				continue
+			}
+			if kind, _ := service["kind"].(string); kind != "" && kind != "Service" {
+				continue
+			}
+			if namespace, _ := service["namespace"].(string); namespace != "" && namespace != route.GetNamespace() {
+				continue
+			}
+			name, _ := service["name"].(string)
+			if name == "" {
+				continue
+			}
+			backend := extcommon.BackendRef{Service: name}
+			switch port := service["port"].(type) {
+			case int64:
+				backend.Port = strconv.FormatInt(port, 10)
+			case float64:
+				backend.Port = strconv.FormatInt(int64(port), 10)
+			case string:
+				backend.Port = port
+			}
+			result.Backends = append(result.Backends, backend)
+		}
+	}
+	return result
+}
+
+func ingressClassName(ingress *networkingv1.Ingress) string {
+	if ingress.Spec.IngressClassName != nil {
+		return *ingress.Spec.IngressClassName
+	}
+	return ingress.Annotations["kubernetes.io/ingress.class"]
+}
+
+func isTraefikIngress(ingress *networkingv1.Ingress, traefikClasses []string, hasDefaultClass bool) bool {
+	if className := ingressClassName(ingress); className != "" {
+		return slices.Contains(traefikClasses, className)
+	}
+	return hasDefaultClass
+}
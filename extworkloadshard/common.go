@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extworkloadshard
+
+const (
+	WorkloadShardTargetType = "com.steadybit.extension_kubernetes.kubernetes-workload-shard"
+	shardAttribute          = "k8s.workload-shard"
+)
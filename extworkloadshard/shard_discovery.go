@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extworkloadshard
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extcommon"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// shardDiscovery lists the pods, containers and hosts of workloads with more than DiscoveryMaxPodCount pods, which
+// are left out of the workload targets to keep their attributes bounded.
+type shardDiscovery struct {
+	k8s *client.Client
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*shardDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*shardDiscovery)(nil)
+)
+
+func NewWorkloadShardDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &shardDiscovery{k8s: k8s}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+		reflect.TypeFor[corev1.Pod](),
+		reflect.TypeFor[corev1.Node](),
+		reflect.TypeFor[appsv1.Deployment](),
+		reflect.TypeFor[appsv1.StatefulSet](),
+		reflect.TypeFor[appsv1.DaemonSet](),
+	)
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, time.Duration(extconfig.Config.DiscoveryRefreshThrottle)*time.Second),
+	)
+}
+
+func (d *shardDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: WorkloadShardTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("30s"),
+		},
+	}
+}
+
+func (d *shardDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       WorkloadShardTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes Workload Shard", Other: "Kubernetes Workload Shards"},
+		Category: new("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new("data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20d%3D%22M10.4478%202.65625C11.2739%202.24209%2012.2447%202.23174%2013.0794%202.62821L19.2871%205.57666C20.3333%206.07356%2021%207.12832%2021%208.28652V15.7134C21%2016.8717%2020.3333%2017.9264%2019.2871%2018.4233L13.0794%2021.3718C12.2447%2021.7682%2011.2739%2021.7579%2010.4478%2021.3437L4.65545%2018.4397L5.55182%2016.6518L11.3441%2019.5558C11.6195%2019.6939%2011.9431%2019.6973%2012.2214%2019.5652L18.429%2016.6167C18.7778%2016.4511%2019%2016.0995%2019%2015.7134V8.28652C19%207.90045%2018.7778%207.54887%2018.429%207.38323L12.2214%204.43479C11.9431%204.30263%2011.6195%204.30608%2011.3441%204.44413L5.55182%207.34814C5.21357%207.51773%205%207.8637%205%208.24208V15.7579C5%2016.1363%205.21357%2016.4822%205.55182%2016.6518L4.65545%2018.4397C3.6407%2017.931%203%2016.893%203%2015.7579V8.24208C3%207.10694%203.6407%206.06901%204.65545%205.56026L10.4478%202.65625Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M11.1377%207.16465C11.5966%206.95033%2012.1359%206.94497%2012.5997%207.15014L16.0484%208.67595C16.6296%208.9331%2017%209.47893%2017%2010.0783V13.9217C17%2014.5211%2016.6296%2015.0669%2016.0484%2015.324L12.5997%2016.8499C12.1359%2017.055%2011.5966%2017.0497%2011.1377%2016.8353L7.9197%2015.3325C7.35594%2015.0693%207%2014.5321%207%2013.9447V10.0553C7%209.46787%207.35594%208.93074%207.9197%208.66747L11.1377%207.16465Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.workload-owner"},
+				{Attribute: shardAttribute},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.workload-owner",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *shardDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	nodes := d.k8s.Nodes()
+	var targets []discovery_kit_api.Target
+
+	if !extconfig.Config.DiscoveryDisabledDeployment {
+		for _, deployment := range d.k8s.Deployments() {
+			if client.IsExcludedFromDiscovery(deployment.ObjectMeta) {
+				continue
+			}
+			pods := d.k8s.PodsOwnedByDeployment(deployment.UID, deployment.Namespace)
+			targets = append(targets, d.toTargets("deployment", deployment.ObjectMeta, pods, nodes)...)
+		}
+	}
+	if !extconfig.Config.DiscoveryDisabledStatefulSet {
+		for _, sts := range d.k8s.StatefulSets() {
+			if client.IsExcludedFromDiscovery(sts.ObjectMeta) {
+				continue
+			}
+			pods := d.k8s.PodsByOwnerUid(sts.UID, sts.Namespace)
+			targets = append(targets, d.toTargets("statefulset", sts.ObjectMeta, pods, nodes)...)
+		}
+	}
+	if !extconfig.Config.DiscoveryDisabledDaemonSet {
+		for _, ds := range d.k8s.DaemonSets() {
+			if client.IsExcludedFromDiscovery(ds.ObjectMeta) {
+				continue
+			}
+			pods := d.k8s.PodsByOwnerUid(ds.UID, ds.Namespace)
+			targets = append(targets, d.toTargets("daemonset", ds.ObjectMeta, pods, nodes)...)
+		}
+	}
+
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesWorkloadShard), nil
+}
+
+func (d *shardDiscovery) toTargets(ownerType string, owner metav1.ObjectMeta, pods []*corev1.Pod, nodes []*corev1.Node) []discovery_kit_api.Target {
+	shards := extcommon.ShardPods(ownerType, pods, nodes)
+	targets := make([]discovery_kit_api.Target, 0, len(shards))
+	for _, shard := range shards {
+		attributes := map[string][]string{
+			"k8s.namespace":      {owner.Namespace},
+			"k8s." + ownerType:   {owner.Name},
+			"k8s.workload-type":  {ownerType},
+			"k8s.workload-owner": {owner.Name},
+			shardAttribute:       {shard.Key},
+			"k8s.cluster-name":   {extconfig.Config.ClusterName},
+			"k8s.distribution":   {d.k8s.Distribution},
+		}
+		extcommon.AddLabels(attributes, owner.Labels, "k8s."+ownerType+".label", "k8s.label")
+		extcommon.AddNamespaceLabels(attributes, d.k8s, owner.Namespace)
+		extcommon.MergeAttributes(attributes, extcommon.GetPodBasedAttributes(ownerType, owner, shard.Pods, nodes))
+
+		targets = append(targets, discovery_kit_api.Target{
+			Id:         fmt.Sprintf("%s/%s/%s/%s/%s", extconfig.Config.ClusterName, owner.Namespace, ownerType, owner.Name, shard.Key),
+			TargetType: WorkloadShardTargetType,
+			Label:      fmt.Sprintf("%s (%s)", owner.Name, shard.Name),
+			Attributes: attributes,
+		})
+	}
+	return targets
+}
+
+func (d *shardDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		getShardToContainerEnrichmentRule(),
+	}
+}
+
+// getShardToContainerEnrichmentRule adds the shard and the workload labels to the containers of large workloads,
+// so attacks can be limited to the containers of one shard.
+func getShardToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentRule {
+	return discovery_kit_api.TargetEnrichmentRule{
+		Id:      "com.steadybit.extension_kubernetes.kubernetes-workload-shard-to-container",
+		Version: extbuild.GetSemverVersionStringOrUnknown(),
+		Src: discovery_kit_api.SourceOrDestination{
+			Type: WorkloadShardTargetType,
+			Selector: map[string]string{
+				"k8s.container.id.stripped": "${dest.container.id.stripped}",
+			},
+		},
+		Dest: discovery_kit_api.SourceOrDestination{
+			Type: "com.steadybit.extension_container.container",
+			Selector: map[string]string{
+				"container.id.stripped": "${src.k8s.container.id.stripped}",
+			},
+		},
+		Attributes: []discovery_kit_api.Attribute{
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    shardAttribute,
+			},
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.deployment.label",
+			},
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.statefulset.label",
+			},
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.daemonset.label",
+			},
+			{
+				Matcher: discovery_kit_api.Regex,
+				Name:    "^k8s\\.label\\.(?!topology).*",
+			},
+		},
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extworkloadshard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_shardDiscovery(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DiscoveryMaxPodCount = 2
+
+	objects := []runtime.Object{
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default", UID: "agent", Labels: map[string]string{"team": "infra"}}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "default", UID: "small"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "small-1", Namespace: "default", OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "small", UID: "small"}}},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	}
+	for i := 1; i <= 3; i++ {
+		objects = append(objects,
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", i)}},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("agent-%d", i), Namespace: "default", OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", UID: "agent"}}},
+				Spec:       corev1.PodSpec{NodeName: fmt.Sprintf("node-%d", i)},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{ContainerID: fmt.Sprintf("containerd://agent-%d", i)}},
+				},
+			},
+		)
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8s := client.CreateClient(testclient.NewClientset(objects...), stopCh, "", client.MockAllPermitted(), testutil.NewFakeDynamicClient())
+	d := &shardDiscovery{k8s: k8s}
+
+	// When
+	var targets []discovery_kit_api.Target
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		var err error
+		targets, err = d.DiscoverTargets(context.Background())
+		require.NoError(c, err)
+		assert.Len(c, targets, 3)
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Then
+	target := targets[0]
+	assert.Equal(t, "development/default/daemonset/agent/node/node-1", target.Id)
+	assert.Equal(t, "agent (node-1)", target.Label)
+	assert.Equal(t, WorkloadShardTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":             {"default"},
+		"k8s.daemonset":             {"agent"},
+		"k8s.workload-type":         {"daemonset"},
+		"k8s.workload-owner":        {"agent"},
+		"k8s.workload-shard":        {"node/node-1"},
+		"k8s.cluster-name":          {"development"},
+		"k8s.distribution":          {"kubernetes"},
+		"k8s.daemonset.label.team":  {"infra"},
+		"k8s.daemonset.label":       {"team"},
+		"k8s.label.team":            {"infra"},
+		"k8s.label":                 {"team"},
+		"k8s.pod.name":              {"agent-1"},
+		"k8s.container.id":          {"containerd://agent-1"},
+		"k8s.container.id.stripped": {"agent-1"},
+		"host.hostname":             {"node-1"},
+		"host.domainname":           {"node-1"},
+	}, target.Attributes)
+	assert.Equal(t, "development/default/daemonset/agent/node/node-3", targets[2].Id)
+}
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extzone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extnode"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	zoneOutageModeCordon = "cordon"
+	zoneOutageModeTaint  = "taint"
+	zoneOutageModeDrain  = "drain"
+)
+
+type ZoneOutageAction struct {
+	k8s *client.Client
+}
+
+type ZoneOutageState struct {
+	Zone  string
+	Mode  string
+	Nodes []ZoneOutageNode
+}
+
+// ZoneOutageNode tracks the changes done to a node, only these are reverted when the attack stops.
+type ZoneOutageNode struct {
+	Name     string
+	Cordoned bool
+	Tainted  bool
+	Drain    extnode.DrainProgress
+}
+
+type ZoneOutageConfig struct {
+	Mode             string
+	MaxCapacityShare int
+}
+
+func NewZoneOutageAction(k8s *client.Client) action_kit_sdk.Action[ZoneOutageState] {
+	return &ZoneOutageAction{k8s: k8s}
+}
+
+var _ action_kit_sdk.Action[ZoneOutageState] = (*ZoneOutageAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[ZoneOutageState] = (*ZoneOutageAction)(nil)
+var _ action_kit_sdk.ActionWithStop[ZoneOutageState] = (*ZoneOutageAction)(nil)
+
+func (a *ZoneOutageAction) NewEmptyState() ZoneOutageState {
+	return ZoneOutageState{}
+}
+
+func (a *ZoneOutageAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          ZoneOutageActionId,
+		Label:       "Zone Outage",
+		Description: "Simulates the outage of an availability zone by cordoning, tainting or draining all of its nodes.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new("data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20d%3D%22M11.9436%207.04563C12.1262%206.98477%2012.3235%206.98477%2012.5061%207.04563L17.8407%208.82395C18.2037%208.94498%2018.4486%209.28468%2018.4485%209.66728C18.4485%2010.0499%2018.2036%2010.3895%2017.8405%2010.5105L12.5059%2012.2877C12.3235%2012.3485%2012.1262%2012.3485%2011.9438%2012.2877L6.60918%2010.5105C6.24611%2010.3895%206.00119%2010.0499%206.00116%209.66728C6.00112%209.28468%206.24598%208.94498%206.60902%208.82395L11.9436%207.04563Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M7.20674%2013.2736C6.68268%2013.0989%206.11622%2013.3821%205.94153%2013.9062C5.76684%2014.4302%206.05007%2014.9967%206.57414%2015.1714L11.9087%2016.9496C12.114%2017.018%2012.336%2017.018%2012.5413%2016.9496L17.8759%2015.1714C18.4%2014.9967%2018.6832%2014.4302%2018.5085%2013.9062C18.3338%2013.3821%2017.7674%2013.0989%2017.2433%2013.2736L12.225%2014.9463L7.20674%2013.2736Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M11.6491%201.06354C11.8754%200.97882%2012.1246%200.97882%2012.3509%201.06354L22.3506%204.80836C22.7412%204.95463%2023%205.32784%2023%205.74482V18.2552C23%2018.6722%2022.7412%2019.0454%2022.3506%2019.1916L12.3509%2022.9365C12.1246%2023.0212%2011.8754%2023.0212%2011.6491%2022.9365L1.64938%2019.1916C1.2588%2019.0454%201%2018.6722%201%2018.2552V5.74482C1%205.32784%201.2588%204.95463%201.64938%204.80836L11.6491%201.06354ZM3.00047%206.43809V17.5619L12%2020.9321L20.9995%2017.5619V6.43809L12%203.06785L3.00047%206.43809Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"),
+		Technology:  new("Kubernetes"),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType:          ZoneTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.QuantityRestrictionExactlyOne),
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "zone",
+					Description: new("Find zone by cluster and name"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.zone=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "Duration",
+				Name:         "duration",
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				Description:  new("The duration of the outage. All nodes are restored afterwards."),
+				Required:     new(true),
+				DefaultValue: new("180s"),
+				Order:        new(0),
+			},
+			{
+				Label:        "Mode",
+				Name:         "mode",
+				Type:         action_kit_api.ActionParameterTypeString,
+				Description:  new("Cordon prevents new pods from being scheduled, the NoExecute taint additionally terminates running pods immediately, drain evicts them respecting PodDisruptionBudgets."),
+				Required:     new(true),
+				DefaultValue: new(zoneOutageModeDrain),
+				Order:        new(1),
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "Cordon",
+						Value: zoneOutageModeCordon,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Taint with NoExecute",
+						Value: zoneOutageModeTaint,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Drain",
+						Value: zoneOutageModeDrain,
+					},
+				}),
+			},
+			{
+				Label:        "Max capacity share",
+				Name:         "maxCapacityShare",
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				Description:  new("Safety limit: the attack is refused if the zone provides a larger share of the cluster's allocatable CPU."),
+				Required:     new(true),
+				DefaultValue: new("50"),
+				MinValue:     new(0),
+				MaxValue:     new(100),
+				Order:        new(2),
+				Advanced:     new(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *ZoneOutageAction) Prepare(_ context.Context, state *ZoneOutageState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config ZoneOutageConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	switch config.Mode {
+	case zoneOutageModeCordon, zoneOutageModeTaint, zoneOutageModeDrain:
+	default:
+		return nil, extension_kit.ToError(fmt.Sprintf("Unknown mode: %s", config.Mode), nil)
+	}
+
+	zone := request.Target.Attributes[zoneAttribute][0]
+	nodes := nodesOfZone(a.k8s, zone)
+	if len(nodes) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Zone %s has no nodes.", zone), nil)
+	}
+	if share := capacityShare(nodes, a.k8s.Nodes()); share > float64(config.MaxCapacityShare) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Zone %s provides %.0f%% of the cluster capacity, which exceeds the limit of %d%%.", zone, share, config.MaxCapacityShare), nil)
+	}
+
+	state.Zone = zone
+	state.Mode = config.Mode
+	state.Nodes = make([]ZoneOutageNode, 0, len(nodes))
+	for _, node := range nodes {
+		state.Nodes = append(state.Nodes, ZoneOutageNode{Name: node.Name})
+	}
+	return nil, nil
+}
+
+// capacityShare returns the percentage of the allocatable CPU of all nodes provided by the given nodes. If no node
+// reports allocatable CPU the share of the node count is used.
+func capacityShare(nodes []*corev1.Node, allNodes []*corev1.Node) float64 {
+	var part, total int64
+	for _, node := range allNodes {
+		total += node.Status.Allocatable.Cpu().MilliValue()
+	}
+	for _, node := range nodes {
+		part += node.Status.Allocatable.Cpu().MilliValue()
+	}
+	if total == 0 {
+		if len(allNodes) == 0 {
+			return 100
+		}
+		return float64(len(nodes)) * 100 / float64(len(allNodes))
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+func (a *ZoneOutageAction) Start(ctx context.Context, state *ZoneOutageState) (*action_kit_api.StartResult, error) {
+	var messages []action_kit_api.Message
+	for i := range state.Nodes {
+		node := &state.Nodes[i]
+		var err error
+		switch state.Mode {
+		case zoneOutageModeCordon, zoneOutageModeDrain:
+			node.Cordoned, err = a.k8s.SetNodeUnschedulable(ctx, node.Name, true)
+			if err == nil && node.Cordoned {
+				messages = append(messages, extnode.NodeMessage(node.Name, "cordoned", action_kit_api.Info))
+			} else if err == nil {
+				messages = append(messages, extnode.NodeMessage(node.Name, "already cordoned, it stays cordoned after the action", action_kit_api.Info))
+			}
+		case zoneOutageModeTaint:
+			taint := zoneOutageTaint(state.Zone)
+			node.Tainted, err = a.k8s.AddNodeTaint(ctx, node.Name, taint)
+			if err == nil && node.Tainted {
+				messages = append(messages, extnode.NodeMessage(node.Name, "tainted with "+taint.ToString(), action_kit_api.Info))
+			} else if err == nil {
+				messages = append(messages, extnode.NodeMessage(node.Name, "already tainted with "+taint.ToString(), action_kit_api.Info))
+			}
+		}
+		if err != nil {
+			// nodes changed so far are restored by stop
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to start the outage of zone %s.", state.Zone), err)
+		}
+	}
+	return &action_kit_api.StartResult{Messages: &messages}, nil
+}
+
+func (a *ZoneOutageAction) Status(ctx context.Context, state *ZoneOutageState) (*action_kit_api.StatusResult, error) {
+	if state.Mode != zoneOutageModeDrain {
+		return &action_kit_api.StatusResult{}, nil
+	}
+
+	var messages []action_kit_api.Message
+	for i := range state.Nodes {
+		node := &state.Nodes[i]
+		nodeMessages, err := node.Drain.Step(ctx, a.k8s, node.Name, extnode.DrainOptions{})
+		if err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to drain node %s.", node.Name), err)
+		}
+		messages = append(messages, nodeMessages...)
+	}
+	return &action_kit_api.StatusResult{Messages: &messages}, nil
+}
+
+func (a *ZoneOutageAction) Stop(ctx context.Context, state *ZoneOutageState) (*action_kit_api.StopResult, error) {
+	var messages []action_kit_api.Message
+	var errs []error
+	for i := range state.Nodes {
+		node := &state.Nodes[i]
+		if node.Tainted {
+			if _, err := a.k8s.RemoveNodeTaint(ctx, node.Name, zoneOutageTaint(state.Zone)); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			node.Tainted = false
+			messages = append(messages, extnode.NodeMessage(node.Name, "taint removed", action_kit_api.Info))
+		}
+		if node.Cordoned {
+			if _, err := a.k8s.SetNodeUnschedulable(ctx, node.Name, false); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			node.Cordoned = false
+			messages = append(messages, extnode.NodeMessage(node.Name, "uncordoned", action_kit_api.Info))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore the nodes of zone %s.", state.Zone), errors.Join(errs...))
+	}
+	return &action_kit_api.StopResult{Messages: &messages}, nil
+}
+
+func zoneOutageTaint(zone string) corev1.Taint {
+	return corev1.Taint{
+		Key:    zoneOutageTaintKey,
+		Value:  zone,
+		Effect: corev1.TaintEffectNoExecute,
+	}
+}
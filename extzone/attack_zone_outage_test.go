@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extzone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+const (
+	defaultTimeout = 2 * time.Second
+	defaultTick    = 10 * time.Millisecond
+)
+
+func TestPrepareRefusesZoneAboveCapacityLimit(t *testing.T) {
+	// Given
+	k8s := createTestClient(t, testclient.NewClientset(
+		zoneNode("node-a1", "eu-central-1a", "6"),
+		zoneNode("node-b1", "eu-central-1b", "4"),
+	))
+	require.Eventually(t, func() bool { return len(k8s.Nodes()) == 2 }, defaultTimeout, defaultTick)
+	action := &ZoneOutageAction{k8s: k8s}
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, zoneOutageRequest("eu-central-1a", zoneOutageModeDrain, 50))
+
+	// Then
+	require.ErrorContains(t, err, "Zone eu-central-1a provides 60% of the cluster capacity, which exceeds the limit of 50%.")
+
+	// When
+	_, err = action.Prepare(context.Background(), &state, zoneOutageRequest("eu-central-1b", zoneOutageModeDrain, 50))
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, []ZoneOutageNode{{Name: "node-b1"}}, state.Nodes)
+}
+
+func TestPrepareSkipsNodesExcludedFromDiscovery(t *testing.T) {
+	// Given
+	excluded := zoneNode("node-a2", "eu-central-1a", "4")
+	excluded.Labels["steadybit.com.discovery-disabled"] = "true"
+	k8s := createTestClient(t, testclient.NewClientset(
+		zoneNode("node-a1", "eu-central-1a", "4"),
+		excluded,
+		zoneNode("node-b1", "eu-central-1b", "4"),
+	))
+	require.Eventually(t, func() bool { return len(k8s.Nodes()) == 3 }, defaultTimeout, defaultTick)
+	action := &ZoneOutageAction{k8s: k8s}
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, zoneOutageRequest("eu-central-1a", zoneOutageModeCordon, 100))
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, []ZoneOutageNode{{Name: "node-a1"}}, state.Nodes)
+}
+
+func TestZoneOutageTaintsAndRestoresNodes(t *testing.T) {
+	// Given
+	clientset := testclient.NewClientset(
+		zoneNode("node-a1", "eu-central-1a", "4"),
+		zoneNode("node-b1", "eu-central-1b", "4"),
+	)
+	k8s := createTestClient(t, clientset)
+	require.Eventually(t, func() bool { return len(k8s.Nodes()) == 2 }, defaultTimeout, defaultTick)
+	action := &ZoneOutageAction{k8s: k8s}
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, zoneOutageRequest("eu-central-1a", zoneOutageModeTaint, 50))
+	require.NoError(t, err)
+
+	// When
+	result, err := action.Start(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "Node node-a1 tainted with steadybit.com/zone-outage=eu-central-1a:NoExecute", (*result.Messages)[0].Message)
+	node, _ := clientset.CoreV1().Nodes().Get(context.Background(), "node-a1", metav1.GetOptions{})
+	require.Len(t, node.Spec.Taints, 1)
+	assert.False(t, node.Spec.Unschedulable)
+
+	// When
+	stopResult, err := action.Stop(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "Node node-a1 taint removed", (*stopResult.Messages)[0].Message)
+	node, _ = clientset.CoreV1().Nodes().Get(context.Background(), "node-a1", metav1.GetOptions{})
+	require.Empty(t, node.Spec.Taints)
+}
+
+func TestZoneOutageDrainsNodesAndReportsBlockedPods(t *testing.T) {
+	// Given
+	cordoned := zoneNode("node-a2", "eu-central-1a", "4")
+	cordoned.Spec.Unschedulable = true
+	clientset := testclient.NewClientset(
+		zoneNode("node-a1", "eu-central-1a", "4"),
+		cordoned,
+		zoneNode("node-b1", "eu-central-1b", "4"),
+		zonePod("checkout", "node-a1", nil),
+		zonePod("cart", "node-a1", nil),
+		zonePod("fluentd", "node-a1", []metav1.OwnerReference{{Kind: "DaemonSet", Name: "fluentd", Controller: new(true)}}),
+	)
+	pdbBlocked := true
+	clientset.PrependReactor("create", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		name := action.(k8sTesting.CreateAction).GetObject().(metav1.Object).GetName()
+		if name == "cart" && pdbBlocked {
+			return true, nil, k8sErrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		return true, nil, clientset.Tracker().Delete(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "shop", name)
+	})
+	k8s := createTestClient(t, clientset)
+	require.Eventually(t, func() bool { return len(k8s.Nodes()) == 3 }, defaultTimeout, defaultTick)
+	action := &ZoneOutageAction{k8s: k8s}
+	state := action.NewEmptyState()
+	_, err := action.Prepare(context.Background(), &state, zoneOutageRequest("eu-central-1a", zoneOutageModeDrain, 100))
+	require.NoError(t, err)
+
+	// When
+	startResult, err := action.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Node node-a1 cordoned",
+		"Node node-a2 already cordoned, it stays cordoned after the action",
+	}, messageTexts(startResult.Messages))
+	status, err := action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Node node-a1 eviction of pod shop/cart blocked by PodDisruptionBudget, retrying",
+		"Node node-a1 evicted pod shop/checkout",
+		"Node node-a2 drained",
+	}, messageTexts(status.Messages))
+	assert.True(t, state.Nodes[0].Cordoned)
+	assert.False(t, state.Nodes[1].Cordoned, "node-a2 was cordoned before")
+
+	// When
+	pdbBlocked = false
+	status, err = action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Node node-a1 evicted pod shop/cart after 2 attempts"}, messageTexts(status.Messages))
+
+	// When
+	status, err = action.Status(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Node node-a1 drained"}, messageTexts(status.Messages))
+
+	// When
+	_, err = action.Stop(context.Background(), &state)
+
+	// Then
+	require.NoError(t, err)
+	node, _ := clientset.CoreV1().Nodes().Get(context.Background(), "node-a1", metav1.GetOptions{})
+	assert.False(t, node.Spec.Unschedulable)
+	node, _ = clientset.CoreV1().Nodes().Get(context.Background(), "node-a2", metav1.GetOptions{})
+	assert.True(t, node.Spec.Unschedulable, "node-a2 must stay cordoned")
+}
+
+func zoneOutageRequest(zone, mode string, maxCapacityShare int) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"duration":         1000 * 60,
+			"mode":             mode,
+			"maxCapacityShare": maxCapacityShare,
+		},
+		Target: &action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.zone": {zone},
+			},
+		},
+	}
+}
+
+func zonePod(name, nodeName string, owners []metav1.OwnerReference) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", OwnerReferences: owners},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func messageTexts(messages *action_kit_api.Messages) []string {
+	var texts []string
+	for _, message := range *messages {
+		texts = append(texts, message.Message)
+	}
+	return texts
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extzone
+
+import (
+	"slices"
+
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	ZoneTargetType         = "com.steadybit.extension_kubernetes.kubernetes-zone"
+	ZoneOutageActionId     = "com.steadybit.extension_kubernetes.zone_outage"
+	zoneLabel              = corev1.LabelTopologyZone
+	regionLabel            = corev1.LabelTopologyRegion
+	zoneOutageTaintKey     = "steadybit.com/zone-outage"
+	zoneAttribute          = "k8s.zone"
+	regionAttribute        = "k8s.region"
+	zoneNodeNamesAttribute = "k8s.node.name"
+)
+
+// nodesOfZone returns the nodes labeled with the given zone, sorted by name. Nodes excluded from discovery are skipped.
+func nodesOfZone(k8s *client.Client, zone string) []*corev1.Node {
+	var nodes []*corev1.Node
+	for _, node := range k8s.Nodes() {
+		if node.Labels[zoneLabel] == zone && !client.IsExcludedFromDiscovery(node.ObjectMeta) {
+			nodes = append(nodes, node)
+		}
+	}
+	slices.SortFunc(nodes, func(a, b *corev1.Node) int {
+		if a.Name < b.Name {
+			return -1
+		} else if a.Name > b.Name {
+			return 1
+		}
+		return 0
+	})
+	return nodes
+}
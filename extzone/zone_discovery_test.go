@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extzone
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kubernetes/v2/client"
+	"github.com/steadybit/extension-kubernetes/v2/extconfig"
+	"github.com/steadybit/extension-kubernetes/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_zoneDiscovery(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	k8s := createTestClient(t, testclient.NewClientset(
+		zoneNode("node-a1", "eu-central-1a", "4"),
+		zoneNode("node-a2", "eu-central-1a", "4"),
+		zoneNode("node-b1", "eu-central-1b", "4"),
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "no-zone"}},
+	))
+	d := &zoneDiscovery{k8s: k8s}
+
+	// When
+	require.Eventually(t, func() bool { return len(k8s.Nodes()) == 4 }, defaultTimeout, defaultTick)
+	targets, err := d.DiscoverTargets(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	slices.SortFunc(targets, func(a, b discovery_kit_api.Target) int { return strings.Compare(a.Id, b.Id) })
+	assert.Equal(t, "development/eu-central-1a", targets[0].Id)
+	assert.Equal(t, "eu-central-1a", targets[0].Label)
+	assert.Equal(t, ZoneTargetType, targets[0].TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.zone":         {"eu-central-1a"},
+		"k8s.region":       {"eu-central-1"},
+		"k8s.node.name":    {"node-a1", "node-a2"},
+		"k8s.cluster-name": {"development"},
+		"k8s.distribution": {"kubernetes"},
+	}, targets[0].Attributes)
+	assert.Equal(t, []string{"node-b1"}, targets[1].Attributes["k8s.node.name"])
+}
+
+func createTestClient(t *testing.T, clientset kubernetes.Interface) *client.Client {
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	return client.CreateClient(clientset, stopCh, "", client.MockAllPermitted(), testutil.NewFakeDynamicClient())
+}
+
+func zoneNode(name, zone, cpu string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				corev1.LabelTopologyZone:   zone,
+				corev1.LabelTopologyRegion: zone[:len(zone)-1],
+			},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+		},
+	}
+}
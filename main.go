@@ -46,13 +46,20 @@ import (
 	"github.com/steadybit/extension-kubernetes/v2/extcontainer"
 	"github.com/steadybit/extension-kubernetes/v2/extdaemonset"
 	"github.com/steadybit/extension-kubernetes/v2/extdeployment"
+	"github.com/steadybit/extension-kubernetes/v2/extdiff"
 	"github.com/steadybit/extension-kubernetes/v2/extenvoygateway"
 	"github.com/steadybit/extension-kubernetes/v2/extevents"
+	"github.com/steadybit/extension-kubernetes/v2/extgatewayapi"
 	"github.com/steadybit/extension-kubernetes/v2/extingress"
+	"github.com/steadybit/extension-kubernetes/v2/extistio"
+	"github.com/steadybit/extension-kubernetes/v2/extmetrics"
 	"github.com/steadybit/extension-kubernetes/v2/extnode"
 	"github.com/steadybit/extension-kubernetes/v2/extpod"
 	"github.com/steadybit/extension-kubernetes/v2/extreplicaset"
 	"github.com/steadybit/extension-kubernetes/v2/extstatefulset"
+	"github.com/steadybit/extension-kubernetes/v2/exttraefik"
+	"github.com/steadybit/extension-kubernetes/v2/extworkloadshard"
+	"github.com/steadybit/extension-kubernetes/v2/extzone"
 )
 
 func main() {
@@ -84,9 +91,51 @@ func main() {
 
 	if !extconfig.Config.DiscoveryDisabledEnvoyGateway && !extconfig.HasNamespaceFilter() && client.K8S.Permissions().IsListEnvoyGatewayHttpRoutesPermitted() {
 		discovery_kit_sdk.Register(extenvoygateway.NewHttpRouteDiscovery(client.K8S))
+		action_kit_sdk.RegisterAction(extdiff.NewHttpRouteDiffAction())
 		if client.K8S.Permissions().IsModifyBackendTrafficPolicyPermitted() {
 			action_kit_sdk.RegisterAction(extenvoygateway.NewDelayAction(client.K8S))
 			action_kit_sdk.RegisterAction(extenvoygateway.NewAbortAction(client.K8S))
+			action_kit_sdk.RegisterAction(extenvoygateway.NewRateLimitAction(client.K8S))
+			action_kit_sdk.RegisterAction(extenvoygateway.NewTimeoutAction(client.K8S))
+			action_kit_sdk.RegisterAction(extenvoygateway.NewDisableRetriesAction(client.K8S))
+			action_kit_sdk.RegisterAction(extenvoygateway.NewCircuitBreakerAction(client.K8S))
+		}
+	}
+
+	if !extconfig.Config.DiscoveryDisabledEnvoyGateway && !extconfig.HasNamespaceFilter() && client.K8S.Permissions().IsListEnvoyGatewayGrpcRoutesPermitted() {
+		discovery_kit_sdk.Register(extenvoygateway.NewGrpcRouteDiscovery(client.K8S))
+		if client.K8S.Permissions().IsModifyBackendTrafficPolicyPermitted() {
+			action_kit_sdk.RegisterAction(extenvoygateway.NewGrpcDelayAction(client.K8S))
+			action_kit_sdk.RegisterAction(extenvoygateway.NewGrpcAbortAction(client.K8S))
+		}
+	}
+
+	if !extconfig.Config.DiscoveryDisabledGatewayApi && !extconfig.HasNamespaceFilter() && client.K8S.Permissions().IsListGatewayApiHttpRoutesPermitted() {
+		discovery_kit_sdk.Register(extgatewayapi.NewHttpRouteDiscovery(client.K8S))
+		if client.K8S.Permissions().IsModifyHttpRoutesPermitted() {
+			action_kit_sdk.RegisterAction(extgatewayapi.NewBlackholeAction(client.K8S))
+			action_kit_sdk.RegisterAction(extgatewayapi.NewHeaderModifierAction(client.K8S))
+		}
+	}
+
+	if !extconfig.Config.DiscoveryDisabledTraefik && !extconfig.HasNamespaceFilter() && client.K8S.Permissions().IsListTraefikIngressRoutesPermitted() {
+		discovery_kit_sdk.Register(exttraefik.NewRouteDiscovery(client.K8S))
+		if client.K8S.Permissions().IsModifyTraefikMiddlewarePermitted() {
+			action_kit_sdk.RegisterAction(exttraefik.NewRateLimitAction(client.K8S))
+			// The delay and abort Middlewares call back into the extension, which Traefik must be able to reach.
+			if extconfig.Config.TraefikFaultUrl != "" {
+				exttraefik.RegisterFaultEndpoint()
+				action_kit_sdk.RegisterAction(exttraefik.NewDelayAction(client.K8S))
+				action_kit_sdk.RegisterAction(exttraefik.NewAbortAction(client.K8S))
+			}
+		}
+	}
+
+	if !extconfig.Config.DiscoveryDisabledIstio && client.K8S.Permissions().IsListIstioVirtualServicesPermitted() {
+		discovery_kit_sdk.Register(extistio.NewVirtualServiceDiscovery(client.K8S))
+		if client.K8S.Permissions().IsModifyIstioVirtualServicesPermitted() {
+			action_kit_sdk.RegisterAction(extistio.NewDelayAction(client.K8S))
+			action_kit_sdk.RegisterAction(extistio.NewAbortAction(client.K8S))
 		}
 	}
 
@@ -94,6 +143,11 @@ func main() {
 		discovery_kit_sdk.Register(extdeployment.NewDeploymentDiscovery(client.K8S))
 		action_kit_sdk.RegisterAction(extdeployment.NewCheckDeploymentRolloutStatusAction())
 		action_kit_sdk.RegisterAction(extdeployment.NewDeploymentPodCountCheckAction(client.K8S))
+		action_kit_sdk.RegisterAction(extevents.NewDeploymentEventsAction())
+		action_kit_sdk.RegisterAction(extdiff.NewDeploymentDiffAction())
+		if client.K8S.Permissions().CanReadPodMetrics() {
+			action_kit_sdk.RegisterAction(extmetrics.NewDeploymentResourceUsageMetricsAction())
+		}
 
 		if client.K8S.Permissions().IsRolloutRestartPermitted() {
 			action_kit_sdk.RegisterAction(extdeployment.NewDeploymentRolloutRestartAction())
@@ -118,6 +172,7 @@ func main() {
 
 	if !extconfig.Config.DiscoveryDisabledPod {
 		discovery_kit_sdk.Register(extpod.NewPodDiscovery(client.K8S))
+		action_kit_sdk.RegisterAction(extevents.NewPodEventsAction())
 		if client.K8S.Permissions().IsDeletePodPermitted() {
 			action_kit_sdk.RegisterAction(extpod.NewDeletePodAction())
 		}
@@ -129,6 +184,11 @@ func main() {
 	if !extconfig.Config.DiscoveryDisabledStatefulSet {
 		discovery_kit_sdk.Register(extstatefulset.NewStatefulSetDiscovery(client.K8S))
 		action_kit_sdk.RegisterAction(extstatefulset.NewStatefulSetPodCountCheckAction(client.K8S))
+		action_kit_sdk.RegisterAction(extevents.NewStatefulSetEventsAction())
+		action_kit_sdk.RegisterAction(extdiff.NewStatefulSetDiffAction())
+		if client.K8S.Permissions().CanReadPodMetrics() {
+			action_kit_sdk.RegisterAction(extmetrics.NewStatefulSetResourceUsageMetricsAction())
+		}
 		if client.K8S.Permissions().IsScaleStatefulSetPermitted() {
 			action_kit_sdk.RegisterAction(extstatefulset.NewScaleStatefulSetAction())
 		}
@@ -137,26 +197,52 @@ func main() {
 	if !extconfig.Config.DiscoveryDisabledDaemonSet {
 		discovery_kit_sdk.Register(extdaemonset.NewDaemonSetDiscovery(client.K8S))
 		action_kit_sdk.RegisterAction(extdaemonset.NewDaemonSetPodCountCheckAction(client.K8S))
+		action_kit_sdk.RegisterAction(extevents.NewDaemonSetEventsAction())
+		action_kit_sdk.RegisterAction(extdiff.NewDaemonSetDiffAction())
+		if client.K8S.Permissions().CanReadPodMetrics() {
+			action_kit_sdk.RegisterAction(extmetrics.NewDaemonSetResourceUsageMetricsAction())
+		}
+	}
+
+	if !extconfig.Config.DiscoveryDisabledWorkloadShard {
+		discovery_kit_sdk.Register(extworkloadshard.NewWorkloadShardDiscovery(client.K8S))
 	}
 
 	if !extconfig.Config.DiscoveryDisabledIngress && client.K8S.Permissions().IsListIngressPermitted() && client.K8S.Permissions().IsListIngressClassesPermitted() && client.K8S.Permissions().IsModifyIngressPermitted() && !extconfig.HasNamespaceFilter() {
 		discovery_kit_sdk.Register(extingress.NewIngressDiscovery(client.K8S))
 		action_kit_sdk.RegisterAction(extingress.NewHAProxyBlockTrafficAction())
 		action_kit_sdk.RegisterAction(extingress.NewHAProxyDelayTrafficAction())
+		action_kit_sdk.RegisterAction(extdiff.NewHAProxyIngressDiffAction())
 		discovery_kit_sdk.Register(extingress.NewNginxIngressDiscovery(client.K8S))
 		action_kit_sdk.RegisterAction(extingress.NewNginxBlockTrafficAction())
 		action_kit_sdk.RegisterAction(extingress.NewNginxDelayTrafficAction())
+		action_kit_sdk.RegisterAction(extdiff.NewNginxIngressDiffAction())
 	}
 
 	if !extconfig.Config.DiscoveryDisabledNode && !extconfig.HasNamespaceFilter() {
 		discovery_kit_sdk.Register(extnode.NewNodeDiscovery(client.K8S))
 		action_kit_sdk.RegisterAction(extnode.NewNodeCountCheckAction())
+		action_kit_sdk.RegisterAction(extnode.NewNodeConditionCheckAction())
+		action_kit_sdk.RegisterAction(extnode.NewClusterNodeConditionCheckAction())
+		action_kit_sdk.RegisterAction(extevents.NewNodeEventsAction())
+		action_kit_sdk.RegisterAction(extdiff.NewNodeDiffAction())
 
 		if client.K8S.Permissions().IsDrainNodePermitted() {
-			action_kit_sdk.RegisterAction(extnode.NewDrainNodeAction())
+			action_kit_sdk.RegisterAction(extnode.NewDrainNodeAction(client.K8S))
 		}
 		if client.K8S.Permissions().IsTaintNodePermitted() {
-			action_kit_sdk.RegisterAction(extnode.NewTaintNodeAction())
+			action_kit_sdk.RegisterAction(extnode.NewTaintNodeAction(client.K8S))
+		}
+		if client.K8S.Permissions().IsNodeLabelPermitted() {
+			action_kit_sdk.RegisterAction(extnode.NewNodeLabelAction(client.K8S))
+		}
+		if client.K8S.Permissions().IsCapacitySqueezePermitted() {
+			action_kit_sdk.RegisterAction(extnode.NewCapacitySqueezeAction(client.K8S))
+		}
+
+		discovery_kit_sdk.Register(extzone.NewZoneDiscovery(client.K8S))
+		if client.K8S.Permissions().IsDrainNodePermitted() {
+			action_kit_sdk.RegisterAction(extzone.NewZoneOutageAction(client.K8S))
 		}
 	}
 
@@ -168,6 +254,12 @@ func main() {
 		discovery_kit_sdk.Register(extcluster.NewClusterDiscovery())
 		action_kit_sdk.RegisterAction(extdeployment.NewPodCountMetricsAction())
 		action_kit_sdk.RegisterAction(extevents.NewK8sEventsAction())
+		if client.K8S.Permissions().CanReadPodMetrics() {
+			action_kit_sdk.RegisterAction(extmetrics.NewClusterResourceUsageMetricsAction())
+		}
+		if client.K8S.Permissions().IsPreemptionPermitted() {
+			action_kit_sdk.RegisterAction(extnode.NewPreemptionAction(client.K8S))
+		}
 	}
 
 	discovery_kit_sdk.Register(extcommon.NewAttributeDescriber())
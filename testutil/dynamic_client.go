@@ -23,15 +23,21 @@ const gatewayNetworkingGroup = "gateway.networking.k8s.io"
 var defaultListKinds = map[schema.GroupVersionResource]string{
 	{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}:                         "RolloutList",
 	{Group: gatewayNetworkingGroup, Version: "v1", Resource: "httproutes"}:                    "HTTPRouteList",
+	{Group: gatewayNetworkingGroup, Version: "v1", Resource: "grpcroutes"}:                    "GRPCRouteList",
 	{Group: gatewayNetworkingGroup, Version: "v1", Resource: "gateways"}:                      "GatewayList",
 	{Group: gatewayNetworkingGroup, Version: "v1", Resource: "gatewayclasses"}:                "GatewayClassList",
 	{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Resource: "backendtrafficpolicies"}: "BackendTrafficPolicyList",
+	{Group: "traefik.io", Version: "v1alpha1", Resource: "ingressroutes"}:                     "IngressRouteList",
+	{Group: "traefik.io", Version: "v1alpha1", Resource: "middlewares"}:                       "MiddlewareList",
+	{Group: "networking.istio.io", Version: "v1beta1", Resource: "virtualservices"}:           "VirtualServiceList",
+	{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}:                           "PodMetricsList",
+	{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}:                          "NodeMetricsList",
 }
 
 // NewFakeDynamicClient creates a fake dynamic client. With no arguments it registers every CRD the
-// extension may watch (Argo Rollouts and the Envoy Gateway resources) so that a client built from it
-// does not panic when the corresponding informers LIST. To register only specific types instead,
-// pass them as arguments:
+// extension may watch (Argo Rollouts, the Envoy Gateway, Traefik and Istio resources) and the metrics.k8s.io resources,
+// so that a client built from it does not panic when the corresponding informers LIST. To register
+// only specific types instead, pass them as arguments:
 //
 //	NewFakeDynamicClient(
 //		schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "CustomResource"},